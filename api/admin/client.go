@@ -5,11 +5,15 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/f01c5700/avalanchego/api"
 	"github.com/f01c5700/avalanchego/database/rpcdb"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman"
 	"github.com/f01c5700/avalanchego/utils/formatting"
+	"github.com/f01c5700/avalanchego/utils/json"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/utils/rpc"
 )
@@ -26,10 +30,16 @@ type Client interface {
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
 	Stacktrace(context.Context, ...rpc.Option) error
+	BenchNode(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	UnbenchNode(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	GetThrottlerConfig(ctx context.Context, options ...rpc.Option) (network.DynamicThrottlerConfig, error)
+	GetConsensusState(ctx context.Context, chain string, numRecentPolls uint32, options ...rpc.Option) (*snowman.ConsensusState, error)
+	GetPeerTimeouts(context.Context, ...rpc.Option) (*GetPeerTimeoutsReply, error)
+	SetThrottlerConfig(ctx context.Context, config network.DynamicThrottlerConfig, options ...rpc.Option) error
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
 }
 
@@ -87,6 +97,21 @@ func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
 
+func (c *client) BenchNode(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.benchNode", &BenchNodeArgs{
+		Chain:    chain,
+		NodeID:   nodeID,
+		Duration: duration,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbenchNode(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbenchNode", &UnbenchNodeArgs{
+		Chain:  chain,
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error) {
 	res := &LoadVMsReply{}
 	err := c.requester.SendRequest(ctx, "admin.loadVMs", struct{}{}, res, options...)
@@ -144,6 +169,31 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	return res, err
 }
 
+func (c *client) GetThrottlerConfig(ctx context.Context, options ...rpc.Option) (network.DynamicThrottlerConfig, error) {
+	var res network.DynamicThrottlerConfig
+	err := c.requester.SendRequest(ctx, "admin.getThrottlerConfig", struct{}{}, &res, options...)
+	return res, err
+}
+
+func (c *client) GetConsensusState(ctx context.Context, chain string, numRecentPolls uint32, options ...rpc.Option) (*snowman.ConsensusState, error) {
+	res := &snowman.ConsensusState{}
+	err := c.requester.SendRequest(ctx, "admin.getConsensusState", &GetConsensusStateArgs{
+		Chain:          chain,
+		NumRecentPolls: json.Uint32(numRecentPolls),
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetPeerTimeouts(ctx context.Context, options ...rpc.Option) (*GetPeerTimeoutsReply, error) {
+	res := &GetPeerTimeoutsReply{}
+	err := c.requester.SendRequest(ctx, "admin.getPeerTimeouts", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SetThrottlerConfig(ctx context.Context, config network.DynamicThrottlerConfig, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setThrottlerConfig", &config, &api.EmptyReply{}, options...)
+}
+
 func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error) {
 	keyStr, err := formatting.Encode(formatting.HexNC, key)
 	if err != nil {
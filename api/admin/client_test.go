@@ -12,6 +12,7 @@ import (
 
 	"github.com/f01c5700/avalanchego/api"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/utils/rpc"
 )
@@ -165,6 +166,16 @@ func TestStacktrace(t *testing.T) {
 	}
 }
 
+func TestSetThrottlerConfig(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.SetThrottlerConfig(context.Background(), network.DynamicThrottlerConfig{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestReloadInstalledVMs(t *testing.T) {
 	t.Run("successful", func(t *testing.T) {
 		require := require.New(t)
@@ -5,9 +5,11 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -18,6 +20,10 @@ import (
 	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/database/rpcdb"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman"
+	"github.com/f01c5700/avalanchego/snow/networking/benchlist"
+	"github.com/f01c5700/avalanchego/snow/networking/timeout"
 	"github.com/f01c5700/avalanchego/utils"
 	"github.com/f01c5700/avalanchego/utils/constants"
 	"github.com/f01c5700/avalanchego/utils/formatting"
@@ -39,8 +45,9 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong   = errors.New("alias length is too long")
+	errNoLogLevel     = errors.New("need to specify either displayLevel or logLevel")
+	errNodeNotBenched = errors.New("node is not benched")
 )
 
 type Config struct {
@@ -50,9 +57,12 @@ type Config struct {
 	NodeConfig   interface{}
 	DB           database.Database
 	ChainManager chains.Manager
+	Network      network.Network
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Benchlist    benchlist.Manager
+	Timeouts     timeout.Manager
 }
 
 // Admin is the API service for node admin management
@@ -214,6 +224,90 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// GetConsensusStateArgs are the arguments for calling GetConsensusState
+type GetConsensusStateArgs struct {
+	Chain string `json:"chain"`
+	// NumRecentPolls is the maximum number of recently finished polls to
+	// return.
+	NumRecentPolls json.Uint32 `json:"numRecentPolls"`
+}
+
+// GetConsensusState returns the processing blocks and polls of a snowman chain
+func (a *Admin) GetConsensusState(_ *http.Request, args *GetConsensusStateArgs, reply *snowman.ConsensusState) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensusState"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	state, err := a.ChainManager.ConsensusState(chainID, int(args.NumRecentPolls))
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, args.Chain)
+	}
+	*reply = state
+	return nil
+}
+
+// BenchNodeArgs are the arguments for calling BenchNode
+type BenchNodeArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration is the amount of time, in nanoseconds, to bench the node for.
+	Duration time.Duration `json:"duration"`
+}
+
+// BenchNode causes queries to [args.NodeID] on [args.Chain] to fail
+// immediately for [args.Duration]
+func (a *Admin) BenchNode(_ *http.Request, args *BenchNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "benchNode"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Duration("duration", args.Duration),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Bench(chainID, args.NodeID, args.Duration)
+}
+
+// UnbenchNodeArgs are the arguments for calling UnbenchNode
+type UnbenchNodeArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UnbenchNode removes [args.NodeID] from the benchlist of [args.Chain]
+func (a *Admin) UnbenchNode(_ *http.Request, args *UnbenchNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbenchNode"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	unbenched, err := a.Benchlist.Unbench(chainID, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if !unbenched {
+		return fmt.Errorf("%w: %s", errNodeNotBenched, args.NodeID)
+	}
+	return nil
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
@@ -320,6 +414,46 @@ func (a *Admin) GetConfig(_ *http.Request, _ *struct{}, reply *interface{}) erro
 	return nil
 }
 
+// GetThrottlerConfig returns the throttler limits that are currently in use.
+func (a *Admin) GetThrottlerConfig(_ *http.Request, _ *struct{}, reply *network.DynamicThrottlerConfig) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getThrottlerConfig"),
+	)
+	*reply = a.Network.ThrottlerConfig()
+	return nil
+}
+
+// GetPeerTimeoutsReply are the results from calling GetPeerTimeouts
+type GetPeerTimeoutsReply struct {
+	// NetworkTimeout is the timeout, in nanoseconds, of requests to peers
+	// without an estimated timeout.
+	NetworkTimeout time.Duration `json:"networkTimeout"`
+	// PeerTimeouts are ordered from least to most recently observed.
+	PeerTimeouts []timeout.PeerTimeout `json:"peerTimeouts"`
+}
+
+// GetPeerTimeouts returns the timeouts of requests sent to peers
+func (a *Admin) GetPeerTimeouts(_ *http.Request, _ *struct{}, reply *GetPeerTimeoutsReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getPeerTimeouts"),
+	)
+	reply.NetworkTimeout = a.Timeouts.TimeoutDuration()
+	reply.PeerTimeouts = a.Timeouts.PeerTimeouts()
+	return nil
+}
+
+// SetThrottlerConfig updates the provided throttler limits. Limits that are
+// not provided are left unchanged.
+func (a *Admin) SetThrottlerConfig(_ *http.Request, args *network.DynamicThrottlerConfig, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setThrottlerConfig"),
+	)
+	return a.Network.SetThrottlerConfig(*args)
+}
+
 // LoadVMsReply contains the response metadata for LoadVMs
 type LoadVMsReply struct {
 	// VMs and their aliases which were successfully loaded
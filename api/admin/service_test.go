@@ -6,12 +6,18 @@ package admin
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/f01c5700/avalanchego/chains"
 	"github.com/f01c5700/avalanchego/database/memdb"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/message"
+	"github.com/f01c5700/avalanchego/snow/networking/benchlist"
+	"github.com/f01c5700/avalanchego/snow/networking/timeout"
+	"github.com/f01c5700/avalanchego/snow/networking/timeout/timeoutmock"
 	"github.com/f01c5700/avalanchego/utils/formatting"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/vms/registry/registrymock"
@@ -167,3 +173,67 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestUnbenchNodeNotBenched(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.TestManager,
+		Benchlist:    benchlist.NewNoBenchlist(),
+	}}
+
+	err := a.UnbenchNode(
+		nil,
+		&UnbenchNodeArgs{
+			Chain:  ids.GenerateTestID().String(),
+			NodeID: ids.GenerateTestNodeID(),
+		},
+		nil,
+	)
+	require.ErrorIs(err, errNodeNotBenched)
+}
+
+func TestGetConsensusStateChainNotRunning(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.TestManager,
+	}}
+
+	err := a.GetConsensusState(
+		nil,
+		&GetConsensusStateArgs{
+			Chain: ids.GenerateTestID().String(),
+		},
+		nil,
+	)
+	require.ErrorIs(err, chains.ErrChainNotRunning)
+}
+
+func TestGetPeerTimeouts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	peerTimeouts := []timeout.PeerTimeout{
+		{
+			NodeID:  ids.GenerateTestNodeID(),
+			Op:      message.ChitsOp.String(),
+			Timeout: time.Second,
+		},
+	}
+	timeouts := timeoutmock.NewManager(ctrl)
+	timeouts.EXPECT().TimeoutDuration().Return(2 * time.Second)
+	timeouts.EXPECT().PeerTimeouts().Return(peerTimeouts)
+
+	a := &Admin{Config: Config{
+		Log:      logging.NoLog{},
+		Timeouts: timeouts,
+	}}
+
+	reply := &GetPeerTimeoutsReply{}
+	require.NoError(a.GetPeerTimeouts(nil, nil, reply))
+	require.Equal(2*time.Second, reply.NetworkTimeout)
+	require.Equal(peerTimeouts, reply.PeerTimeouts)
+}
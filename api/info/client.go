@@ -26,7 +26,9 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	GetBenchlist(context.Context, string, ...rpc.Option) ([]ChainBenchlist, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
+	PeerTrackerStats(context.Context, string, ...rpc.Option) ([]PeerTrackerStat, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
@@ -89,6 +91,14 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) GetBenchlist(ctx context.Context, chain string, options ...rpc.Option) ([]ChainBenchlist, error) {
+	res := &GetBenchlistReply{}
+	err := c.requester.SendRequest(ctx, "info.getBenchlist", &GetBenchlistArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Benchlists, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "info.isBootstrapped", &IsBootstrappedArgs{
@@ -97,6 +107,14 @@ func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...
 	return res.IsBootstrapped, err
 }
 
+func (c *client) PeerTrackerStats(ctx context.Context, chainID string, options ...rpc.Option) ([]PeerTrackerStat, error) {
+	res := &PeerTrackerStatsResponse{}
+	err := c.requester.SendRequest(ctx, "info.peerTrackerStats", &PeerTrackerStatsArgs{
+		Chain: chainID,
+	}, res, options...)
+	return res.Peers, err
+}
+
 func (c *client) GetTxFee(ctx context.Context, options ...rpc.Option) (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
 	err := c.requester.SendRequest(ctx, "info.getTxFee", struct{}{}, res, options...)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"slices"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -16,6 +17,7 @@ import (
 	"github.com/f01c5700/avalanchego/genesis"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/network/p2p"
 	"github.com/f01c5700/avalanchego/network/peer"
 	"github.com/f01c5700/avalanchego/snow/networking/benchlist"
 	"github.com/f01c5700/avalanchego/snow/validators"
@@ -252,6 +254,66 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// GetBenchlistArgs are the arguments for calling GetBenchlist
+type GetBenchlistArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	// If empty, the benchlists of all chains are returned
+	Chain string `json:"chain"`
+}
+
+// ChainBenchlist are the nodes benched on a chain
+type ChainBenchlist struct {
+	ChainID ids.ID                  `json:"chainID"`
+	Alias   string                  `json:"alias"`
+	Benched []benchlist.BenchedNode `json:"benched"`
+}
+
+// GetBenchlistReply are the results from calling GetBenchlist
+type GetBenchlistReply struct {
+	Benchlists []ChainBenchlist `json:"benchlists"`
+}
+
+// GetBenchlist returns the nodes that are currently benched on each chain
+func (i *Info) GetBenchlist(_ *http.Request, args *GetBenchlistArgs, reply *GetBenchlistReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getBenchlist"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	benchlists := i.benchlist.Benchlists()
+	if args.Chain != "" {
+		chainID, err := i.chainManager.Lookup(args.Chain)
+		if err != nil {
+			return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+		}
+		benchlists = map[ids.ID][]benchlist.BenchedNode{
+			chainID: benchlists[chainID],
+		}
+	}
+
+	reply.Benchlists = make([]ChainBenchlist, 0, len(benchlists))
+	for chainID, benched := range benchlists {
+		alias, err := i.chainManager.PrimaryAlias(chainID)
+		if err != nil {
+			return fmt.Errorf("failed to get primary alias for chain ID %s: %w", chainID, err)
+		}
+		if benched == nil {
+			benched = []benchlist.BenchedNode{}
+		}
+		reply.Benchlists = append(reply.Benchlists, ChainBenchlist{
+			ChainID: chainID,
+			Alias:   alias,
+			Benched: benched,
+		})
+	}
+	slices.SortFunc(reply.Benchlists, func(a, b ChainBenchlist) int {
+		return a.ChainID.Compare(b.ChainID)
+	})
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -285,6 +347,72 @@ func (i *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, reply *
 	return nil
 }
 
+// PeerTrackerStatsArgs are the arguments for calling PeerTrackerStats
+type PeerTrackerStatsArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// PeerTrackerStat is the performance observed from a peer
+type PeerTrackerStat struct {
+	NodeID      ids.NodeID   `json:"nodeID"`
+	Tracked     bool         `json:"tracked"`
+	Responsive  bool         `json:"responsive"`
+	Pending     bool         `json:"pending"`
+	Bandwidth   json.Float64 `json:"bandwidth"`
+	Latency     json.Uint64  `json:"latency"`
+	ErrorRate   json.Float64 `json:"errorRate"`
+	HybridScore json.Float64 `json:"hybridScore"`
+}
+
+// PeerTrackerStatsResponse are the results from calling PeerTrackerStats
+type PeerTrackerStatsResponse struct {
+	NumPeers json.Uint64       `json:"numPeers"`
+	Peers    []PeerTrackerStat `json:"peers"`
+}
+
+// PeerTrackerStats returns the performance that [args.Chain] has observed from
+// each of its connected peers.
+func (i *Info) PeerTrackerStats(_ *http.Request, args *PeerTrackerStatsArgs, reply *PeerTrackerStatsResponse) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "peerTrackerStats"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	if args.Chain == "" {
+		return errNoChainProvided
+	}
+	chainID, err := i.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	stats, ok := i.chainManager.PeerTrackerStats(chainID)
+	if !ok {
+		return fmt.Errorf("chain '%s' is not running", args.Chain)
+	}
+
+	slices.SortFunc(stats, func(a, b p2p.PeerStats) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	reply.Peers = make([]PeerTrackerStat, len(stats))
+	for j, stat := range stats {
+		reply.Peers[j] = PeerTrackerStat{
+			NodeID:      stat.NodeID,
+			Tracked:     stat.Tracked,
+			Responsive:  stat.Responsive,
+			Pending:     stat.Pending,
+			Bandwidth:   json.Float64(stat.Bandwidth),
+			Latency:     json.Uint64(stat.Latency),
+			ErrorRate:   json.Float64(stat.ErrorRate),
+			HybridScore: json.Float64(stat.HybridScore),
+		}
+	}
+	reply.NumPeers = json.Uint64(len(reply.Peers))
+	return nil
+}
+
 // Upgrades returns the upgrade schedule this node is running.
 func (i *Info) Upgrades(_ *http.Request, _ *struct{}, reply *upgrade.Config) error {
 	i.log.Debug("API called",
@@ -34,6 +34,7 @@ import (
 	"github.com/f01c5700/avalanchego/snow/engine/common"
 	"github.com/f01c5700/avalanchego/snow/engine/common/tracker"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/block"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/syncer"
 	"github.com/f01c5700/avalanchego/snow/networking/handler"
 	"github.com/f01c5700/avalanchego/snow/networking/router"
@@ -106,6 +107,9 @@ var (
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
 
+	ErrChainNotRunning    = errors.New("chain is not running")
+	ErrChainBootstrapping = errors.New("chain is bootstrapping")
+
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
 		nftfx.ID:       &nftfx.Factory{},
@@ -143,6 +147,15 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the peer performance observed by the chain with the given ID.
+	// Returns false if the chain doesn't exist.
+	PeerTrackerStats(ids.ID) ([]p2p.PeerStats, bool)
+
+	// Returns the consensus state of the chain with the given ID, including up
+	// to [numRecentPolls] recently finished polls. Returns an error if the
+	// chain doesn't exist or is still bootstrapping.
+	ConsensusState(chainID ids.ID, numRecentPolls int) (smeng.ConsensusState, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -171,6 +184,11 @@ type chain struct {
 	Context *snow.ConsensusContext
 	VM      common.VM
 	Handler handler.Handler
+	// PeerTracker is used to select peers to send bootstrapping requests to.
+	PeerTracker *p2p.PeerTracker
+	// ConsensusEngine is the snowman engine that runs once the chain is
+	// bootstrapped.
+	ConsensusEngine *smeng.Engine
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -223,6 +241,9 @@ type ManagerConfig struct {
 
 	FrontierPollFrequency   time.Duration
 	ConsensusAppConcurrency int
+	// ByzantineConfig makes the snowman engines deviate from the protocol. It
+	// must only be enabled when testing.
+	ByzantineConfig sender.ByzantineConfig
 
 	// Max Time to spend fetching a container and its
 	// ancestors when responding to a GetAncestors
@@ -232,6 +253,20 @@ type ManagerConfig struct {
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int
+	// Number of goroutines that parse and pre-verify blocks ahead of their
+	// execution while bootstrapping, if the VM enables parallel parsing.
+	BootstrapParseWorkers int
+	// Strategy used to select the peers to fetch containers from while
+	// bootstrapping.
+	BootstrapPeerSelectionStrategy p2p.PeerSelectionStrategy
+	// BootstrapCheckpoints maps a chainID to a block that the chain can
+	// bootstrap to without first polling its beacons for their accepted
+	// frontier.
+	BootstrapCheckpoints map[ids.ID]smbootstrap.Checkpoint
+
+	// ConsensusEvents is notified of the consensus lifecycle of blocks on
+	// snowman chains. May be nil.
+	ConsensusEvents event.Publisher
 
 	Upgrades upgrade.Config
 
@@ -267,6 +302,12 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The peer tracker of the chain
+	peerTrackers map[ids.ID]*p2p.PeerTracker
+	// Key: Chain's ID
+	// Value: The chain's snowman engine
+	consensusEngines map[ids.ID]*smeng.Engine
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -328,6 +369,8 @@ func New(config *ManagerConfig) (Manager, error) {
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
 		chains:                 make(map[ids.ID]handler.Handler),
+		peerTrackers:           make(map[ids.ID]*p2p.PeerTracker),
+		consensusEngines:       make(map[ids.ID]*smeng.Engine),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -434,6 +477,8 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.peerTrackers[chainParams.ID] = chain.PeerTracker
+	m.consensusEngines[chainParams.ID] = chain.ConsensusEngine
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -697,6 +742,11 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't initialize avalanche sender: %w", err)
 	}
 
+	snowmanMessageSender, err = sender.Byzantine(snowmanMessageSender, m.ByzantineConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize byzantine sender: %w", err)
+	}
+
 	if m.TracingEnabled {
 		snowmanMessageSender = sender.Trace(snowmanMessageSender, m.Tracer)
 	}
@@ -937,13 +987,16 @@ func (m *manager) createAvalancheChain(
 		Validators:          vdrs,
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
+		PollStrategy:        sb.Config().PollStrategy,
 		Consensus:           snowmanConsensus,
+		VoteStrategy:        m.voteStrategy(),
+		Events:              m.ConsensusEvents,
 	}
-	var snowmanEngine common.Engine
-	snowmanEngine, err = smeng.New(snowmanEngineConfig)
+	consensusEngine, err := smeng.New(snowmanEngineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
+	var snowmanEngine common.Engine = consensusEngine
 
 	if m.TracingEnabled {
 		snowmanEngine = common.TraceEngine(snowmanEngine, m.Tracer)
@@ -961,9 +1014,12 @@ func (m *manager) createAvalancheChain(
 		BootstrapTracker:               sb,
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
+		PeerSelectionStrategy:          m.BootstrapPeerSelectionStrategy,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
 		DB:                             blockBootstrappingDB,
 		VM:                             vmWrappingProposerVM,
+		ParseWorkers:                   m.BootstrapParseWorkers,
+		TrustedCheckpoint:              m.trustedCheckpoint(ctx.ChainID),
 	}
 	var snowmanBootstrapper common.BootstrapableEngine
 	snowmanBootstrapper, err = smbootstrap.New(
@@ -1003,6 +1059,7 @@ func (m *manager) createAvalancheChain(
 		StartupTracker:                 startupTracker,
 		Sender:                         avalancheMessageSender,
 		PeerTracker:                    peerTracker,
+		PeerSelectionStrategy:          m.BootstrapPeerSelectionStrategy,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
 		VtxBlocked:                     vtxBlocker,
 		TxBlocked:                      txBlocker,
@@ -1045,10 +1102,12 @@ func (m *manager) createAvalancheChain(
 	}
 
 	return &chain{
-		Name:    primaryAlias,
-		Context: ctx,
-		VM:      dagVM,
-		Handler: h,
+		Name:            primaryAlias,
+		Context:         ctx,
+		VM:              dagVM,
+		Handler:         h,
+		PeerTracker:     peerTracker,
+		ConsensusEngine: consensusEngine,
 	}, nil
 }
 
@@ -1103,6 +1162,11 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
 	}
 
+	messageSender, err = sender.Byzantine(messageSender, m.ByzantineConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize byzantine sender: %w", err)
+	}
+
 	if m.TracingEnabled {
 		messageSender = sender.Trace(messageSender, m.Tracer)
 	}
@@ -1336,14 +1400,17 @@ func (m *manager) createSnowmanChain(
 		Validators:          vdrs,
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
+		PollStrategy:        sb.Config().PollStrategy,
 		Consensus:           consensus,
 		PartialSync:         m.PartialSyncPrimaryNetwork && ctx.ChainID == constants.PlatformChainID,
+		VoteStrategy:        m.voteStrategy(),
+		Events:              m.ConsensusEvents,
 	}
-	var engine common.Engine
-	engine, err = smeng.New(engineConfig)
+	consensusEngine, err := smeng.New(engineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
+	var engine common.Engine = consensusEngine
 
 	if m.TracingEnabled {
 		engine = common.TraceEngine(engine, m.Tracer)
@@ -1361,10 +1428,13 @@ func (m *manager) createSnowmanChain(
 		BootstrapTracker:               sb,
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
+		PeerSelectionStrategy:          m.BootstrapPeerSelectionStrategy,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
 		DB:                             bootstrappingDB,
 		VM:                             vm,
 		Bootstrapped:                   bootstrapFunc,
+		ParseWorkers:                   m.BootstrapParseWorkers,
+		TrustedCheckpoint:              m.trustedCheckpoint(ctx.ChainID),
 	}
 	var bootstrapper common.BootstrapableEngine
 	bootstrapper, err = smbootstrap.New(
@@ -1418,10 +1488,12 @@ func (m *manager) createSnowmanChain(
 	}
 
 	return &chain{
-		Name:    primaryAlias,
-		Context: ctx,
-		VM:      vm,
-		Handler: h,
+		Name:            primaryAlias,
+		Context:         ctx,
+		VM:              vm,
+		Handler:         h,
+		PeerTracker:     peerTracker,
+		ConsensusEngine: consensusEngine,
 	}, nil
 }
 
@@ -1436,6 +1508,51 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) PeerTrackerStats(id ids.ID) ([]p2p.PeerStats, bool) {
+	m.chainsLock.Lock()
+	peerTracker, exists := m.peerTrackers[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, false
+	}
+
+	return peerTracker.Stats(), true
+}
+
+func (m *manager) ConsensusState(id ids.ID, numRecentPolls int) (smeng.ConsensusState, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[id]
+	engine := m.consensusEngines[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return smeng.ConsensusState{}, ErrChainNotRunning
+	}
+
+	ctx := chain.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	// The engine isn't polling until the chain has finished bootstrapping, so
+	// its state would be empty.
+	if ctx.State.Get().State != snow.NormalOp {
+		return smeng.ConsensusState{}, ErrChainBootstrapping
+	}
+	return engine.ConsensusState(numRecentPolls), nil
+}
+
+// voteStrategy returns the strategy the snowman engines use to choose the
+// blocks they vote for.
+func (m *manager) voteStrategy() smeng.VoteStrategy {
+	switch {
+	case m.ByzantineConfig.Contains(sender.ConflictingVotes):
+		return smeng.ConflictingVotes
+	case m.ByzantineConfig.Contains(sender.WrongPreference):
+		return smeng.WrongPreferenceVotes
+	default:
+		return smeng.HonestVotes
+	}
+}
+
 func (m *manager) registerBootstrappedHealthChecks() error {
 	bootstrappedCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		if subnetIDs := m.Subnets.Bootstrapping(); len(subnetIDs) != 0 {
@@ -1583,3 +1700,13 @@ func (m *manager) getOrMakeVMRegisterer(vmID ids.ID, chainAlias string) (metrics
 	)
 	return chainReg, err
 }
+
+// trustedCheckpoint returns the configured bootstrap checkpoint of [chainID],
+// or nil if there isn't one.
+func (m *manager) trustedCheckpoint(chainID ids.ID) *smbootstrap.Checkpoint {
+	checkpoint, ok := m.BootstrapCheckpoints[chainID]
+	if !ok {
+		return nil
+	}
+	return &checkpoint
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/networking/handler"
+	"github.com/f01c5700/avalanchego/snow/networking/handler/handlermock"
+	"github.com/f01c5700/avalanchego/snow/snowtest"
+
+	p2ppb "github.com/f01c5700/avalanchego/proto/pb/p2p"
+	smeng "github.com/f01c5700/avalanchego/snow/engine/snowman"
+)
+
+func TestConsensusStateErrors(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	chainID := ids.GenerateTestID()
+	ctx := snowtest.ConsensusContext(snowtest.Context(t, chainID))
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+
+	h := handlermock.NewHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+
+	m := &manager{
+		chains: map[ids.ID]handler.Handler{
+			chainID: h,
+		},
+		consensusEngines: map[ids.ID]*smeng.Engine{
+			chainID: nil,
+		},
+	}
+
+	_, err := m.ConsensusState(ids.GenerateTestID(), 1)
+	require.ErrorIs(err, ErrChainNotRunning)
+
+	_, err = m.ConsensusState(chainID, 1)
+	require.ErrorIs(err, ErrChainBootstrapping)
+}
@@ -3,7 +3,12 @@
 
 package chains
 
-import "github.com/f01c5700/avalanchego/ids"
+import (
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+
+	smeng "github.com/f01c5700/avalanchego/snow/engine/snowman"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -56,3 +61,11 @@ func (testManager) Lookup(s string) (ids.ID, error) {
 func (testManager) LookupVM(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
+
+func (testManager) ConsensusState(ids.ID, int) (smeng.ConsensusState, error) {
+	return smeng.ConsensusState{}, ErrChainNotRunning
+}
+
+func (testManager) PeerTrackerStats(ids.ID) ([]p2p.PeerStats, bool) {
+	return nil, false
+}
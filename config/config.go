@@ -24,6 +24,8 @@ import (
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/network"
 	"github.com/f01c5700/avalanchego/network/dialer"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/network/peer"
 	"github.com/f01c5700/avalanchego/network/throttling"
 	"github.com/f01c5700/avalanchego/node"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
@@ -70,6 +72,7 @@ var (
 	errConflictingImplicitACPOpinion          = errors.New("objecting to enabled ACP")
 	errSybilProtectionDisabledStakerWeights   = errors.New("sybil protection disabled weights must be positive")
 	errSybilProtectionDisabledOnPublicNetwork = errors.New("sybil protection disabled on public network")
+	errByzantineOnProductionNetwork           = errors.New("byzantine behavior enabled on production network")
 	errInvalidUptimeRequirement               = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax              = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee                   = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -186,6 +189,8 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+
+			ConsensusEventsAPIEnabled: v.GetBool(ConsensusEventsAPIEnabledKey),
 		},
 		HTTPHost:           v.GetString(HTTPHostKey),
 		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
@@ -225,6 +230,7 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 		MaximumTimeout:     v.GetDuration(NetworkMaximumTimeoutKey),
 		TimeoutHalflife:    v.GetDuration(NetworkTimeoutHalflifeKey),
 		TimeoutCoefficient: v.GetFloat64(NetworkTimeoutCoefficientKey),
+		MaxPeerTimeouts:    v.GetInt(NetworkMaxPeerTimeoutsKey),
 	}
 	switch {
 	case config.MinimumTimeout < 1:
@@ -237,6 +243,8 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must > 0", NetworkTimeoutHalflifeKey)
 	case config.TimeoutCoefficient < 1:
 		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must be >= 1", NetworkTimeoutCoefficientKey)
+	case config.MaxPeerTimeouts < 0:
+		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must be >= 0", NetworkMaxPeerTimeoutsKey)
 	}
 
 	return config, nil
@@ -379,6 +387,29 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		OutboundQueueConfig: peer.PrioritizedMessageQueueConfig{
+			Enabled: v.GetBool(NetworkOutboundQueuePrioritizedKey),
+			Quantum: v.GetUint64(NetworkOutboundQueueQuantumKey),
+			Lanes: [peer.NumLanes]peer.LaneConfig{
+				peer.ConsensusLane: {
+					Weight:   v.GetUint64(NetworkOutboundQueueConsensusWeightKey),
+					MaxBytes: v.GetUint64(NetworkOutboundQueueConsensusMaxBytesKey),
+				},
+				peer.BootstrapLane: {
+					Weight:   v.GetUint64(NetworkOutboundQueueBootstrapWeightKey),
+					MaxBytes: v.GetUint64(NetworkOutboundQueueBootstrapMaxBytesKey),
+				},
+				peer.AppRequestLane: {
+					Weight:   v.GetUint64(NetworkOutboundQueueAppRequestWeightKey),
+					MaxBytes: v.GetUint64(NetworkOutboundQueueAppRequestMaxBytesKey),
+				},
+				peer.AppGossipLane: {
+					Weight:   v.GetUint64(NetworkOutboundQueueAppGossipWeightKey),
+					MaxBytes: v.GetUint64(NetworkOutboundQueueAppGossipMaxBytesKey),
+				},
+			},
+		},
 	}
 
 	switch {
@@ -417,6 +448,11 @@ func getNetworkConfig(
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
 	}
+	if config.OutboundQueueConfig.Enabled {
+		if err := config.OutboundQueueConfig.Verify(); err != nil {
+			return network.Config{}, fmt.Errorf("invalid outbound queue config: %w", err)
+		}
+	}
 	return config, nil
 }
 
@@ -485,6 +521,19 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapParseWorkers:                   int(v.GetUint(BootstrapParseWorkersKey)),
+	}
+
+	peerSelectionStrategy, err := p2p.ParsePeerSelectionStrategy(v.GetString(BootstrapPeerSelectionStrategyKey))
+	if err != nil {
+		return node.BootstrapConfig{}, fmt.Errorf("couldn't parse %s: %w", BootstrapPeerSelectionStrategyKey, err)
+	}
+	config.BootstrapPeerSelectionStrategy = peerSelectionStrategy
+
+	if checkpoints := v.GetString(BootstrapCheckpointsKey); checkpoints != "" {
+		if err := json.Unmarshal([]byte(checkpoints), &config.BootstrapCheckpoints); err != nil {
+			return node.BootstrapConfig{}, fmt.Errorf("couldn't parse %s: %w", BootstrapCheckpointsKey, err)
+		}
 	}
 
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
@@ -1293,6 +1342,18 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	if byzantineConfig := v.GetString(ConsensusByzantineConfigKey); byzantineConfig != "" {
+		if constants.ProductionNetworkIDs.Contains(nodeConfig.NetworkID) {
+			return node.Config{}, fmt.Errorf("%w: %s", errByzantineOnProductionNetwork, ConsensusByzantineConfigKey)
+		}
+		if err := json.Unmarshal([]byte(byzantineConfig), &nodeConfig.ByzantineConfig); err != nil {
+			return node.Config{}, fmt.Errorf("couldn't parse %s: %w", ConsensusByzantineConfigKey, err)
+		}
+		if err := nodeConfig.ByzantineConfig.Verify(); err != nil {
+			return node.Config{}, fmt.Errorf("invalid %s: %w", ConsensusByzantineConfigKey, err)
+		}
+	}
+
 	// Database
 	nodeConfig.DatabaseConfig, err = getDatabaseConfig(v, nodeConfig.NetworkID)
 	if err != nil {
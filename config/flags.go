@@ -17,6 +17,7 @@ import (
 	"github.com/f01c5700/avalanchego/database/memdb"
 	"github.com/f01c5700/avalanchego/database/pebbledb"
 	"github.com/f01c5700/avalanchego/genesis"
+	"github.com/f01c5700/avalanchego/network/p2p"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
 	"github.com/f01c5700/avalanchego/trace"
 	"github.com/f01c5700/avalanchego/utils/compression"
@@ -167,6 +168,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkMaximumInboundTimeoutKey, constants.DefaultNetworkMaximumInboundTimeout, "Maximum timeout value of an inbound message. Defines duration within which an incoming message must be fulfilled. Incoming messages containing deadline higher than this value will be overridden with this value.")
 	fs.Duration(NetworkTimeoutHalflifeKey, constants.DefaultNetworkTimeoutHalflife, "Halflife of average network response time. Higher value --> network timeout is less volatile. Can't be 0")
 	fs.Float64(NetworkTimeoutCoefficientKey, constants.DefaultNetworkTimeoutCoefficient, "Multiplied by average network response time to get the network timeout. Must be >= 1")
+	fs.Int(NetworkMaxPeerTimeoutsKey, constants.DefaultNetworkMaxPeerTimeouts, "Maximum number of (peer, message type) pairs whose timeouts are estimated from their own response times. Other requests use the network timeout. If 0, the network timeout is used for all requests")
 	fs.Duration(NetworkReadHandshakeTimeoutKey, constants.DefaultNetworkReadHandshakeTimeout, "Timeout value for reading handshake messages")
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
@@ -181,6 +183,16 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkOutboundQueuePrioritizedKey, constants.DefaultNetworkOutboundQueuePrioritized, "If true, outbound messages to each peer are queued on separate consensus, bootstrap, app request and app gossip lanes that are served by weight rather than in FIFO order")
+	fs.Uint64(NetworkOutboundQueueQuantumKey, constants.DefaultNetworkOutboundQueueQuantum, "Number of bytes a lane with a weight of 1 may send per scheduling round of the prioritized outbound queue")
+	fs.Uint64(NetworkOutboundQueueConsensusWeightKey, constants.DefaultNetworkOutboundQueueConsensusWeight, "Weight of the consensus lane of the prioritized outbound queue")
+	fs.Uint64(NetworkOutboundQueueBootstrapWeightKey, constants.DefaultNetworkOutboundQueueBootstrapWeight, "Weight of the bootstrap lane of the prioritized outbound queue")
+	fs.Uint64(NetworkOutboundQueueAppRequestWeightKey, constants.DefaultNetworkOutboundQueueAppRequestWeight, "Weight of the app request lane of the prioritized outbound queue")
+	fs.Uint64(NetworkOutboundQueueAppGossipWeightKey, constants.DefaultNetworkOutboundQueueAppGossipWeight, "Weight of the app gossip lane of the prioritized outbound queue")
+	fs.Uint64(NetworkOutboundQueueConsensusMaxBytesKey, constants.DefaultNetworkOutboundQueueConsensusMaxBytes, "Max number of bytes queued per peer on the consensus lane of the prioritized outbound queue. If 0, the lane is unbounded")
+	fs.Uint64(NetworkOutboundQueueBootstrapMaxBytesKey, constants.DefaultNetworkOutboundQueueBootstrapMaxBytes, "Max number of bytes queued per peer on the bootstrap lane of the prioritized outbound queue. If 0, the lane is unbounded")
+	fs.Uint64(NetworkOutboundQueueAppRequestMaxBytesKey, constants.DefaultNetworkOutboundQueueAppRequestMaxBytes, "Max number of bytes queued per peer on the app request lane of the prioritized outbound queue. If 0, the lane is unbounded")
+	fs.Uint64(NetworkOutboundQueueAppGossipMaxBytesKey, constants.DefaultNetworkOutboundQueueAppGossipMaxBytes, "Max number of bytes queued per peer on the app gossip lane of the prioritized outbound queue. If 0, the lane is unbounded")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -201,6 +213,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
 	fs.Duration(ConsensusFrontierPollFrequencyKey, constants.DefaultFrontierPollFrequency, "Frequency of polling for new consensus frontiers")
+	fs.String(ConsensusByzantineConfigKey, "", "JSON config that makes the snowman engines deviate from the protocol. Must only be used to test how honest nodes react to byzantine peers")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -240,6 +253,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(KeystoreAPIEnabledKey, false, "If true, this node exposes the Keystore API")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
+	fs.Bool(ConsensusEventsAPIEnabledKey, false, "If true, this node streams the consensus events of its chains over a websocket")
 
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
@@ -302,6 +316,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.Uint(BootstrapParseWorkersKey, 1, "Number of goroutines that parse and pre-verify blocks ahead of their execution while bootstrapping. Only used if the VM supports parallel parsing")
+	fs.String(BootstrapPeerSelectionStrategyKey, p2p.BandwidthStrategy.String(), "Strategy used to select the peers to fetch containers from while bootstrapping. One of \"bandwidth\", \"latency\", \"hybrid\" or \"uniform\"")
+	fs.String(BootstrapCheckpointsKey, "", "JSON map from chainID to the {\"height\", \"blockID\"} of a block the chain should bootstrap to. The checkpoint must still be accepted by a majority of the beacons")
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, snowball.DefaultParameters.K, "Number of nodes to query for each network poll")
@@ -96,6 +96,7 @@ const (
 	NetworkMaximumInboundTimeoutKey                    = "network-maximum-inbound-timeout"
 	NetworkTimeoutHalflifeKey                          = "network-timeout-halflife"
 	NetworkTimeoutCoefficientKey                       = "network-timeout-coefficient"
+	NetworkMaxPeerTimeoutsKey                          = "network-max-peer-timeouts"
 	NetworkHealthMinPeersKey                           = "network-health-min-conn-peers"
 	NetworkHealthMaxTimeSinceMsgReceivedKey            = "network-health-max-time-since-msg-received"
 	NetworkHealthMaxTimeSinceMsgSentKey                = "network-health-max-time-since-msg-sent"
@@ -116,6 +117,16 @@ const (
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkOutboundQueuePrioritizedKey                 = "network-outbound-queue-prioritized"
+	NetworkOutboundQueueQuantumKey                     = "network-outbound-queue-quantum"
+	NetworkOutboundQueueConsensusWeightKey             = "network-outbound-queue-consensus-weight"
+	NetworkOutboundQueueBootstrapWeightKey             = "network-outbound-queue-bootstrap-weight"
+	NetworkOutboundQueueAppRequestWeightKey            = "network-outbound-queue-app-request-weight"
+	NetworkOutboundQueueAppGossipWeightKey             = "network-outbound-queue-app-gossip-weight"
+	NetworkOutboundQueueConsensusMaxBytesKey           = "network-outbound-queue-consensus-max-bytes"
+	NetworkOutboundQueueBootstrapMaxBytesKey           = "network-outbound-queue-bootstrap-max-bytes"
+	NetworkOutboundQueueAppRequestMaxBytesKey          = "network-outbound-queue-app-request-max-bytes"
+	NetworkOutboundQueueAppGossipMaxBytesKey           = "network-outbound-queue-app-gossip-max-bytes"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
@@ -151,10 +162,12 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	ConsensusEventsAPIEnabledKey                       = "api-consensus-events-enabled"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	ConsensusFrontierPollFrequencyKey                  = "consensus-frontier-poll-frequency"
+	ConsensusByzantineConfigKey                        = "consensus-byzantine-config"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
@@ -168,6 +181,9 @@ const (
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapParseWorkersKey                           = "bootstrap-parse-workers"
+	BootstrapPeerSelectionStrategyKey                  = "bootstrap-peer-selection-strategy"
+	BootstrapCheckpointsKey                            = "bootstrap-checkpoints"
 	ChainDataDirKey                                    = "chain-data-dir"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
@@ -0,0 +1,295 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/staking"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/ips"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/wrappers"
+)
+
+const (
+	// addressBookMaxAge is the amount of time after which a peer that hasn't
+	// been seen is removed from the address book.
+	addressBookMaxAge = 14 * 24 * time.Hour
+
+	// Peers that have been dialed at least [minAddressBookDialAttempts] times
+	// and have a success rate below [minAddressBookSuccessRate] are not dialed
+	// from the address book on startup. The node will wait to learn a fresh IP
+	// through gossip instead.
+	minAddressBookDialAttempts = 8
+	minAddressBookSuccessRate  = .1
+
+	addressBookEntryBaseLen = 2*wrappers.IntLen + net.IPv6len + wrappers.ShortLen + 4*wrappers.LongLen
+)
+
+var (
+	_ validators.ManagerCallbackListener = (*addressBookListener)(nil)
+
+	errUnexpectedAddressBookEntryLen = errors.New("unexpected address book entry length")
+)
+
+type addressBookEntry struct {
+	ip       *ips.ClaimedIPPort
+	lastSeen time.Time
+	// attempts is the number of outbound connections attempted to [ip].
+	attempts uint64
+	// successes is the number of [attempts] that resulted in an upgraded
+	// connection.
+	successes uint64
+}
+
+// successRate returns the smoothed fraction of dial attempts that succeeded.
+// Peers without any recorded attempts are given a success rate of 1/2.
+func (e *addressBookEntry) successRate() float64 {
+	return float64(e.successes+1) / float64(e.attempts+2)
+}
+
+func (e *addressBookEntry) shouldDial() bool {
+	return e.attempts < minAddressBookDialAttempts || e.successRate() >= minAddressBookSuccessRate
+}
+
+func (e *addressBookEntry) Bytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, addressBookEntryBaseLen+len(e.ip.Cert.Raw)+len(e.ip.Signature)),
+	}
+	p.PackBytes(e.ip.Cert.Raw)
+	addrBytes := e.ip.AddrPort.Addr().As16()
+	p.PackFixedBytes(addrBytes[:])
+	p.PackShort(e.ip.AddrPort.Port())
+	p.PackLong(e.ip.Timestamp)
+	p.PackBytes(e.ip.Signature)
+	p.PackLong(uint64(e.lastSeen.Unix()))
+	p.PackLong(e.attempts)
+	p.PackLong(e.successes)
+	return p.Bytes
+}
+
+func parseAddressBookEntry(b []byte) (*addressBookEntry, error) {
+	p := wrappers.Packer{Bytes: b}
+	certBytes := p.UnpackBytes()
+	addrBytes := p.UnpackFixedBytes(net.IPv6len)
+	port := p.UnpackShort()
+	timestamp := p.UnpackLong()
+	signature := p.UnpackBytes()
+	lastSeen := p.UnpackLong()
+	attempts := p.UnpackLong()
+	successes := p.UnpackLong()
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if p.Offset != len(b) {
+		return nil, errUnexpectedAddressBookEntryLen
+	}
+
+	cert, err := staking.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	addr := netip.AddrFrom16([16]byte(addrBytes)).Unmap()
+	return &addressBookEntry{
+		ip: ips.NewClaimedIPPort(
+			cert,
+			netip.AddrPortFrom(addr, port),
+			timestamp,
+			signature,
+		),
+		lastSeen:  time.Unix(int64(lastSeen), 0),
+		attempts:  attempts,
+		successes: successes,
+	}, nil
+}
+
+// addressBook persists the most recent signed IPs of the peers this node has
+// connected to, along with how reliably they could be dialed. It allows the
+// node to reconnect to its previous peers after a restart without waiting to
+// re-learn their IPs from the bootstrappers.
+type addressBook struct {
+	db         database.Database
+	log        logging.Logger
+	numEntries prometheus.Gauge
+
+	lock    sync.Mutex
+	entries map[ids.NodeID]*addressBookEntry
+}
+
+// newAddressBook loads the entries persisted in [db]. Entries that haven't
+// been seen since [now] - [addressBookMaxAge] are removed.
+func newAddressBook(
+	db database.Database,
+	log logging.Logger,
+	registerer prometheus.Registerer,
+	now time.Time,
+) (*addressBook, error) {
+	a := &addressBook{
+		db:  db,
+		log: log,
+		numEntries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "address_book_entries",
+			Help: "number of peers in the persisted address book",
+		}),
+		entries: make(map[ids.NodeID]*addressBookEntry),
+	}
+	if err := registerer.Register(a.numEntries); err != nil {
+		return nil, err
+	}
+
+	var (
+		iter     = db.NewIterator()
+		minSeen  = now.Add(-addressBookMaxAge)
+		toDelete [][]byte
+	)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := slices.Clone(iter.Key())
+		entry, err := parseAddressBookEntry(iter.Value())
+		if err != nil {
+			log.Warn("dropping malformed address book entry",
+				zap.Binary("key", key),
+				zap.Error(err),
+			)
+			toDelete = append(toDelete, key)
+			continue
+		}
+		if entry.lastSeen.Before(minSeen) || !bytes.Equal(entry.ip.NodeID[:], key) {
+			toDelete = append(toDelete, key)
+			continue
+		}
+		a.entries[entry.ip.NodeID] = entry
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range toDelete {
+		if err := db.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+	a.numEntries.Set(float64(len(a.entries)))
+	return a, nil
+}
+
+// Connected records that a connection was established with the peer claiming
+// [ip] at [now].
+func (a *addressBook) Connected(ip *ips.ClaimedIPPort, now time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := a.entries[ip.NodeID]
+	if !ok {
+		entry = &addressBookEntry{}
+		a.entries[ip.NodeID] = entry
+		a.numEntries.Inc()
+	}
+	if entry.ip == nil || entry.ip.Timestamp < ip.Timestamp {
+		entry.ip = ip
+	}
+	entry.lastSeen = now
+	a.put(entry)
+}
+
+// Dialed records the result of an outbound connection attempt to [nodeID].
+// Attempts to dial peers that have never been connected to are not recorded.
+func (a *addressBook) Dialed(nodeID ids.NodeID, succeeded bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := a.entries[nodeID]
+	if !ok {
+		return
+	}
+	entry.attempts++
+	if succeeded {
+		entry.successes++
+	}
+	a.put(entry)
+}
+
+// GetIP returns the persisted IP of [nodeID] if it should be dialed.
+func (a *addressBook) GetIP(nodeID ids.NodeID) (*ips.ClaimedIPPort, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := a.entries[nodeID]
+	if !ok || !entry.shouldDial() {
+		return nil, false
+	}
+	return entry.ip, true
+}
+
+// IPs returns the persisted IPs that should be dialed, ordered by descending
+// success rate.
+func (a *addressBook) IPs() []*ips.ClaimedIPPort {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entries := make([]*addressBookEntry, 0, len(a.entries))
+	for _, entry := range a.entries {
+		if entry.shouldDial() {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b *addressBookEntry) int {
+		switch aRate, bRate := a.successRate(), b.successRate(); {
+		case aRate > bRate:
+			return -1
+		case aRate < bRate:
+			return 1
+		default:
+			return b.lastSeen.Compare(a.lastSeen)
+		}
+	})
+
+	claimedIPs := make([]*ips.ClaimedIPPort, len(entries))
+	for i, entry := range entries {
+		claimedIPs[i] = entry.ip
+	}
+	return claimedIPs
+}
+
+func (a *addressBook) put(entry *addressBookEntry) {
+	if err := a.db.Put(entry.ip.NodeID[:], entry.Bytes()); err != nil {
+		a.log.Warn("failed to persist address book entry",
+			zap.Stringer("nodeID", entry.ip.NodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+// addressBookListener adds the persisted IPs of validators to the network as
+// soon as they are added to the validator set.
+type addressBookListener struct {
+	network *network
+}
+
+func (l *addressBookListener) OnValidatorAdded(_ ids.ID, nodeID ids.NodeID, _ *bls.PublicKey, _ ids.ID, _ uint64) {
+	ip, ok := l.network.addressBook.GetIP(nodeID)
+	if !ok {
+		return
+	}
+
+	l.network.addPersistedIP(ip)
+}
+
+func (*addressBookListener) OnValidatorWeightChanged(ids.ID, ids.NodeID, uint64, uint64) {}
+
+func (*addressBookListener) OnValidatorRemoved(ids.ID, ids.NodeID, uint64) {}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/database/memdb"
+	"github.com/f01c5700/avalanchego/utils/ips"
+	"github.com/f01c5700/avalanchego/utils/logging"
+)
+
+func newTestAddressBook(t *testing.T, db database.Database, now time.Time) *addressBook {
+	a, err := newAddressBook(
+		db,
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		now,
+	)
+	require.NoError(t, err)
+	return a
+}
+
+func signedTestIP(ip *ips.ClaimedIPPort) *ips.ClaimedIPPort {
+	return ips.NewClaimedIPPort(
+		ip.Cert,
+		ip.AddrPort,
+		ip.Timestamp,
+		[]byte{1, 2, 3},
+	)
+}
+
+func TestAddressBookPersistence(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db      = memdb.New()
+		now     = time.Unix(1_000_000, 0)
+		ip      = signedTestIP(ip)
+		otherIP = signedTestIP(otherIP)
+	)
+
+	a := newTestAddressBook(t, db, now)
+	a.Connected(ip, now)
+	a.Connected(otherIP, now)
+	a.Dialed(ip.NodeID, false)
+	a.Dialed(otherIP.NodeID, true)
+	require.Equal(float64(2), testutil.ToFloat64(a.numEntries))
+
+	// The peer with the higher success rate should be dialed first.
+	require.Equal([]*ips.ClaimedIPPort{otherIP, ip}, a.IPs())
+
+	// Restarting should reload the same entries.
+	reloaded := newTestAddressBook(t, db, now)
+	require.Equal(a.entries, reloaded.entries)
+	require.Equal([]*ips.ClaimedIPPort{otherIP, ip}, reloaded.IPs())
+	require.Equal(float64(2), testutil.ToFloat64(reloaded.numEntries))
+
+	gotIP, ok := reloaded.GetIP(ip.NodeID)
+	require.True(ok)
+	require.Equal(ip, gotIP)
+}
+
+func TestAddressBookKeepsNewestIP(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now   = time.Unix(1_000_000, 0)
+		ip    = signedTestIP(ip)
+		newIP = signedTestIP(newerTestIP(ip))
+	)
+
+	a := newTestAddressBook(t, memdb.New(), now)
+	a.Connected(newIP, now)
+	a.Connected(ip, now.Add(time.Second))
+
+	entry := a.entries[ip.NodeID]
+	require.Equal(newIP, entry.ip)
+	require.Equal(now.Add(time.Second), entry.lastSeen)
+}
+
+func TestAddressBookExpiry(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db  = memdb.New()
+		now = time.Unix(1_000_000, 0)
+		ip  = signedTestIP(ip)
+	)
+
+	a := newTestAddressBook(t, db, now)
+	a.Connected(ip, now)
+
+	a = newTestAddressBook(t, db, now.Add(addressBookMaxAge+time.Second))
+	require.Empty(a.entries)
+	require.Empty(a.IPs())
+
+	has, err := db.Has(ip.NodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestAddressBookSkipsUnreliablePeers(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		now     = time.Unix(1_000_000, 0)
+		ip      = signedTestIP(ip)
+		otherIP = signedTestIP(otherIP)
+	)
+
+	a := newTestAddressBook(t, memdb.New(), now)
+
+	// Dialing a peer that was never connected to isn't recorded.
+	a.Dialed(ip.NodeID, false)
+	require.Empty(a.entries)
+
+	a.Connected(ip, now)
+	for i := 0; i < 2*minAddressBookDialAttempts; i++ {
+		a.Dialed(ip.NodeID, false)
+	}
+	a.Connected(otherIP, now)
+
+	_, ok := a.GetIP(ip.NodeID)
+	require.False(ok)
+	require.Equal([]*ips.ClaimedIPPort{otherIP}, a.IPs())
+}
+
+func TestAddressBookDropsMalformedEntries(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db  = memdb.New()
+		now = time.Unix(1_000_000, 0)
+		ip  = signedTestIP(ip)
+	)
+	require.NoError(db.Put(ip.NodeID[:], []byte{0x01}))
+
+	a := newTestAddressBook(t, db, now)
+	require.Empty(a.entries)
+
+	has, err := db.Has(ip.NodeID[:])
+	require.NoError(err)
+	require.False(has)
+}
@@ -9,8 +9,10 @@ import (
 	"net/netip"
 	"time"
 
+	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/network/dialer"
+	"github.com/f01c5700/avalanchego/network/peer"
 	"github.com/f01c5700/avalanchego/network/throttling"
 	"github.com/f01c5700/avalanchego/snow/networking/tracker"
 	"github.com/f01c5700/avalanchego/snow/uptime"
@@ -19,6 +21,7 @@ import (
 	"github.com/f01c5700/avalanchego/utils/compression"
 	"github.com/f01c5700/avalanchego/utils/crypto/bls"
 	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 )
 
 // HealthConfig describes parameters for network layer health checks.
@@ -172,9 +175,22 @@ type Config struct {
 
 	// Specifies how much CPU usage each peer can cause before
 	// we rate-limit them.
-	CPUTargeter tracker.Targeter `json:"-"`
+	CPUTargeter tracker.DynamicTargeter `json:"-"`
 
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
-	DiskTargeter tracker.Targeter `json:"-"`
+	DiskTargeter tracker.DynamicTargeter `json:"-"`
+
+	// AddressBookDB persists the IPs of previously connected peers so that
+	// they can be reconnected to after a restart. If nil, the address book is
+	// only kept in memory.
+	AddressBookDB database.Database `json:"-"`
+
+	// Clock provides the time and the timers of the network and its peers. If
+	// no source is set, the wall clock is used.
+	Clock mockable.Clock `json:"-"`
+
+	// OutboundQueueConfig specifies how messages queued for each peer are
+	// prioritized.
+	OutboundQueueConfig peer.PrioritizedMessageQueueConfig `json:"outboundQueueConfig"`
 }
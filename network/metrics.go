@@ -37,6 +37,7 @@ type metrics struct {
 	nodeSubnetUptimeWeightedAverage *prometheus.GaugeVec // Deprecated
 	nodeSubnetUptimeRewardingStake  *prometheus.GaugeVec // Deprecated
 	peerConnectedLifetimeAverage    prometheus.Gauge
+	throttlerLimits                 *prometheus.GaugeVec
 	lock                            sync.RWMutex
 	peerConnectedStartTimes         map[ids.NodeID]float64
 	peerConnectedStartTimesSum      float64
@@ -131,6 +132,13 @@ func newMetrics(
 				Help: "The average duration of all peer connections in nanoseconds",
 			},
 		),
+		throttlerLimits: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "throttler_limit",
+				Help: "Current value of each throttler limit that can be updated at runtime",
+			},
+			[]string{"limit"},
+		),
 		peerConnectedStartTimes: make(map[ids.NodeID]float64),
 	}
 
@@ -153,6 +161,7 @@ func newMetrics(
 		registerer.Register(m.nodeSubnetUptimeWeightedAverage),
 		registerer.Register(m.nodeSubnetUptimeRewardingStake),
 		registerer.Register(m.peerConnectedLifetimeAverage),
+		registerer.Register(m.throttlerLimits),
 	)
 
 	// init subnet tracker metrics with tracked subnets
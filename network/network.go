@@ -20,6 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/f01c5700/avalanchego/api/health"
+	"github.com/f01c5700/avalanchego/database/memdb"
 	"github.com/f01c5700/avalanchego/genesis"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/message"
@@ -46,6 +47,10 @@ const (
 	TimeSinceLastMsgReceivedKey = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey     = "timeSinceLastMsgSent"
 	SendFailRateKey             = "sendFailRate"
+
+	// persistedIPDialFrequency is how often the persisted IPs of newly added
+	// validators are dialed.
+	persistedIPDialFrequency = time.Second
 )
 
 var (
@@ -89,6 +94,13 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// ThrottlerConfig returns the current throttler limits.
+	ThrottlerConfig() DynamicThrottlerConfig
+
+	// SetThrottlerConfig updates the throttler limits while the network is
+	// running.
+	SetThrottlerConfig(config DynamicThrottlerConfig) error
 }
 
 type UptimeResult struct {
@@ -126,6 +138,10 @@ type network struct {
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
 	// Listens for and accepts new inbound connections
 	listener net.Listener
+	// The rate limit of [listener], if it is throttled
+	throttledListener throttling.ThrottledListener
+	// Serializes updates to the throttler limits
+	throttlerConfigLock sync.Mutex
 	// Makes new outbound connections
 	dialer dialer.Dialer
 	// Does TLS handshakes for inbound connections
@@ -144,7 +160,14 @@ type network struct {
 
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
-	peersLock sync.RWMutex
+	// Persists the IPs of previously connected peers
+	addressBook *addressBook
+	// persistedIPsLock protects [persistedIPs]
+	persistedIPsLock sync.Mutex
+	// persistedIPs are the IPs of validators, loaded from [addressBook], that
+	// should be dialed.
+	persistedIPs map[ids.NodeID]netip.AddrPort
+	peersLock    sync.RWMutex
 	// trackedIPs contains the set of IPs that we are currently attempting to
 	// connect to. An entry is added to this set when we first start attempting
 	// to connect to the peer. An entry is deleted from this set once we have
@@ -181,6 +204,7 @@ func NewNetwork(
 	dialer dialer.Dialer,
 	router router.ExternalHandler,
 ) (Network, error) {
+	throttledListener, _ := listener.(throttling.ThrottledListener)
 	if config.ProxyEnabled {
 		// Wrap the listener to process the proxy header.
 		listener = &proxyproto.Listener{
@@ -246,6 +270,15 @@ func NewNetwork(
 	}
 	config.Validators.RegisterCallbackListener(ipTracker)
 
+	addressBookDB := config.AddressBookDB
+	if addressBookDB == nil {
+		addressBookDB = memdb.New()
+	}
+	addressBook, err := newAddressBook(addressBookDB, log, metricsRegisterer, config.Clock.Time())
+	if err != nil {
+		return nil, fmt.Errorf("initializing address book failed with: %w", err)
+	}
+
 	// Track all default bootstrappers to ensure their current IPs are gossiped
 	// like validator IPs.
 	for _, bootstrapper := range genesis.GetBootstrappers(config.NetworkID) {
@@ -280,8 +313,9 @@ func NewNetwork(
 		ObjectedACPs:         config.ObjectedACPs.List(),
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
-		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		Clock:                config.Clock,
 	}
+	peerConfig.IPSigner = peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey, &peerConfig.Clock)
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
@@ -292,6 +326,7 @@ func NewNetwork(
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
+		throttledListener:           throttledListener,
 		dialer:                      dialer,
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
 		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
@@ -302,16 +337,26 @@ func NewNetwork(
 		sendFailRateCalculator: safemath.NewSyncAverager(safemath.NewAverager(
 			0,
 			config.SendFailRateHalflife,
-			time.Now(),
+			config.Clock.Time(),
 		)),
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		ipTracker:       ipTracker,
+		addressBook:     addressBook,
+		persistedIPs:    make(map[ids.NodeID]netip.AddrPort),
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
 		router:          router,
 	}
 	n.peerConfig.Network = n
+
+	// Reconnect to the validators we were previously connected to as they are
+	// added to the validator set.
+	config.Validators.RegisterCallbackListener(&addressBookListener{network: n})
+
+	n.throttlerConfigLock.Lock()
+	n.updateThrottlerMetrics()
+	n.throttlerConfigLock.Unlock()
 	return n, nil
 }
 
@@ -462,6 +507,7 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	)
 	trackedSubnets := peer.TrackedSubnets()
 	n.ipTracker.Connected(newIP, trackedSubnets)
+	n.addressBook.Connected(newIP, n.peerConfig.Clock.Time())
 
 	n.metrics.markConnected(peer)
 
@@ -679,6 +725,85 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
 	}
 }
 
+// addPersistedIP adds the IP that was persisted in the address book to the IP
+// tracker and schedules it to be dialed by [dialPersistedIPs].
+//
+// Invariant: [peersLock] must not be grabbed, because this is called while
+// the validator set is locked.
+func (n *network) addPersistedIP(ip *ips.ClaimedIPPort) {
+	if !n.ipTracker.ShouldVerifyIP(ip, false) {
+		return
+	}
+
+	signedIP := peer.SignedIP{
+		UnsignedIP: peer.UnsignedIP{
+			AddrPort:  ip.AddrPort,
+			Timestamp: ip.Timestamp,
+		},
+		TLSSignature: ip.Signature,
+	}
+	maxTimestamp := n.peerConfig.Clock.Time().Add(n.peerConfig.MaxClockDifference)
+	if err := signedIP.Verify(ip.Cert, maxTimestamp); err != nil {
+		n.peerConfig.Log.Debug("failed to verify persisted IP",
+			zap.Stringer("nodeID", ip.NodeID),
+			zap.Stringer("ip", ip.AddrPort),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if !n.ipTracker.AddIP(ip) {
+		return
+	}
+
+	n.persistedIPsLock.Lock()
+	defer n.persistedIPsLock.Unlock()
+
+	n.persistedIPs[ip.NodeID] = ip.AddrPort
+}
+
+// dialPersistedIPs starts dialing the IPs added by [addPersistedIP] of the
+// peers we are not already connected, or attempting to connect, to.
+//
+// The peers are dialed in order of descending success rate, so that the peers
+// that were the most reliable are the first to be let through the dial
+// throttler.
+func (n *network) dialPersistedIPs() {
+	n.persistedIPsLock.Lock()
+	persistedIPs := n.persistedIPs
+	n.persistedIPs = make(map[ids.NodeID]netip.AddrPort)
+	n.persistedIPsLock.Unlock()
+
+	if len(persistedIPs) == 0 {
+		return
+	}
+
+	// Peers that are no longer worth dialing are not returned by the address
+	// book, and are therefore skipped.
+	orderedIPs := n.addressBook.IPs()
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for _, orderedIP := range orderedIPs {
+		nodeID := orderedIP.NodeID
+		ip, ok := persistedIPs[nodeID]
+		if !ok {
+			continue
+		}
+		if _, connected := n.connectedPeers.GetByID(nodeID); connected {
+			continue
+		}
+		if _, isTracked := n.trackedIPs[nodeID]; isTracked {
+			continue
+		}
+
+		tracked := newTrackedIP(ip)
+		n.trackedIPs[nodeID] = tracked
+		n.dial(nodeID, tracked)
+	}
+}
+
 func (n *network) track(ip *ips.ClaimedIPPort, trackAllSubnets bool) error {
 	// To avoid signature verification when the IP isn't needed, we
 	// optimistically filter out IPs. This can result in us not tracking an IP
@@ -891,7 +1016,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 		defer n.metrics.numTracked.Dec()
 
 		for {
-			timer := time.NewTimer(ip.getDelay())
+			timer := n.peerConfig.Clock.NewTimer(ip.getDelay())
 
 			select {
 			case <-n.onCloseCtx.Done():
@@ -900,7 +1025,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 			case <-ip.onStopTracking:
 				timer.Stop()
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			n.peersLock.Lock()
@@ -966,6 +1091,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
+				n.addressBook.Dialed(nodeID, false)
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
 					zap.Stringer("nodeID", nodeID),
@@ -982,6 +1108,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 			)
 
 			err = n.upgrade(conn, n.clientUpgrader)
+			n.addressBook.Dialed(nodeID, err == nil)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -1098,16 +1225,31 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		tlsConn,
 		cert,
 		nodeID,
-		peer.NewThrottledMessageQueue(
+		n.newMessageQueue(nodeID),
+	)
+	n.connectingPeers.Add(peer)
+	n.peersLock.Unlock()
+	return nil
+}
+
+func (n *network) newMessageQueue(nodeID ids.NodeID) peer.MessageQueue {
+	if n.config.OutboundQueueConfig.Enabled {
+		return peer.NewPrioritizedMessageQueue(
+			n.config.OutboundQueueConfig,
+			n.peerConfig.Metrics,
+			&n.peerConfig.Clock,
 			n.peerConfig.Metrics,
 			nodeID,
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
-		),
+		)
+	}
+	return peer.NewThrottledMessageQueue(
+		n.peerConfig.Metrics,
+		nodeID,
+		n.peerConfig.Log,
+		n.outboundMsgThrottler,
 	)
-	n.connectingPeers.Add(peer)
-	n.peersLock.Unlock()
-	return nil
 }
 
 func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
@@ -1209,21 +1351,26 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 }
 
 func (n *network) runTimers() {
-	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
-	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
-	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	pullGossipPeerlists := n.peerConfig.Clock.NewTicker(n.config.PeerListPullGossipFreq)
+	resetPeerListBloom := n.peerConfig.Clock.NewTicker(n.config.PeerListBloomResetFreq)
+	updateUptimes := n.peerConfig.Clock.NewTicker(n.config.UptimeMetricFreq)
+	dialPersistedIPs := n.peerConfig.Clock.NewTicker(persistedIPDialFrequency)
 	defer func() {
+		pullGossipPeerlists.Stop()
 		resetPeerListBloom.Stop()
 		updateUptimes.Stop()
+		dialPersistedIPs.Stop()
 	}()
 
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
 			return
-		case <-pullGossipPeerlists.C:
+		case <-pullGossipPeerlists.C():
 			n.pullGossipPeerLists()
-		case <-resetPeerListBloom.C:
+		case <-dialPersistedIPs.C():
+			n.dialPersistedIPs()
+		case <-resetPeerListBloom.C():
 			if err := n.ipTracker.ResetBloom(); err != nil {
 				n.peerConfig.Log.Error("failed to reset ip tracker bloom filter",
 					zap.Error(err),
@@ -1231,7 +1378,7 @@ func (n *network) runTimers() {
 			} else {
 				n.peerConfig.Log.Debug("reset ip tracker bloom filter")
 			}
-		case <-updateUptimes.C:
+		case <-updateUptimes.C():
 			primaryUptime, err := n.NodeUptime(constants.PrimaryNetworkID)
 			if err != nil {
 				n.peerConfig.Log.Debug("failed to get primary network uptime",
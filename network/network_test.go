@@ -131,7 +131,7 @@ func init() {
 	defaultConfig.DiskTargeter = newDefaultTargeter(defaultConfig.ResourceTracker.DiskTracker())
 }
 
-func newDefaultTargeter(t tracker.Tracker) tracker.Targeter {
+func newDefaultTargeter(t tracker.Tracker) tracker.DynamicTargeter {
 	return tracker.NewTargeter(
 		logging.NoLog{},
 		&tracker.TargeterConfig{
@@ -442,6 +442,60 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	wg.Wait()
 }
 
+func TestAddressBookListenerAddsPersistedIP(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0]
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+	nodeID := ids.NodeIDFromCert(cert)
+
+	blsKey, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	unsignedIP := peer.UnsignedIP{
+		AddrPort: netip.AddrPortFrom(
+			netip.AddrFrom4([4]byte{123, 132, 123, 123}),
+			10000,
+		),
+		Timestamp: 1000,
+	}
+	signedIP, err := unsignedIP.Sign(tlsCert.PrivateKey.(crypto.Signer), blsKey)
+	require.NoError(err)
+
+	ip := ips.NewClaimedIPPort(
+		cert,
+		unsignedIP.AddrPort,
+		unsignedIP.Timestamp,
+		signedIP.TLSSignature,
+	)
+	network.addressBook.Connected(ip, time.Now())
+
+	// The persisted IP is added as soon as the node becomes a validator.
+	require.NoError(network.config.Validators.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1))
+
+	trackedIP, ok := network.ipTracker.GetIP(nodeID)
+	require.True(ok)
+	require.Equal(ip, trackedIP)
+
+	network.dialPersistedIPs()
+
+	network.peersLock.RLock()
+	require.Contains(network.trackedIPs, nodeID)
+	network.peersLock.RUnlock()
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestTrackDoesNotDialPrivateIPs(t *testing.T) {
 	require := require.New(t)
 
@@ -567,7 +621,7 @@ func TestDialDeletesNonValidators(t *testing.T) {
 	}
 
 	config := configs[0]
-	signer := peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey)
+	signer := peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey, &mockable.Clock{})
 	ip, err := signer.GetSignedIP()
 	require.NoError(err)
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -102,9 +103,15 @@ func (c *Client) AppRequest(
 			return err
 		}
 
+		callback := onResponse
+		if tracker := c.options.peerTracker; tracker != nil {
+			tracker.RegisterRequest(nodeID)
+			callback = trackedAppResponseCallback(tracker, onResponse)
+		}
+
 		c.router.pendingAppRequests[requestID] = pendingAppRequest{
 			handlerID: c.handlerIDStr,
-			callback:  onResponse,
+			callback:  callback,
 		}
 		c.router.requestID += 2
 	}
@@ -112,6 +119,24 @@ func (c *Client) AppRequest(
 	return nil
 }
 
+// trackedAppResponseCallback reports the outcome of a request to [tracker]
+// before invoking [onResponse].
+func trackedAppResponseCallback(tracker *PeerTracker, onResponse AppResponseCallback) AppResponseCallback {
+	requestTime := time.Now()
+	return func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		if err != nil {
+			tracker.RegisterFailure(nodeID)
+		} else {
+			var (
+				requestLatency = time.Since(requestTime).Seconds() + epsilon
+				bandwidth      = float64(len(responseBytes)) / requestLatency
+			)
+			tracker.RegisterResponse(nodeID, bandwidth)
+		}
+		onResponse(ctx, nodeID, responseBytes, err)
+	}
+}
+
 // AppGossip sends a gossip message to a random set of peers.
 func (c *Client) AppGossip(
 	ctx context.Context,
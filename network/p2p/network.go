@@ -25,6 +25,7 @@ var (
 	_ validators.Connector = (*Network)(nil)
 	_ common.AppHandler    = (*Network)(nil)
 	_ NodeSampler          = (*peerSampler)(nil)
+	_ NodeSampler          = (*peerTrackerSampler)(nil)
 
 	opLabel      = "op"
 	handlerLabel = "handlerID"
@@ -49,10 +50,25 @@ func WithValidatorSampling(validators *Validators) ClientOption {
 	})
 }
 
+// WithPeerTracker configures Client.AppRequestAny to select peers from
+// [tracker] using [strategy]. The outcome of every request issued by the
+// Client is reported to [tracker].
+func WithPeerTracker(tracker *PeerTracker, strategy PeerSelectionStrategy) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.nodeSampler = &peerTrackerSampler{
+			tracker:  tracker,
+			strategy: strategy,
+		}
+		options.peerTracker = tracker
+	})
+}
+
 // clientOptions holds client-configurable values
 type clientOptions struct {
 	// nodeSampler is used to select nodes to route Client.AppRequestAny to
 	nodeSampler NodeSampler
+	// peerTracker, if non-nil, is notified of the outcome of requests
+	peerTracker *PeerTracker
 }
 
 // NewNetwork returns an instance of Network
@@ -162,6 +178,24 @@ func (n *Network) AddHandler(handlerID uint64, handler Handler) error {
 	return n.router.addHandler(handlerID, handler)
 }
 
+type peerTrackerSampler struct {
+	tracker  *PeerTracker
+	strategy PeerSelectionStrategy
+}
+
+// Sample returns at most one peer, as the tracker only ranks a single best
+// peer.
+func (p *peerTrackerSampler) Sample(_ context.Context, limit int) []ids.NodeID {
+	if limit <= 0 {
+		return nil
+	}
+	nodeID, ok := p.tracker.SelectPeerWithStrategy(p.strategy)
+	if !ok {
+		return nil
+	}
+	return []ids.NodeID{nodeID}
+}
+
 // Peers contains metadata about the current set of connected peers
 type Peers struct {
 	lock sync.RWMutex
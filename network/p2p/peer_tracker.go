@@ -5,6 +5,7 @@ package p2p
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"sync"
@@ -24,6 +25,8 @@ import (
 
 const (
 	bandwidthHalflife = 5 * time.Minute
+	latencyHalflife   = 5 * time.Minute
+	errorRateHalflife = 5 * time.Minute
 
 	// controls how eagerly we connect to new peers vs. using peers with known
 	// good response bandwidth.
@@ -33,8 +36,89 @@ const (
 	// The probability that, when we select a peer, we select randomly rather
 	// than based on their performance.
 	randomPeerProbability = 0.2
+
+	// epsilon is used to avoid dividing by zero when a latency of 0 is
+	// observed.
+	epsilon = 1e-6
+)
+
+const (
+	// BandwidthStrategy prefers peers with the highest observed response
+	// bandwidth.
+	BandwidthStrategy PeerSelectionStrategy = iota
+	// LatencyStrategy prefers peers with the lowest observed round-trip
+	// latency.
+	LatencyStrategy
+	// HybridStrategy prefers peers that respond quickly, reliably, and with
+	// high bandwidth. Peers are scored by:
+	//
+	//	bandwidth * (1 - errorRate) / latency
+	HybridStrategy
+	// UniformStrategy selects uniformly at random from the connected peers,
+	// ignoring all observed performance.
+	UniformStrategy
 )
 
+var errUnknownPeerSelectionStrategy = errors.New("unknown peer selection strategy")
+
+// PeerSelectionStrategy determines how PeerTracker chooses between peers with
+// known performance.
+type PeerSelectionStrategy uint8
+
+func (s PeerSelectionStrategy) String() string {
+	switch s {
+	case BandwidthStrategy:
+		return "bandwidth"
+	case LatencyStrategy:
+		return "latency"
+	case HybridStrategy:
+		return "hybrid"
+	case UniformStrategy:
+		return "uniform"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePeerSelectionStrategy returns the strategy named [s].
+func ParsePeerSelectionStrategy(s string) (PeerSelectionStrategy, error) {
+	switch s {
+	case "bandwidth":
+		return BandwidthStrategy, nil
+	case "latency":
+		return LatencyStrategy, nil
+	case "hybrid":
+		return HybridStrategy, nil
+	case "uniform":
+		return UniformStrategy, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownPeerSelectionStrategy, s)
+	}
+}
+
+// PeerStats is a snapshot of the performance PeerTracker has observed from a
+// peer.
+type PeerStats struct {
+	NodeID ids.NodeID
+	// Tracked is true if a request has been sent to the peer since it most
+	// recently connected.
+	Tracked bool
+	// Responsive is true if the peer responded to its most recent request.
+	Responsive bool
+	// Pending is true if the peer currently has an outstanding request.
+	Pending bool
+	// Bandwidth is the average bandwidth of the peer's responses in bytes per
+	// second.
+	Bandwidth float64
+	// Latency is the average round-trip time of requests sent to the peer.
+	Latency time.Duration
+	// ErrorRate is the average fraction of requests sent to the peer that
+	// failed.
+	ErrorRate float64
+	// HybridScore is the score used to rank the peer under HybridStrategy.
+	HybridScore float64
+}
+
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
@@ -57,6 +141,20 @@ type PeerTracker struct {
 	bandwidthHeap heap.Map[ids.NodeID, safemath.Averager]
 	// Average bandwidth is only used for metrics.
 	averageBandwidth safemath.Averager
+	// Round-trip latency, in seconds, of peers that we have measured.
+	peerLatency map[ids.NodeID]safemath.Averager
+	// Fraction of failed requests of peers that we have measured.
+	peerErrorRate map[ids.NodeID]safemath.Averager
+	// Time that the most recent request was sent to each tracked peer.
+	requestTimes map[ids.NodeID]time.Time
+	// Min heap that contains the average latency of peers that do not have an
+	// outstanding request.
+	latencyHeap heap.Map[ids.NodeID, float64]
+	// Max heap that contains the hybrid score of peers that do not have an
+	// outstanding request.
+	hybridHeap heap.Map[ids.NodeID, float64]
+	// Average latency is only used for metrics.
+	averageLatency safemath.Averager
 
 	// The below fields are assumed to be constant and are not protected by the
 	// lock.
@@ -70,6 +168,7 @@ type peerTrackerMetrics struct {
 	numTrackedPeers    prometheus.Gauge
 	numResponsivePeers prometheus.Gauge
 	averageBandwidth   prometheus.Gauge
+	averageLatency     prometheus.Gauge
 }
 
 func NewPeerTracker(
@@ -85,9 +184,19 @@ func NewPeerTracker(
 			return a.Read() > b.Read()
 		}),
 		averageBandwidth: safemath.NewAverager(0, bandwidthHalflife, time.Now()),
-		log:              log,
-		ignoredNodes:     ignoredNodes,
-		minVersion:       minVersion,
+		peerLatency:      make(map[ids.NodeID]safemath.Averager),
+		peerErrorRate:    make(map[ids.NodeID]safemath.Averager),
+		requestTimes:     make(map[ids.NodeID]time.Time),
+		latencyHeap: heap.NewMap[ids.NodeID, float64](func(a, b float64) bool {
+			return a < b
+		}),
+		hybridHeap: heap.NewMap[ids.NodeID, float64](func(a, b float64) bool {
+			return a > b
+		}),
+		averageLatency: safemath.NewAverager(0, latencyHalflife, time.Now()),
+		log:            log,
+		ignoredNodes:   ignoredNodes,
+		minVersion:     minVersion,
 		metrics: peerTrackerMetrics{
 			numTrackedPeers: prometheus.NewGauge(
 				prometheus.GaugeOpts{
@@ -110,6 +219,13 @@ func NewPeerTracker(
 					Help:      "average sync bandwidth used by peers",
 				},
 			),
+			averageLatency: prometheus.NewGauge(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "average_latency",
+					Help:      "average round-trip latency of requests sent to peers (s)",
+				},
+			),
 		},
 	}
 
@@ -117,6 +233,7 @@ func NewPeerTracker(
 		registerer.Register(t.metrics.numTrackedPeers),
 		registerer.Register(t.metrics.numResponsivePeers),
 		registerer.Register(t.metrics.averageBandwidth),
+		registerer.Register(t.metrics.averageLatency),
 	)
 	return t, err
 }
@@ -152,19 +269,32 @@ func (p *PeerTracker) shouldSelectUntrackedPeer() bool {
 	return rand.Float64() < newPeerProbability // #nosec G404
 }
 
-// SelectPeer that we could send a request to.
+// SelectPeer that we could send a request to using the BandwidthStrategy.
+//
+// See SelectPeerWithStrategy.
+func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
+	return p.SelectPeerWithStrategy(BandwidthStrategy)
+}
+
+// SelectPeerWithStrategy that we could send a request to.
 //
-// If we should track more peers, returns a random untracked peer, if any exist.
-// Otherwise, with probability [randomPeerProbability] returns a random peer
-// from [p.responsivePeers].
-// With probability [1-randomPeerProbability] returns the peer in
-// [p.bandwidthHeap] with the highest bandwidth.
+// If [strategy] is UniformStrategy, returns a random connected peer.
+//
+// Otherwise, if we should track more peers, returns a random untracked peer,
+// if any exist. Otherwise, with probability [randomPeerProbability] returns a
+// random peer from [p.responsivePeers].
+// With probability [1-randomPeerProbability] returns the peer without an
+// outstanding request that is ranked the highest by [strategy].
 //
 // Returns false if there are no connected peers.
-func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
+func (p *PeerTracker) SelectPeerWithStrategy(strategy PeerSelectionStrategy) (ids.NodeID, bool) {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
+	if strategy == UniformStrategy {
+		return p.selectUniformPeer()
+	}
+
 	if p.shouldSelectUntrackedPeer() {
 		if nodeID, ok := p.untrackedPeers.Peek(); ok {
 			p.log.Debug("selecting peer",
@@ -177,14 +307,9 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 		}
 	}
 
-	useBandwidthHeap := rand.Float64() > randomPeerProbability // #nosec G404
-	if useBandwidthHeap {
-		if nodeID, bandwidth, ok := p.bandwidthHeap.Peek(); ok {
-			p.log.Debug("selecting peer",
-				zap.String("reason", "bandwidth"),
-				zap.Stringer("nodeID", nodeID),
-				zap.Float64("bandwidth", bandwidth.Read()),
-			)
+	useHeap := rand.Float64() > randomPeerProbability // #nosec G404
+	if useHeap {
+		if nodeID, ok := p.peekHeap(strategy); ok {
 			return nodeID, true
 		}
 	} else {
@@ -201,7 +326,8 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 		p.log.Debug("selecting peer",
 			zap.String("reason", "tracked"),
 			zap.Stringer("nodeID", nodeID),
-			zap.Bool("checkedBandwidthHeap", useBandwidthHeap),
+			zap.Stringer("strategy", strategy),
+			zap.Bool("checkedHeap", useHeap),
 		)
 		return nodeID, true
 	}
@@ -210,9 +336,72 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	return ids.EmptyNodeID, false
 }
 
+// Assumes the read lock is held.
+func (p *PeerTracker) peekHeap(strategy PeerSelectionStrategy) (ids.NodeID, bool) {
+	switch strategy {
+	case LatencyStrategy:
+		nodeID, latency, ok := p.latencyHeap.Peek()
+		if ok {
+			p.log.Debug("selecting peer",
+				zap.String("reason", "latency"),
+				zap.Stringer("nodeID", nodeID),
+				zap.Float64("latency", latency),
+			)
+		}
+		return nodeID, ok
+	case HybridStrategy:
+		nodeID, score, ok := p.hybridHeap.Peek()
+		if ok {
+			p.log.Debug("selecting peer",
+				zap.String("reason", "hybrid"),
+				zap.Stringer("nodeID", nodeID),
+				zap.Float64("score", score),
+			)
+		}
+		return nodeID, ok
+	default:
+		nodeID, bandwidth, ok := p.bandwidthHeap.Peek()
+		if ok {
+			p.log.Debug("selecting peer",
+				zap.String("reason", "bandwidth"),
+				zap.Stringer("nodeID", nodeID),
+				zap.Float64("bandwidth", bandwidth.Read()),
+			)
+		}
+		return nodeID, ok
+	}
+}
+
+// Assumes the read lock is held.
+func (p *PeerTracker) selectUniformPeer() (ids.NodeID, bool) {
+	numUntracked := p.untrackedPeers.Len()
+	numPeers := numUntracked + p.trackedPeers.Len()
+	if numPeers == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	index := rand.Intn(numPeers) // #nosec G404
+	peers := p.untrackedPeers
+	if index >= numUntracked {
+		index -= numUntracked
+		peers = p.trackedPeers
+	}
+	for nodeID := range peers {
+		if index == 0 {
+			p.log.Debug("selecting peer",
+				zap.String("reason", "uniform"),
+				zap.Stringer("nodeID", nodeID),
+			)
+			return nodeID, true
+		}
+		index--
+	}
+	return ids.EmptyNodeID, false
+}
+
 // Record that we sent a request to [nodeID].
 //
-// Removes the peer's bandwidth averager from the bandwidth heap.
+// Removes the peer from the selection heaps until the request completes.
 func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -220,20 +409,25 @@ func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID) {
 	p.untrackedPeers.Remove(nodeID)
 	p.trackedPeers.Add(nodeID)
 	p.bandwidthHeap.Remove(nodeID)
+	p.latencyHeap.Remove(nodeID)
+	p.hybridHeap.Remove(nodeID)
+	p.requestTimes[nodeID] = time.Now()
 
 	p.metrics.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
 }
 
 // Record that we observed that [nodeID]'s bandwidth is [bandwidth].
 //
-// Adds the peer's bandwidth averager to the bandwidth heap.
+// The latency of the response is measured from the most recent call to
+// RegisterRequest. The peer is added back to the selection heaps.
 func (p *PeerTracker) RegisterResponse(nodeID ids.NodeID, bandwidth float64) {
 	p.updateBandwidth(nodeID, bandwidth, true)
 }
 
 // Record that a request failed to [nodeID].
 //
-// Adds the peer's bandwidth averager to the bandwidth heap.
+// The time spent waiting for the failure is recorded as the latency of the
+// request. The peer is added back to the selection heaps.
 func (p *PeerTracker) RegisterFailure(nodeID ids.NodeID) {
 	p.updateBandwidth(nodeID, 0, false)
 }
@@ -261,6 +455,37 @@ func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, resp
 	p.bandwidthHeap.Push(nodeID, peerBandwidth)
 	p.averageBandwidth.Observe(bandwidth, now)
 
+	var errorRate float64
+	if !responsive {
+		errorRate = 1
+	}
+	peerErrorRate, ok := p.peerErrorRate[nodeID]
+	if ok {
+		peerErrorRate.Observe(errorRate, now)
+	} else {
+		peerErrorRate = safemath.NewAverager(errorRate, errorRateHalflife, now)
+		p.peerErrorRate[nodeID] = peerErrorRate
+	}
+
+	peerLatency, hasLatency := p.peerLatency[nodeID]
+	if requestTime, ok := p.requestTimes[nodeID]; ok {
+		delete(p.requestTimes, nodeID)
+
+		latency := now.Sub(requestTime).Seconds()
+		if hasLatency {
+			peerLatency.Observe(latency, now)
+		} else {
+			peerLatency = safemath.NewAverager(latency, latencyHalflife, now)
+			p.peerLatency[nodeID] = peerLatency
+			hasLatency = true
+		}
+		p.averageLatency.Observe(latency, now)
+	}
+	if hasLatency {
+		p.latencyHeap.Push(nodeID, peerLatency.Read())
+		p.hybridHeap.Push(nodeID, hybridScore(peerBandwidth.Read(), peerLatency.Read(), peerErrorRate.Read()))
+	}
+
 	if responsive {
 		p.responsivePeers.Add(nodeID)
 	} else {
@@ -269,6 +494,16 @@ func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, resp
 
 	p.metrics.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
 	p.metrics.averageBandwidth.Set(p.averageBandwidth.Read())
+	p.metrics.averageLatency.Set(p.averageLatency.Read())
+}
+
+// hybridScore ranks a peer by the amount of data it is expected to
+// successfully return per second of waiting.
+func hybridScore(bandwidth, latency, errorRate float64) float64 {
+	if latency <= 0 {
+		latency = epsilon
+	}
+	return bandwidth * (1 - errorRate) / latency
 }
 
 // Connected should be called when [nodeID] connects to this node.
@@ -303,6 +538,11 @@ func (p *PeerTracker) Disconnected(nodeID ids.NodeID) {
 	p.responsivePeers.Remove(nodeID)
 	delete(p.peerBandwidth, nodeID)
 	p.bandwidthHeap.Remove(nodeID)
+	delete(p.peerLatency, nodeID)
+	delete(p.peerErrorRate, nodeID)
+	delete(p.requestTimes, nodeID)
+	p.latencyHeap.Remove(nodeID)
+	p.hybridHeap.Remove(nodeID)
 
 	p.metrics.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
 	p.metrics.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
@@ -315,3 +555,37 @@ func (p *PeerTracker) Size() int {
 
 	return p.untrackedPeers.Len() + p.trackedPeers.Len()
 }
+
+// Stats returns the observed performance of every connected peer.
+func (p *PeerTracker) Stats() []PeerStats {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	stats := make([]PeerStats, 0, p.untrackedPeers.Len()+p.trackedPeers.Len())
+	for nodeID := range p.untrackedPeers {
+		stats = append(stats, PeerStats{
+			NodeID: nodeID,
+		})
+	}
+	for nodeID := range p.trackedPeers {
+		peerStats := PeerStats{
+			NodeID:     nodeID,
+			Tracked:    true,
+			Responsive: p.responsivePeers.Contains(nodeID),
+		}
+		_, peerStats.Pending = p.requestTimes[nodeID]
+		if bandwidth, ok := p.peerBandwidth[nodeID]; ok {
+			peerStats.Bandwidth = bandwidth.Read()
+		}
+		if errorRate, ok := p.peerErrorRate[nodeID]; ok {
+			peerStats.ErrorRate = errorRate.Read()
+		}
+		if latency, ok := p.peerLatency[nodeID]; ok {
+			latencySeconds := latency.Read()
+			peerStats.Latency = time.Duration(latencySeconds * float64(time.Second))
+			peerStats.HybridScore = hybridScore(peerStats.Bandwidth, latencySeconds, peerStats.ErrorRate)
+		}
+		stats = append(stats, peerStats)
+	}
+	return stats
+}
@@ -5,6 +5,7 @@ package p2p
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -102,3 +103,126 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+func TestParsePeerSelectionStrategy(t *testing.T) {
+	for _, strategy := range []PeerSelectionStrategy{
+		BandwidthStrategy,
+		LatencyStrategy,
+		HybridStrategy,
+		UniformStrategy,
+	} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			parsed, err := ParsePeerSelectionStrategy(strategy.String())
+			require.NoError(t, err)
+			require.Equal(t, strategy, parsed)
+		})
+	}
+
+	_, err := ParsePeerSelectionStrategy("unknown")
+	require.ErrorIs(t, err, errUnknownPeerSelectionStrategy)
+}
+
+func TestPeerTrackerStrategies(t *testing.T) {
+	require := require.New(t)
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+	)
+	require.NoError(err)
+
+	var (
+		peerVersion = &version.Application{
+			Major: 1,
+			Minor: 2,
+			Patch: 3,
+		}
+		fastPeer       = ids.GenerateTestNodeID()
+		highBWPeer     = ids.GenerateTestNodeID()
+		unreliablePeer = ids.GenerateTestNodeID()
+		untrackedPeer  = ids.GenerateTestNodeID()
+	)
+	for _, nodeID := range []ids.NodeID{fastPeer, highBWPeer, unreliablePeer, untrackedPeer} {
+		p.Connected(nodeID, peerVersion)
+	}
+
+	// Backdate the requests to simulate the round-trip latency of each peer.
+	request := func(nodeID ids.NodeID, latency time.Duration) {
+		p.RegisterRequest(nodeID)
+		p.requestTimes[nodeID] = time.Now().Add(-latency)
+	}
+
+	request(fastPeer, 10*time.Millisecond)
+	p.RegisterResponse(fastPeer, 50)
+
+	request(highBWPeer, time.Second)
+	p.RegisterResponse(highBWPeer, 10_000)
+
+	request(unreliablePeer, 20*time.Millisecond)
+	p.RegisterResponse(unreliablePeer, 200)
+	request(unreliablePeer, 20*time.Millisecond)
+	p.RegisterFailure(unreliablePeer)
+
+	tests := []struct {
+		strategy PeerSelectionStrategy
+		expected ids.NodeID
+	}{
+		{
+			strategy: BandwidthStrategy,
+			expected: highBWPeer,
+		},
+		{
+			strategy: LatencyStrategy,
+			expected: fastPeer,
+		},
+		{
+			strategy: HybridStrategy,
+			expected: highBWPeer,
+		},
+	}
+	for _, test := range tests {
+		nodeID, ok := p.peekHeap(test.strategy)
+		require.True(ok)
+		require.Equal(test.expected, nodeID, test.strategy.String())
+	}
+
+	// Peers with an outstanding request should not be selected.
+	p.RegisterRequest(highBWPeer)
+	nodeID, ok := p.peekHeap(HybridStrategy)
+	require.True(ok)
+	require.NotEqual(highBWPeer, nodeID)
+
+	nodeID, ok = p.SelectPeerWithStrategy(UniformStrategy)
+	require.True(ok)
+	require.Contains([]ids.NodeID{fastPeer, highBWPeer, unreliablePeer, untrackedPeer}, nodeID)
+
+	stats := p.Stats()
+	require.Len(stats, 4)
+	statsByNodeID := make(map[ids.NodeID]PeerStats)
+	for _, stat := range stats {
+		statsByNodeID[stat.NodeID] = stat
+	}
+
+	require.Equal(PeerStats{NodeID: untrackedPeer}, statsByNodeID[untrackedPeer])
+
+	highBWStats := statsByNodeID[highBWPeer]
+	require.True(highBWStats.Tracked)
+	require.True(highBWStats.Responsive)
+	require.True(highBWStats.Pending)
+	require.Zero(highBWStats.ErrorRate)
+	require.GreaterOrEqual(highBWStats.Latency, time.Second)
+
+	unreliableStats := statsByNodeID[unreliablePeer]
+	require.True(unreliableStats.Tracked)
+	require.False(unreliableStats.Responsive)
+	require.False(unreliableStats.Pending)
+	require.Positive(unreliableStats.ErrorRate)
+
+	// Disconnecting should remove all of the peer's stats.
+	p.Disconnected(unreliablePeer)
+	require.Len(p.Stats(), 3)
+	require.NotContains(p.peerErrorRate, unreliablePeer)
+	require.NotContains(p.peerLatency, unreliablePeer)
+}
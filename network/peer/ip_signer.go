@@ -16,7 +16,7 @@ import (
 // IPSigner will return a signedIP for the current value of our dynamic IP.
 type IPSigner struct {
 	ip        *utils.Atomic[netip.AddrPort]
-	clock     mockable.Clock
+	clock     *mockable.Clock
 	tlsSigner crypto.Signer
 	blsSigner *bls.SecretKey
 
@@ -31,9 +31,11 @@ func NewIPSigner(
 	ip *utils.Atomic[netip.AddrPort],
 	tlsSigner crypto.Signer,
 	blsSigner *bls.SecretKey,
+	clock *mockable.Clock,
 ) *IPSigner {
 	return &IPSigner{
 		ip:        ip,
+		clock:     clock,
 		tlsSigner: tlsSigner,
 		blsSigner: blsSigner,
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/f01c5700/avalanchego/staking"
 	"github.com/f01c5700/avalanchego/utils"
 	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 )
 
 func TestIPSigner(t *testing.T) {
@@ -31,7 +32,7 @@ func TestIPSigner(t *testing.T) {
 	blsKey, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	s := NewIPSigner(dynIP, tlsKey, blsKey)
+	s := NewIPSigner(dynIP, tlsKey, blsKey, &mockable.Clock{})
 
 	s.clock.Set(time.Unix(10, 0))
 
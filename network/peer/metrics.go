@@ -14,6 +14,7 @@ import (
 
 const (
 	ioLabel         = "io"
+	laneLabel       = "lane"
 	opLabel         = "op"
 	compressedLabel = "compressed"
 
@@ -23,6 +24,7 @@ const (
 
 var (
 	opLabels             = []string{opLabel}
+	laneLabels           = []string{laneLabel}
 	ioOpLabels           = []string{ioLabel, opLabel}
 	ioOpCompressedLabels = []string{ioLabel, opLabel, compressedLabel}
 )
@@ -37,6 +39,11 @@ type Metrics struct {
 	Messages   *prometheus.CounterVec // io + op + compressed
 	Bytes      *prometheus.CounterVec // io + op
 	BytesSaved *prometheus.GaugeVec   // io + op
+
+	QueueLen      *prometheus.GaugeVec     // lane
+	QueueBytes    *prometheus.GaugeVec     // lane
+	QueueDropped  *prometheus.CounterVec   // lane
+	QueueWaitTime *prometheus.HistogramVec // lane
 }
 
 func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
@@ -81,6 +88,35 @@ func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
 			},
 			ioOpLabels,
 		),
+		QueueLen: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "outbound_queue_len",
+				Help: "number of messages waiting in outbound queues",
+			},
+			laneLabels,
+		),
+		QueueBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "outbound_queue_bytes",
+				Help: "number of message bytes waiting in outbound queues",
+			},
+			laneLabels,
+		),
+		QueueDropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "outbound_queue_dropped",
+				Help: "number of messages dropped because their outbound queue lane was full",
+			},
+			laneLabels,
+		),
+		QueueWaitTime: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "outbound_queue_wait_time",
+				Help:    "time messages spent waiting in outbound queues (s)",
+				Buckets: prometheus.DefBuckets,
+			},
+			laneLabels,
+		),
 	}
 	return m, errors.Join(
 		registerer.Register(m.ClockSkewCount),
@@ -90,6 +126,10 @@ func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
 		registerer.Register(m.Messages),
 		registerer.Register(m.Bytes),
 		registerer.Register(m.BytesSaved),
+		registerer.Register(m.QueueLen),
+		registerer.Register(m.QueueBytes),
+		registerer.Register(m.QueueDropped),
+		registerer.Register(m.QueueWaitTime),
 	)
 }
 
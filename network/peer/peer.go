@@ -634,7 +634,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 }
 
 func (p *peer) sendNetworkMessages() {
-	sendPingsTicker := time.NewTicker(p.PingFrequency)
+	sendPingsTicker := p.Clock.NewTicker(p.PingFrequency)
 	defer func() {
 		sendPingsTicker.Stop()
 
@@ -662,7 +662,7 @@ func (p *peer) sendNetworkMessages() {
 			}
 
 			p.Send(p.onClosingCtx, msg)
-		case <-sendPingsTicker.C:
+		case <-sendPingsTicker.C():
 			if !p.Network.AllowConnection(p.id) {
 				p.Log.Debug(disconnectingLog,
 					zap.String("reason", "connection is no longer desired"),
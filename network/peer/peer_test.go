@@ -31,6 +31,7 @@ import (
 	"github.com/f01c5700/avalanchego/utils/math/meter"
 	"github.com/f01c5700/avalanchego/utils/resource"
 	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 	"github.com/f01c5700/avalanchego/version"
 )
 
@@ -115,7 +116,7 @@ func newRawTestPeer(t *testing.T, config Config) *rawTestPeer {
 	bls, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	config.IPSigner = NewIPSigner(ip, tls, bls)
+	config.IPSigner = NewIPSigner(ip, tls, bls, &mockable.Clock{})
 
 	inboundMsgChan := make(chan message.InboundMessage)
 	config.Router = router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
@@ -0,0 +1,380 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/message"
+	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/utils/buffer"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
+)
+
+// Lane is a class of outbound messages that is queued and scheduled
+// independently of the other classes.
+type Lane uint8
+
+const (
+	// ConsensusLane carries handshake messages and the messages used by
+	// consensus once a chain is bootstrapped.
+	ConsensusLane Lane = iota
+	// BootstrapLane carries the messages used by state sync and bootstrapping.
+	BootstrapLane
+	// AppRequestLane carries application level requests and responses.
+	AppRequestLane
+	// AppGossipLane carries application level gossip.
+	AppGossipLane
+
+	NumLanes
+)
+
+var (
+	_ MessageQueue = (*prioritizedMessageQueue)(nil)
+
+	errZeroLaneWeight = errors.New("lane weight must be positive")
+	errZeroQuantum    = errors.New("quantum must be positive")
+)
+
+func (l Lane) String() string {
+	switch l {
+	case ConsensusLane:
+		return "consensus"
+	case BootstrapLane:
+		return "bootstrap"
+	case AppRequestLane:
+		return "app_request"
+	case AppGossipLane:
+		return "app_gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// LaneOf returns the lane that messages with [op] are queued on.
+func LaneOf(op message.Op) Lane {
+	switch op {
+	case message.GetStateSummaryFrontierOp,
+		message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.AcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.AcceptedOp,
+		message.GetAncestorsOp,
+		message.AncestorsOp:
+		return BootstrapLane
+	case message.AppRequestOp,
+		message.AppResponseOp,
+		message.AppErrorOp:
+		return AppRequestLane
+	case message.AppGossipOp:
+		return AppGossipLane
+	default:
+		return ConsensusLane
+	}
+}
+
+type LaneConfig struct {
+	// Weight is the relative share of the outbound bandwidth this lane is
+	// given when multiple lanes have pending messages.
+	Weight uint64 `json:"weight"`
+
+	// MaxBytes is the maximum number of bytes that may be queued on this lane.
+	// If 0, the lane is only limited by the outbound message throttler.
+	MaxBytes uint64 `json:"maxBytes"`
+}
+
+type PrioritizedMessageQueueConfig struct {
+	// Enabled marks whether outbound messages should be scheduled across lanes
+	// rather than delivered in FIFO order.
+	Enabled bool `json:"enabled"`
+
+	// Quantum is the number of bytes a lane with a weight of 1 is allowed to
+	// send during each scheduling round.
+	Quantum uint64 `json:"quantum"`
+
+	// Lanes is indexed by [Lane].
+	Lanes [NumLanes]LaneConfig `json:"lanes"`
+}
+
+func (c *PrioritizedMessageQueueConfig) Verify() error {
+	if c.Quantum == 0 {
+		return errZeroQuantum
+	}
+	for lane, laneConfig := range c.Lanes {
+		if laneConfig.Weight == 0 {
+			return fmt.Errorf("%w: %s", errZeroLaneWeight, Lane(lane))
+		}
+	}
+	return nil
+}
+
+type queuedMessage struct {
+	msg      message.OutboundMessage
+	size     uint64
+	queuedAt time.Time
+}
+
+type lane struct {
+	queue buffer.Deque[queuedMessage]
+	// bytes is the total size of the messages in [queue].
+	bytes uint64
+	// deficit is the number of bytes this lane is currently allowed to send.
+	deficit uint64
+}
+
+// prioritizedMessageQueue schedules messages across lanes using deficit round
+// robin. Each lane is granted [quantum] * [weight] bytes per round, so lanes
+// with pending messages share the connection proportionally to their weights
+// regardless of the size of the individual messages.
+type prioritizedMessageQueue struct {
+	config   PrioritizedMessageQueueConfig
+	metrics  *Metrics
+	clock    *mockable.Clock
+	onFailed SendFailedCallback
+	// [id] of the peer we're sending messages to
+	id                   ids.NodeID
+	log                  logging.Logger
+	outboundMsgThrottler throttling.OutboundMsgThrottler
+
+	// Signalled when a message is added to the queue and when Close() is
+	// called.
+	cond *sync.Cond
+
+	// closed flags whether the send queue has been closed.
+	// [cond.L] must be held while accessing [closed].
+	closed bool
+
+	// [cond.L] must be held while accessing any of the following fields.
+	lanes [NumLanes]lane
+	// numMessages is the total number of messages across all lanes.
+	numMessages int
+	// current is the lane currently being served.
+	current Lane
+	// credited marks whether [current] has already been granted its quantum
+	// for this round.
+	credited bool
+}
+
+// NewPrioritizedMessageQueue returns a message queue that separates outbound
+// messages into lanes based on their op and serves the lanes in proportion to
+// their configured weights.
+//
+// Invariant: [config] must have been verified.
+func NewPrioritizedMessageQueue(
+	config PrioritizedMessageQueueConfig,
+	metrics *Metrics,
+	clock *mockable.Clock,
+	onFailed SendFailedCallback,
+	id ids.NodeID,
+	log logging.Logger,
+	outboundMsgThrottler throttling.OutboundMsgThrottler,
+) MessageQueue {
+	q := &prioritizedMessageQueue{
+		config:               config,
+		metrics:              metrics,
+		clock:                clock,
+		onFailed:             onFailed,
+		id:                   id,
+		log:                  log,
+		outboundMsgThrottler: outboundMsgThrottler,
+		cond:                 sync.NewCond(&sync.Mutex{}),
+	}
+	for i := range q.lanes {
+		q.lanes[i].queue = buffer.NewUnboundedDeque[queuedMessage](initialQueueSize)
+	}
+	return q
+}
+
+func (q *prioritizedMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
+	op := msg.Op()
+	if err := ctx.Err(); err != nil {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", q.id),
+			zap.Error(err),
+		)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	// Acquire space on the outbound message queue, or drop [msg] if we can't.
+	if !q.outboundMsgThrottler.Acquire(msg, q.id) {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	// Invariant: must call q.outboundMsgThrottler.Release(msg, q.id) when [msg]
+	// is popped or, if this queue closes before [msg] is popped, when this
+	// queue closes.
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "closed queue"),
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	var (
+		laneID   = LaneOf(op)
+		l        = &q.lanes[laneID]
+		size     = uint64(len(msg.Bytes()))
+		maxBytes = q.config.Lanes[laneID].MaxBytes
+		laneName = laneID.String()
+	)
+	if maxBytes != 0 && l.bytes+size > maxBytes {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "lane full"),
+			zap.Stringer("lane", laneID),
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.metrics.QueueDropped.WithLabelValues(laneName).Inc()
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	l.queue.PushRight(queuedMessage{
+		msg:      msg,
+		size:     size,
+		queuedAt: q.clock.Time(),
+	})
+	l.bytes += size
+	q.numMessages++
+	q.metrics.QueueLen.WithLabelValues(laneName).Inc()
+	q.metrics.QueueBytes.WithLabelValues(laneName).Add(float64(size))
+	q.cond.Signal()
+	return true
+}
+
+func (q *prioritizedMessageQueue) Pop() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if q.numMessages > 0 {
+			// There is a message
+			break
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+
+	return q.pop(), true
+}
+
+func (q *prioritizedMessageQueue) PopNow() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed || q.numMessages == 0 {
+		// There isn't a message
+		return nil, false
+	}
+
+	return q.pop(), true
+}
+
+// pop returns the next message according to the deficit round robin schedule.
+//
+// Invariant: [numMessages] must be positive.
+func (q *prioritizedMessageQueue) pop() message.OutboundMessage {
+	for {
+		l := &q.lanes[q.current]
+		if l.queue.Len() == 0 {
+			// Idle lanes don't accumulate credit.
+			l.deficit = 0
+			q.advance()
+			continue
+		}
+
+		if !q.credited {
+			l.deficit += q.config.Quantum * q.config.Lanes[q.current].Weight
+			q.credited = true
+		}
+
+		next, _ := l.queue.PeekLeft()
+		if next.size > l.deficit {
+			q.advance()
+			continue
+		}
+
+		_, _ = l.queue.PopLeft()
+		l.deficit -= next.size
+		l.bytes -= next.size
+		q.numMessages--
+
+		laneName := q.current.String()
+		q.metrics.QueueLen.WithLabelValues(laneName).Dec()
+		q.metrics.QueueBytes.WithLabelValues(laneName).Sub(float64(next.size))
+		waitTime := q.clock.Time().Sub(next.queuedAt)
+		q.metrics.QueueWaitTime.WithLabelValues(laneName).Observe(waitTime.Seconds())
+
+		q.outboundMsgThrottler.Release(next.msg, q.id)
+		return next.msg
+	}
+}
+
+func (q *prioritizedMessageQueue) advance() {
+	q.current = (q.current + 1) % NumLanes
+	q.credited = false
+}
+
+func (q *prioritizedMessageQueue) Close() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+
+	for i := range q.lanes {
+		l := &q.lanes[i]
+		laneName := Lane(i).String()
+		for l.queue.Len() > 0 {
+			next, _ := l.queue.PopLeft()
+			q.metrics.QueueLen.WithLabelValues(laneName).Dec()
+			q.metrics.QueueBytes.WithLabelValues(laneName).Sub(float64(next.size))
+			q.outboundMsgThrottler.Release(next.msg, q.id)
+			q.onFailed.SendFailed(next.msg)
+		}
+		l.queue = nil
+		l.bytes = 0
+	}
+	q.numMessages = 0
+
+	q.cond.Broadcast()
+}
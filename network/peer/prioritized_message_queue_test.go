@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/message"
+	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/utils"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
+	"github.com/f01c5700/avalanchego/utils/units"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func newTestPrioritizedMessageQueue(
+	t *testing.T,
+	config PrioritizedMessageQueueConfig,
+	onFailed SendFailedCallback,
+) (MessageQueue, *Metrics) {
+	t.Helper()
+	require := require.New(t)
+
+	require.NoError(config.Verify())
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(err)
+
+	return NewPrioritizedMessageQueue(
+		config,
+		metrics,
+		&mockable.Clock{},
+		onFailed,
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	), metrics
+}
+
+func newTestAncestors(t *testing.T, mc message.Creator, size int) message.OutboundMessage {
+	t.Helper()
+
+	msg, err := mc.Ancestors(
+		ids.GenerateTestID(),
+		0,
+		[][]byte{utils.RandomBytes(size)},
+	)
+	require.NoError(t, err)
+	return msg
+}
+
+func newTestPing(t *testing.T, mc message.Creator) message.OutboundMessage {
+	t.Helper()
+
+	msg, err := mc.Ping(0, nil)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestLaneOf(t *testing.T) {
+	tests := []struct {
+		op   message.Op
+		lane Lane
+	}{
+		{op: message.PingOp, lane: ConsensusLane},
+		{op: message.PushQueryOp, lane: ConsensusLane},
+		{op: message.ChitsOp, lane: ConsensusLane},
+		{op: message.GetAcceptedFrontierOp, lane: BootstrapLane},
+		{op: message.AncestorsOp, lane: BootstrapLane},
+		{op: message.StateSummaryFrontierOp, lane: BootstrapLane},
+		{op: message.AppRequestOp, lane: AppRequestLane},
+		{op: message.AppResponseOp, lane: AppRequestLane},
+		{op: message.AppErrorOp, lane: AppRequestLane},
+		{op: message.AppGossipOp, lane: AppGossipLane},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.lane, LaneOf(test.op))
+		})
+	}
+}
+
+func TestPrioritizedMessageQueueConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PrioritizedMessageQueueConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: PrioritizedMessageQueueConfig{
+				Quantum: 1,
+				Lanes:   [NumLanes]LaneConfig{{Weight: 1}, {Weight: 1}, {Weight: 1}, {Weight: 1}},
+			},
+		},
+		{
+			name: "zero quantum",
+			config: PrioritizedMessageQueueConfig{
+				Lanes: [NumLanes]LaneConfig{{Weight: 1}, {Weight: 1}, {Weight: 1}, {Weight: 1}},
+			},
+			expectedErr: errZeroQuantum,
+		},
+		{
+			name: "zero weight",
+			config: PrioritizedMessageQueueConfig{
+				Quantum: 1,
+				Lanes:   [NumLanes]LaneConfig{{Weight: 1}, {Weight: 1}, {Weight: 0}, {Weight: 1}},
+			},
+			expectedErr: errZeroLaneWeight,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPrioritizedMessageQueueWeightedScheduling(t *testing.T) {
+	require := require.New(t)
+
+	q, metrics := newTestPrioritizedMessageQueue(
+		t,
+		PrioritizedMessageQueueConfig{
+			Quantum: units.KiB,
+			Lanes: [NumLanes]LaneConfig{
+				ConsensusLane:  {Weight: 4},
+				BootstrapLane:  {Weight: 1},
+				AppRequestLane: {Weight: 1},
+				AppGossipLane:  {Weight: 1},
+			},
+		},
+		SendFailedFunc(func(message.OutboundMessage) {
+			require.FailNow("unexpected send failure")
+		}),
+	)
+
+	mc := newMessageCreator(t)
+	ancestors := []message.OutboundMessage{
+		newTestAncestors(t, mc, 2*units.KiB),
+		newTestAncestors(t, mc, 2*units.KiB),
+	}
+	pings := []message.OutboundMessage{
+		newTestPing(t, mc),
+		newTestPing(t, mc),
+		newTestPing(t, mc),
+	}
+
+	// The large bootstrapping messages are queued before the consensus
+	// messages.
+	for _, msg := range ancestors {
+		require.True(q.Push(context.Background(), msg))
+	}
+	for _, msg := range pings {
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	bootstrapLabel := BootstrapLane.String()
+	consensusLabel := ConsensusLane.String()
+	require.Equal(float64(len(ancestors)), testutil.ToFloat64(metrics.QueueLen.WithLabelValues(bootstrapLabel)))
+	require.Equal(float64(len(pings)), testutil.ToFloat64(metrics.QueueLen.WithLabelValues(consensusLabel)))
+
+	// The consensus messages should be sent first.
+	expected := append(pings, ancestors...)
+	for _, expectedMsg := range expected {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expectedMsg, msg)
+	}
+
+	_, ok := q.PopNow()
+	require.False(ok)
+
+	require.Zero(testutil.ToFloat64(metrics.QueueLen.WithLabelValues(bootstrapLabel)))
+	require.Zero(testutil.ToFloat64(metrics.QueueBytes.WithLabelValues(bootstrapLabel)))
+	require.Equal(uint64(len(ancestors)), numObservations(t, metrics.QueueWaitTime.WithLabelValues(bootstrapLabel)))
+	require.Equal(uint64(len(pings)), numObservations(t, metrics.QueueWaitTime.WithLabelValues(consensusLabel)))
+}
+
+func numObservations(t *testing.T, observer prometheus.Observer) uint64 {
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestPrioritizedMessageQueueLaneMaxBytes(t *testing.T) {
+	require := require.New(t)
+
+	var failed []message.OutboundMessage
+	q, metrics := newTestPrioritizedMessageQueue(
+		t,
+		PrioritizedMessageQueueConfig{
+			Quantum: units.KiB,
+			Lanes: [NumLanes]LaneConfig{
+				ConsensusLane:  {Weight: 1},
+				BootstrapLane:  {Weight: 1, MaxBytes: 3 * units.KiB},
+				AppRequestLane: {Weight: 1},
+				AppGossipLane:  {Weight: 1},
+			},
+		},
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			failed = append(failed, msg)
+		}),
+	)
+
+	mc := newMessageCreator(t)
+	first := newTestAncestors(t, mc, 2*units.KiB)
+	second := newTestAncestors(t, mc, 2*units.KiB)
+	ping := newTestPing(t, mc)
+
+	require.True(q.Push(context.Background(), first))
+	require.False(q.Push(context.Background(), second))
+	require.Equal([]message.OutboundMessage{second}, failed)
+	require.Equal(float64(1), testutil.ToFloat64(metrics.QueueDropped.WithLabelValues(BootstrapLane.String())))
+
+	// Other lanes are not impacted by the full bootstrap lane.
+	require.True(q.Push(context.Background(), ping))
+
+	// Closing the queue should report the remaining messages as failed.
+	q.Close()
+	require.ElementsMatch([]message.OutboundMessage{second, first, ping}, failed)
+	require.Zero(testutil.ToFloat64(metrics.QueueLen.WithLabelValues(BootstrapLane.String())))
+	require.Zero(testutil.ToFloat64(metrics.QueueLen.WithLabelValues(ConsensusLane.String())))
+
+	_, ok := q.Pop()
+	require.False(ok)
+	require.False(q.Push(context.Background(), ping))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/f01c5700/avalanchego/utils/math/meter"
 	"github.com/f01c5700/avalanchego/utils/resource"
 	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 	"github.com/f01c5700/avalanchego/version"
 )
 
@@ -131,6 +132,7 @@ func StartTestPeer(
 				)),
 				tlsKey,
 				blsKey,
+				&mockable.Clock{},
 			),
 		},
 		conn,
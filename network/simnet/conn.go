@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	errConnReset = errors.New("connection reset")
+
+	_ net.Conn = (*conn)(nil)
+)
+
+// conn is one end of an in-memory connection. Bytes written to a conn are
+// delivered to its peer by the simulator once the virtual clock reaches their
+// delivery time.
+//
+// Read deadlines are measured on the virtual clock. Write deadlines are
+// ignored, as writes never block.
+type conn struct {
+	sim    *Simulator
+	link   *link
+	local  netip.AddrPort
+	remote netip.AddrPort
+
+	// peer is set once during construction.
+	peer *conn
+
+	// lastDeliveryAt is the delivery time of the most recent write. It is
+	// guarded by the simulator's lock.
+	lastDeliveryAt time.Time
+	// deadlineGeneration is incremented whenever the read deadline changes so
+	// that the expiry of a previous deadline is ignored. It is guarded by the
+	// simulator's lock.
+	deadlineGeneration uint64
+
+	lock sync.Mutex
+	cond *sync.Cond
+	// buffer contains the bytes that have been delivered but not yet read.
+	buffer []byte
+	// closed is set once Close has been called on this end.
+	closed bool
+	// eof is set once the peer's Close has been delivered to this end.
+	eof bool
+	// reset is set if the connection was severed by a partition.
+	reset bool
+	// readTimedOut is set once the virtual clock reaches the read deadline.
+	readTimedOut bool
+}
+
+func newConnPair(
+	sim *Simulator,
+	clientIP netip.AddrPort,
+	clientLink *link,
+	serverIP netip.AddrPort,
+	serverLink *link,
+) (*conn, *conn) {
+	client := &conn{
+		sim:    sim,
+		link:   clientLink,
+		local:  clientIP,
+		remote: serverIP,
+	}
+	server := &conn{
+		sim:    sim,
+		link:   serverLink,
+		local:  serverIP,
+		remote: clientIP,
+	}
+	client.cond = sync.NewCond(&client.lock)
+	server.cond = sync.NewCond(&server.lock)
+	client.peer = server
+	server.peer = client
+	return client, server
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for len(c.buffer) == 0 && !c.closed && !c.eof && !c.reset && !c.readTimedOut {
+		c.cond.Wait()
+	}
+
+	switch {
+	case c.closed:
+		return 0, net.ErrClosed
+	case c.reset:
+		return 0, errConnReset
+	case c.readTimedOut:
+		return 0, os.ErrDeadlineExceeded
+	case len(c.buffer) > 0:
+		n := copy(b, c.buffer)
+		c.buffer = c.buffer[n:]
+		return n, nil
+	default:
+		return 0, io.EOF
+	}
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	c.lock.Lock()
+	switch {
+	case c.closed:
+		c.lock.Unlock()
+		return 0, net.ErrClosed
+	case c.reset:
+		c.lock.Unlock()
+		return 0, errConnReset
+	}
+	c.lock.Unlock()
+
+	c.sim.send(c, b)
+	return len(b), nil
+}
+
+func (c *conn) Close() error {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.cond.Broadcast()
+	c.lock.Unlock()
+
+	// The close is delivered after any bytes that were previously written.
+	c.sim.send(c, nil)
+	return nil
+}
+
+// deliver is called by the simulator when bytes written by the peer arrive.
+// A nil [b] indicates that the peer closed the connection.
+func (c *conn) deliver(b []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.closed || c.reset {
+		return
+	}
+	if b == nil {
+		c.eof = true
+	} else {
+		c.buffer = append(c.buffer, b...)
+	}
+	c.cond.Broadcast()
+}
+
+// sever immediately breaks the connection. Any bytes that were delivered but
+// not yet read are discarded.
+func (c *conn) sever() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.reset = true
+	c.buffer = nil
+	c.cond.Broadcast()
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return net.TCPAddrFromAddrPort(c.local)
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return net.TCPAddrFromAddrPort(c.remote)
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	expired := c.sim.setReadDeadline(c, t)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.readTimedOut = expired
+	c.cond.Broadcast()
+	return nil
+}
+
+// expireReadDeadline is called by the simulator once the virtual clock reaches
+// the read deadline.
+func (c *conn) expireReadDeadline() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.readTimedOut = true
+	c.cond.Broadcast()
+}
+
+func (*conn) SetWriteDeadline(time.Time) error {
+	return nil
+}
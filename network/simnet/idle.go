@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"bytes"
+	"runtime"
+
+	"github.com/f01c5700/avalanchego/utils/units"
+)
+
+const initialStackBufSize = 64 * units.KiB
+
+var goroutineHeaderPrefix = []byte("goroutine ")
+
+// waitUntilIdle blocks until every other goroutine in the process is blocked.
+//
+// Nodes only make progress when they receive bytes or when one of their timers
+// fires, both of which happen when the virtual clock is advanced. Once every
+// goroutine is blocked, the nodes have finished handling the previous events,
+// so the virtual clock can move forward without changing the virtual time at
+// which the nodes react to those events.
+//
+// Assumes [s.advanceLock] is held.
+func (s *Simulator) waitUntilIdle() {
+	if s.stackBuf == nil {
+		s.stackBuf = make([]byte, initialStackBufSize)
+	}
+	for {
+		runtime.Gosched()
+
+		n := runtime.Stack(s.stackBuf, true)
+		if n == len(s.stackBuf) {
+			// The stacks may have been truncated.
+			s.stackBuf = make([]byte, 2*len(s.stackBuf))
+			continue
+		}
+		if !hasRunningGoroutine(s.stackBuf[:n]) {
+			return
+		}
+	}
+}
+
+// hasRunningGoroutine returns true if any goroutine other than the first in
+// [stacks], which is the caller of [runtime.Stack], isn't blocked.
+//
+// Every goroutine in [stacks] starts with a header such as
+// "goroutine 7 [chan receive, 2 minutes]:", which contains the state of the
+// goroutine.
+func hasRunningGoroutine(stacks []byte) bool {
+	isCaller := true
+	for len(stacks) > 0 {
+		var line []byte
+		line, stacks, _ = bytes.Cut(stacks, []byte{'\n'})
+		if !bytes.HasPrefix(line, goroutineHeaderPrefix) {
+			continue
+		}
+		if isCaller {
+			isCaller = false
+			continue
+		}
+
+		_, state, _ := bytes.Cut(line, []byte{'['})
+		state, _, _ = bytes.Cut(state, []byte{']'})
+		state, _, _ = bytes.Cut(state, []byte{','})
+		if isRunningState(string(state)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRunningState returns true if a goroutine in [state] may make progress
+// without another goroutine unblocking it.
+func isRunningState(state string) bool {
+	switch state {
+	case "running", "runnable", "syscall", "preempted":
+		return true
+	default:
+		return false
+	}
+}
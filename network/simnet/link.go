@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// maxRetransmissions bounds the number of times a single write can be lost so
+// that a LossRate close to 1 can't stall the simulation forever.
+const maxRetransmissions = 16
+
+var (
+	errInvalidLossRate           = errors.New("loss rate must be in [0, 1)")
+	errNegativeLatency           = errors.New("latency must be non-negative")
+	errNegativeRetransmitTimeout = errors.New("retransmit timeout must be non-negative")
+)
+
+// LinkConfig describes the conditions of a directed link between two nodes.
+type LinkConfig struct {
+	// Latency is the one-way delay applied to every write.
+	Latency time.Duration `json:"latency"`
+	// Bandwidth is the number of bytes per second the link can transmit. If
+	// 0, the bandwidth is unlimited.
+	Bandwidth uint64 `json:"bandwidth"`
+	// LossRate is the probability that a write is lost in transit.
+	//
+	// Connections are reliable streams, so a lost write is retransmitted after
+	// RetransmitTimeout rather than dropped. Writes are always delivered in
+	// order, so a lost write also delays every write after it.
+	LossRate float64 `json:"lossRate"`
+	// RetransmitTimeout is the delay added each time a write is lost.
+	RetransmitTimeout time.Duration `json:"retransmitTimeout"`
+}
+
+func (c LinkConfig) Verify() error {
+	switch {
+	case c.Latency < 0:
+		return fmt.Errorf("%w: %s", errNegativeLatency, c.Latency)
+	case c.LossRate < 0 || c.LossRate >= 1:
+		return fmt.Errorf("%w: %f", errInvalidLossRate, c.LossRate)
+	case c.RetransmitTimeout < 0:
+		return fmt.Errorf("%w: %s", errNegativeRetransmitTimeout, c.RetransmitTimeout)
+	default:
+		return nil
+	}
+}
+
+// link tracks the state of a directed link between two nodes.
+type link struct {
+	config LinkConfig
+	// rng determines which writes on this link are lost. It is guarded by the
+	// simulator's lock.
+	rng *rand.Rand
+	// busyUntil is the time at which the link finishes transmitting all of the
+	// previously written bytes.
+	busyUntil time.Time
+}
+
+// deliveryTime returns the time at which a write of [numBytes] issued at
+// [now] arrives at the other end of the link.
+func (l *link) deliveryTime(now time.Time, numBytes int, rng *rand.Rand) time.Time {
+	start := now
+	if l.busyUntil.After(start) {
+		start = l.busyUntil
+	}
+
+	var transmitDuration time.Duration
+	if l.config.Bandwidth > 0 {
+		transmitDuration = time.Duration(uint64(numBytes) * uint64(time.Second) / l.config.Bandwidth)
+	}
+	l.busyUntil = start.Add(transmitDuration)
+
+	deliverAt := l.busyUntil.Add(l.config.Latency)
+	for i := 0; i < maxRetransmissions && rng.Float64() < l.config.LossRate; i++ {
+		deliverAt = deliverAt.Add(l.config.RetransmitTimeout)
+	}
+	return deliverAt
+}
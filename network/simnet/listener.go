@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/f01c5700/avalanchego/network/dialer"
+)
+
+var (
+	errRefused     = errors.New("connection refused")
+	errPartitioned = errors.New("destination is partitioned")
+
+	_ net.Listener  = (*listener)(nil)
+	_ dialer.Dialer = (*nodeDialer)(nil)
+)
+
+type listener struct {
+	ip      netip.AddrPort
+	inbound chan net.Conn
+
+	once   sync.Once
+	closed chan struct{}
+}
+
+func newListener(ip netip.AddrPort) *listener {
+	return &listener{
+		ip:      ip,
+		inbound: make(chan net.Conn),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.inbound:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return net.TCPAddrFromAddrPort(l.ip)
+}
+
+// nodeDialer dials other nodes in the simulation on behalf of a single node.
+type nodeDialer struct {
+	sim *Simulator
+	ip  netip.AddrPort
+}
+
+func (d *nodeDialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
+	client, server, listener, err := d.sim.connect(d.ip, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case listener.inbound <- server:
+		return client, nil
+	case <-ctx.Done():
+		d.sim.removeConn(client)
+		return nil, ctx.Err()
+	case <-listener.closed:
+		d.sim.removeConn(client)
+		return nil, errRefused
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/message"
+	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/snow/networking/router"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/upgrade"
+	"github.com/f01c5700/avalanchego/utils"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/version"
+)
+
+var _ router.ExternalHandler = (*noopRouter)(nil)
+
+type NodeConfig struct {
+	// Router receives the messages sent to the node. If nil, messages are
+	// dropped.
+	Router router.ExternalHandler
+	// Validators is the validator set of the node. If nil, the node has no
+	// validators.
+	Validators validators.Manager
+	// TrackedSubnets are the subnets the node tracks in addition to the
+	// primary network.
+	TrackedSubnets set.Set[ids.ID]
+	// Log is used by the node's network. If nil, nothing is logged.
+	Log logging.Logger
+	// Configure, if non-nil, is called with the node's network config before
+	// the network is created.
+	Configure func(*network.Config)
+}
+
+// Node is a network.Network running in the simulation.
+type Node struct {
+	NodeID  ids.NodeID
+	IP      netip.AddrPort
+	Network network.Network
+
+	done        chan struct{}
+	dispatchErr error
+}
+
+// AddNode creates a node and starts its network. The node isn't connected to
+// any other nodes until Connect or ConnectAll is called.
+func (s *Simulator) AddNode(config NodeConfig) (*Node, error) {
+	if config.Router == nil {
+		config.Router = noopRouter{}
+	}
+	if config.Validators == nil {
+		config.Validators = validators.NewManager()
+	}
+	if config.Log == nil {
+		config.Log = logging.NoLog{}
+	}
+
+	metrics := prometheus.NewRegistry()
+	msgCreator, err := message.NewCreator(
+		logging.NoLog{},
+		metrics,
+		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkMaximumInboundTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	networkConfig, err := network.NewTestNetworkConfig(
+		metrics,
+		s.config.NetworkID,
+		config.Validators,
+		config.TrackedSubnets,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := s.nextIP()
+	networkConfig.MyIPPort = utils.NewAtomic(ip)
+	networkConfig.Clock.SetSource(s)
+	// Nodes reconnect to each other when partitions heal, which the upgrade
+	// throttler would otherwise delay by its cooldown.
+	networkConfig.ThrottlerConfig.InboundConnUpgradeThrottlerConfig = throttling.InboundConnUpgradeThrottlerConfig{}
+	if config.Configure != nil {
+		config.Configure(networkConfig)
+	}
+
+	listener := s.addEndpoint(networkConfig.MyNodeID, ip)
+	net, err := network.NewNetwork(
+		networkConfig,
+		upgrade.InitiallyActiveTime,
+		msgCreator,
+		metrics,
+		config.Log,
+		listener,
+		&nodeDialer{
+			sim: s,
+			ip:  ip,
+		},
+		config.Router,
+	)
+	if err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	node := &Node{
+		NodeID:  networkConfig.MyNodeID,
+		IP:      ip,
+		Network: net,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(node.done)
+
+		node.dispatchErr = net.Dispatch()
+	}()
+
+	s.lock.Lock()
+	s.nodes = append(s.nodes, node)
+	s.lock.Unlock()
+	return node, nil
+}
+
+// Nodes returns the nodes in the order they were added.
+func (s *Simulator) Nodes() []*Node {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodes := make([]*Node, len(s.nodes))
+	copy(nodes, s.nodes)
+	return nodes
+}
+
+// Connect makes [from] repeatedly attempt to connect to [to].
+func (*Simulator) Connect(from *Node, to *Node) {
+	from.Network.ManuallyTrack(to.NodeID, to.IP)
+}
+
+// ConnectAll connects every pair of nodes.
+func (s *Simulator) ConnectAll() {
+	nodes := s.Nodes()
+	for i, from := range nodes {
+		for _, to := range nodes[i+1:] {
+			s.Connect(from, to)
+		}
+	}
+}
+
+// Close shuts down every node and waits for their networks to stop. Returns
+// the first error returned by a network's Dispatch.
+func (s *Simulator) Close() error {
+	nodes := s.Nodes()
+	for _, node := range nodes {
+		node.Network.StartClose()
+	}
+
+	var err error
+	for _, node := range nodes {
+		<-node.done
+		if err == nil {
+			err = node.dispatchErr
+		}
+	}
+	return err
+}
+
+// NumConnectedPeers returns the number of peers that the node has finished the
+// handshake with.
+func (n *Node) NumConnectedPeers() int {
+	return len(n.Network.PeerInfo(nil))
+}
+
+type noopRouter struct{}
+
+func (noopRouter) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	msg.OnFinishedHandling()
+}
+
+func (noopRouter) Connected(ids.NodeID, *version.Application, ids.ID) {}
+
+func (noopRouter) Disconnected(ids.NodeID) {}
@@ -0,0 +1,716 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/message"
+	"github.com/f01c5700/avalanchego/proto/pb/p2p"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/snow/networking/router"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/subnets"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/hashing"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/version"
+)
+
+const (
+	scenarioTimeout = 5 * time.Minute
+	scenarioStep    = 10 * time.Millisecond
+	// scenarioResolution batches events so that the nodes need to be waited on
+	// less often.
+	scenarioResolution = 10 * time.Millisecond
+	requestDeadline    = 10 * time.Second
+)
+
+var (
+	_ router.ExternalHandler = (*scenarioRouter)(nil)
+
+	scenarioChainID = ids.GenerateTestID()
+)
+
+// scenarioRouter passes the consensus and app messages received by a node to
+// [handle].
+type scenarioRouter struct {
+	handle func(message.InboundMessage)
+}
+
+func (r *scenarioRouter) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	defer msg.OnFinishedHandling()
+
+	if r.handle != nil {
+		r.handle(msg)
+	}
+}
+
+func (*scenarioRouter) Connected(ids.NodeID, *version.Application, ids.ID) {}
+
+func (*scenarioRouter) Disconnected(ids.NodeID) {}
+
+// newValidators adds [numNodes] nodes that are all primary network validators
+// with the same weight. The returned routers are used to handle the messages
+// received by the corresponding nodes.
+func newValidators(t *testing.T, s *Simulator, numNodes int) ([]*Node, []*scenarioRouter) {
+	require := require.New(t)
+
+	var (
+		vdrs    = validators.NewManager()
+		nodes   = make([]*Node, numNodes)
+		routers = make([]*scenarioRouter, numNodes)
+	)
+	for i := range nodes {
+		routers[i] = &scenarioRouter{}
+		node, err := s.AddNode(NodeConfig{
+			Router:     routers[i],
+			Validators: vdrs,
+		})
+		require.NoError(err)
+		nodes[i] = node
+	}
+	for _, node := range nodes {
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, node.NodeID, nil, ids.Empty, 1))
+	}
+	t.Cleanup(func() {
+		require.NoError(s.Close())
+	})
+	return nodes, routers
+}
+
+func newMessageCreator(t *testing.T) message.Creator {
+	msgCreator, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkMaximumInboundTimeout,
+	)
+	require.NoError(t, err)
+	return msgCreator
+}
+
+func send(node *Node, msg message.OutboundMessage, nodeIDs ...ids.NodeID) {
+	node.Network.Send(
+		msg,
+		common.SendConfig{
+			NodeIDs: set.Of(nodeIDs...),
+		},
+		constants.PrimaryNetworkID,
+		subnets.NoOpAllower,
+	)
+}
+
+func allConnected(nodes []*Node) func() bool {
+	return func() bool {
+		for _, node := range nodes {
+			if node.NumConnectedPeers() != len(nodes)-1 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func nodeIDsExcept(nodes []*Node, except *Node) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, 0, len(nodes)-1)
+	for _, node := range nodes {
+		if node != except {
+			nodeIDs = append(nodeIDs, node.NodeID)
+		}
+	}
+	return nodeIDs
+}
+
+// Nodes that only know about a single bootstrapper discover every other
+// validator through peer list gossip, after which app gossip is flooded to the
+// whole network.
+func TestScenarioGossip(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+
+	const (
+		numNodes = 30
+		latency  = 50 * time.Millisecond
+	)
+	s, err := New(Config{
+		NetworkID: constants.LocalID,
+		DefaultLink: LinkConfig{
+			Latency:   latency,
+			Bandwidth: 10 * 1024 * 1024,
+		},
+		Resolution: scenarioResolution,
+	})
+	require.NoError(err)
+
+	nodes, routers := newValidators(t, s, numNodes)
+	for _, node := range nodes[1:] {
+		s.Connect(node, nodes[0])
+	}
+	require.NoError(s.RunUntil(ctx, scenarioStep, allConnected(nodes)))
+
+	var (
+		msgCreator = newMessageCreator(t)
+		gossip     = []byte("gossip")
+
+		lock       sync.Mutex
+		receivedAt = make(map[ids.NodeID]time.Time)
+		numCopies  = make(map[ids.NodeID]int)
+	)
+	for i, node := range nodes {
+		node := node
+		routers[i].handle = func(msg message.InboundMessage) {
+			appGossip, ok := msg.Message().(*p2p.AppGossip)
+			if !ok || string(appGossip.AppBytes) != string(gossip) {
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			numCopies[node.NodeID]++
+			if _, ok := receivedAt[node.NodeID]; ok {
+				return
+			}
+			receivedAt[node.NodeID] = s.Now()
+
+			// Forward the gossip to every other node the first time it is
+			// received.
+			outMsg, err := msgCreator.AppGossip(scenarioChainID, gossip)
+			if err != nil {
+				return
+			}
+			send(node, outMsg, nodeIDsExcept(nodes, node)...)
+		}
+	}
+
+	// The first node doesn't forward the gossip that it sent.
+	receivedAt[nodes[0].NodeID] = s.Now()
+	sentAt := s.Now()
+	outMsg, err := msgCreator.AppGossip(scenarioChainID, gossip)
+	require.NoError(err)
+	send(nodes[0], outMsg, nodeIDsExcept(nodes, nodes[0])...)
+
+	allCopiesReceived := func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		for _, node := range nodes {
+			if numCopies[node.NodeID] != numNodes-1 {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(s.RunUntil(ctx, scenarioStep, allCopiesReceived))
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Every node is directly connected to the first node, so the gossip
+	// arrives after a single hop.
+	for _, node := range nodes[1:] {
+		elapsed := receivedAt[node.NodeID].Sub(sentAt)
+		require.GreaterOrEqual(elapsed, latency)
+		require.Less(elapsed, 2*latency)
+	}
+}
+
+// block is a container of the chain served by the beacons in
+// TestScenarioBootstrapping.
+type block struct {
+	id       ids.ID
+	parentID ids.ID
+	height   uint64
+	bytes    []byte
+}
+
+func newBlock(parentID ids.ID, height uint64) *block {
+	bytes := binary.BigEndian.AppendUint64(parentID[:], height)
+	return &block{
+		id:       hashing.ComputeHash256Array(bytes),
+		parentID: parentID,
+		height:   height,
+		bytes:    bytes,
+	}
+}
+
+func parseBlock(bytes []byte) (*block, bool) {
+	if len(bytes) != ids.IDLen+8 {
+		return nil, false
+	}
+	return &block{
+		id:       hashing.ComputeHash256Array(bytes),
+		parentID: ids.ID(bytes[:ids.IDLen]),
+		height:   binary.BigEndian.Uint64(bytes[ids.IDLen:]),
+		bytes:    bytes,
+	}, true
+}
+
+// newChain returns a chain of [length] blocks, indexed by height.
+func newChain(genesisParentID ids.ID, length int) []*block {
+	chain := make([]*block, length)
+	parentID := genesisParentID
+	for height := range chain {
+		chain[height] = newBlock(parentID, uint64(height))
+		parentID = chain[height].id
+	}
+	return chain
+}
+
+// bootstrapper fetches the chain accepted by a majority of the beacons. It
+// requests the accepted frontier from every beacon and then fetches the
+// ancestors of the frontier that a majority of the beacons reported.
+type bootstrapper struct {
+	sim        *Simulator
+	msgCreator message.Creator
+	node       *Node
+	// beacons are asked for the accepted frontier.
+	beacons []ids.NodeID
+	// servers are asked for ancestors, in turn.
+	servers []ids.NodeID
+
+	lock          sync.Mutex
+	frontierVotes map[ids.NodeID]ids.ID
+	requestID     uint32
+	// fetching is the ID of the block that was most recently requested.
+	fetching ids.ID
+	fetched  []*block
+	doneAt   time.Time
+	failed   bool
+}
+
+func (b *bootstrapper) start() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	msg, err := b.msgCreator.GetAcceptedFrontier(scenarioChainID, b.requestID, requestDeadline)
+	if err != nil {
+		return err
+	}
+	send(b.node, msg, b.beacons...)
+	return nil
+}
+
+func (b *bootstrapper) done() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return !b.doneAt.IsZero() || b.failed
+}
+
+func (b *bootstrapper) handle(msg message.InboundMessage) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch m := msg.Message().(type) {
+	case *p2p.AcceptedFrontier:
+		b.handleAcceptedFrontier(msg.NodeID(), m)
+	case *p2p.Ancestors:
+		b.handleAncestors(m)
+	}
+}
+
+// Assumes [b.lock] is held.
+func (b *bootstrapper) handleAcceptedFrontier(nodeID ids.NodeID, m *p2p.AcceptedFrontier) {
+	if m.RequestId != 0 || b.fetching != ids.Empty {
+		return
+	}
+	containerID, err := ids.ToID(m.ContainerId)
+	if err != nil {
+		return
+	}
+	b.frontierVotes[nodeID] = containerID
+	if len(b.frontierVotes) != len(b.beacons) {
+		return
+	}
+
+	votes := make(map[ids.ID]int)
+	for _, frontier := range b.frontierVotes {
+		votes[frontier]++
+	}
+	for frontier, numVotes := range votes {
+		if 2*numVotes > len(b.beacons) {
+			b.fetch(frontier)
+			return
+		}
+	}
+	b.failed = true
+}
+
+// Assumes [b.lock] is held.
+func (b *bootstrapper) handleAncestors(m *p2p.Ancestors) {
+	if m.RequestId != b.requestID || len(m.Containers) == 0 {
+		return
+	}
+
+	expectedID := b.fetching
+	for _, bytes := range m.Containers {
+		blk, ok := parseBlock(bytes)
+		if !ok || blk.id != expectedID {
+			b.failed = true
+			return
+		}
+		b.fetched = append(b.fetched, blk)
+		if blk.height == 0 {
+			b.doneAt = b.sim.Now()
+			return
+		}
+		expectedID = blk.parentID
+	}
+	b.fetch(expectedID)
+}
+
+// Assumes [b.lock] is held.
+func (b *bootstrapper) fetch(blkID ids.ID) {
+	b.requestID++
+	b.fetching = blkID
+	msg, err := b.msgCreator.GetAncestors(scenarioChainID, b.requestID, requestDeadline, blkID, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	if err != nil {
+		b.failed = true
+		return
+	}
+	server := b.servers[int(b.requestID)%len(b.servers)]
+	send(b.node, msg, server)
+}
+
+// serveChain returns a message handler that serves [chain] to bootstrapping
+// nodes.
+func serveChain(node *Node, msgCreator message.Creator, chain []*block, maxContainers int) func(message.InboundMessage) {
+	blocks := make(map[ids.ID]*block, len(chain))
+	for _, blk := range chain {
+		blocks[blk.id] = blk
+	}
+	return func(msg message.InboundMessage) {
+		var (
+			outMsg message.OutboundMessage
+			err    error
+		)
+		switch m := msg.Message().(type) {
+		case *p2p.GetAcceptedFrontier:
+			outMsg, err = msgCreator.AcceptedFrontier(scenarioChainID, m.RequestId, chain[len(chain)-1].id)
+		case *p2p.GetAncestors:
+			blkID, idErr := ids.ToID(m.ContainerId)
+			blk, ok := blocks[blkID]
+			if idErr != nil || !ok {
+				return
+			}
+
+			var containers [][]byte
+			for len(containers) < maxContainers {
+				containers = append(containers, blk.bytes)
+				if blk.height == 0 {
+					break
+				}
+				blk = chain[blk.height-1]
+			}
+			outMsg, err = msgCreator.Ancestors(scenarioChainID, m.RequestId, containers)
+		default:
+			return
+		}
+		if err == nil {
+			send(node, outMsg, msg.NodeID())
+		}
+	}
+}
+
+// A new node fetches the chain accepted by a majority of the beacons, even
+// though a minority of the beacons report a different frontier and one of the
+// beacons is far away.
+func TestScenarioBootstrapping(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+
+	const (
+		numBeacons          = 24
+		numByzantineBeacons = 5
+		chainLength         = 256
+		maxContainers       = 32
+		latency             = 50 * time.Millisecond
+		slowLatency         = 500 * time.Millisecond
+	)
+	s, err := New(Config{
+		NetworkID: constants.LocalID,
+		DefaultLink: LinkConfig{
+			Latency:   latency,
+			Bandwidth: 10 * 1024 * 1024,
+		},
+	})
+	require.NoError(err)
+
+	nodes, routers := newValidators(t, s, numBeacons+1)
+	s.ConnectAll()
+	require.NoError(s.RunUntil(ctx, scenarioStep, allConnected(nodes)))
+
+	var (
+		msgCreator     = newMessageCreator(t)
+		chain          = newChain(ids.Empty, chainLength)
+		byzantineChain = newChain(ids.GenerateTestID(), chainLength)
+		beacons        = nodes[:numBeacons]
+		slowBeacon     = beacons[numBeacons-1]
+		newNode        = nodes[numBeacons]
+	)
+	for i, beacon := range beacons {
+		beaconChain := chain
+		if i < numByzantineBeacons {
+			beaconChain = byzantineChain
+		}
+		routers[i].handle = serveChain(beacon, msgCreator, beaconChain, maxContainers)
+	}
+	require.NoError(s.SetLink(newNode.NodeID, slowBeacon.NodeID, LinkConfig{Latency: slowLatency}))
+	require.NoError(s.SetLink(slowBeacon.NodeID, newNode.NodeID, LinkConfig{Latency: slowLatency}))
+
+	b := &bootstrapper{
+		sim:           s,
+		msgCreator:    msgCreator,
+		node:          newNode,
+		beacons:       nodeIDsExcept(beacons, nil),
+		servers:       nodeIDsExcept(beacons[numByzantineBeacons:numBeacons-1], nil),
+		frontierVotes: make(map[ids.NodeID]ids.ID),
+	}
+	routers[numBeacons].handle = b.handle
+
+	startedAt := s.Now()
+	require.NoError(b.start())
+	require.NoError(s.RunUntil(ctx, scenarioStep, b.done))
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	require.False(b.failed)
+	require.Len(b.fetched, chainLength)
+	for i, blk := range b.fetched {
+		require.Equal(chain[chainLength-1-i].id, blk.id)
+	}
+
+	// Fetching the frontier waits for the slow beacon, after which every
+	// batch of ancestors takes a round trip.
+	var (
+		numBatches  = chainLength / maxContainers
+		minDuration = 2*slowLatency + time.Duration(numBatches)*2*latency
+		elapsed     = b.doneAt.Sub(startedAt)
+	)
+	require.GreaterOrEqual(elapsed, minDuration)
+	require.Less(elapsed, minDuration+latency)
+}
+
+// poller runs a snowball instance that polls a random sample of the other
+// nodes every poll interval.
+type poller struct {
+	msgCreator message.Creator
+	node       *Node
+	peers      []ids.NodeID
+	params     snowball.Parameters
+	rng        *rand.Rand
+
+	lock      sync.Mutex
+	consensus snowball.Nnary
+	requestID uint32
+	// votes are the preferences reported for the current poll.
+	votes map[ids.NodeID]ids.ID
+}
+
+func (p *poller) run(ticker <-chan time.Time, done <-chan struct{}) {
+	for {
+		select {
+		case <-ticker:
+			p.poll()
+		case <-done:
+			return
+		}
+	}
+}
+
+func (p *poller) poll() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.consensus.Finalized() {
+		return
+	}
+	if len(p.votes) != 0 {
+		// The previous poll didn't finish before the next poll started.
+		p.consensus.RecordUnsuccessfulPoll()
+	}
+
+	p.requestID++
+	p.votes = make(map[ids.NodeID]ids.ID, p.params.K)
+	p.rng.Shuffle(len(p.peers), func(i, j int) {
+		p.peers[i], p.peers[j] = p.peers[j], p.peers[i]
+	})
+	msg, err := p.msgCreator.PullQuery(scenarioChainID, p.requestID, requestDeadline, p.consensus.Preference(), 0)
+	if err != nil {
+		return
+	}
+	send(p.node, msg, p.peers[:p.params.K]...)
+}
+
+func (p *poller) handle(msg message.InboundMessage) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch m := msg.Message().(type) {
+	case *p2p.PullQuery:
+		preference := p.consensus.Preference()
+		outMsg, err := p.msgCreator.Chits(scenarioChainID, m.RequestId, preference, preference, preference)
+		if err == nil {
+			send(p.node, outMsg, msg.NodeID())
+		}
+	case *p2p.Chits:
+		if m.RequestId != p.requestID || p.votes == nil {
+			return
+		}
+		preference, err := ids.ToID(m.PreferredId)
+		if err != nil {
+			return
+		}
+		p.votes[msg.NodeID()] = preference
+		if len(p.votes) != p.params.K {
+			return
+		}
+
+		counts := make(map[ids.ID]int)
+		for _, vote := range p.votes {
+			counts[vote]++
+		}
+		var (
+			choice    ids.ID
+			maxCount  int
+			candidate = []ids.ID{p.consensus.Preference()}
+		)
+		for vote := range counts {
+			candidate = append(candidate, vote)
+		}
+		for _, vote := range candidate {
+			if counts[vote] > maxCount {
+				choice, maxCount = vote, counts[vote]
+			}
+		}
+		p.consensus.RecordPoll(maxCount, choice)
+		p.votes = nil
+	}
+}
+
+func (p *poller) result() (ids.ID, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.consensus.Preference(), p.consensus.Finalized()
+}
+
+// Validators that are split between two choices finalize the same choice by
+// repeatedly polling each other with PullQuery and Chits messages over links
+// with varying latencies.
+func TestScenarioConsensus(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	defer cancel()
+
+	const (
+		numNodes     = 30
+		pollInterval = 250 * time.Millisecond
+	)
+	s, err := New(Config{
+		NetworkID: constants.LocalID,
+		DefaultLink: LinkConfig{
+			Latency:   20 * time.Millisecond,
+			Bandwidth: 10 * 1024 * 1024,
+		},
+		Resolution: scenarioResolution,
+	})
+	require.NoError(err)
+
+	nodes, routers := newValidators(t, s, numNodes)
+	for i, from := range nodes {
+		for j, to := range nodes {
+			latency := time.Duration(10+(i+j)%7*10) * time.Millisecond
+			require.NoError(s.SetLink(from.NodeID, to.NodeID, LinkConfig{Latency: latency}))
+		}
+	}
+	s.ConnectAll()
+	require.NoError(s.RunUntil(ctx, scenarioStep, allConnected(nodes)))
+
+	var (
+		msgCreator = newMessageCreator(t)
+		params     = snowball.Parameters{
+			K:               10,
+			AlphaPreference: 6,
+			AlphaConfidence: 8,
+			Beta:            15,
+		}
+		choiceA = ids.GenerateTestID()
+		choiceB = ids.GenerateTestID()
+		done    = make(chan struct{})
+		wg      sync.WaitGroup
+		pollers = make([]*poller, numNodes)
+	)
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+	for i, node := range nodes {
+		initialChoice, otherChoice := choiceA, choiceB
+		if i%2 == 1 {
+			initialChoice, otherChoice = otherChoice, initialChoice
+		}
+		consensus := snowball.SnowballFactory.NewNnary(params, initialChoice)
+		consensus.Add(otherChoice)
+
+		pollers[i] = &poller{
+			msgCreator: msgCreator,
+			node:       node,
+			peers:      nodeIDsExcept(nodes, node),
+			params:     params,
+			rng:        rand.New(rand.NewSource(int64(i))), // #nosec G404
+			consensus:  consensus,
+		}
+		routers[i].handle = pollers[i].handle
+
+		ticker := s.NewTicker(pollInterval)
+		wg.Add(1)
+		go func(p *poller) {
+			defer func() {
+				ticker.Stop()
+				wg.Done()
+			}()
+			p.run(ticker.C(), done)
+		}(pollers[i])
+	}
+
+	startedAt := s.Now()
+	allFinalized := func() bool {
+		for _, p := range pollers {
+			if _, finalized := p.result(); !finalized {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(s.RunUntil(ctx, scenarioStep, allFinalized))
+
+	decision, _ := pollers[0].result()
+	for _, p := range pollers {
+		preference, _ := p.result()
+		require.Equal(decision, preference)
+	}
+
+	// Finalization requires at least [Beta] consecutive successful polls.
+	require.GreaterOrEqual(s.Now().Sub(startedAt), time.Duration(params.Beta)*pollInterval)
+}
@@ -0,0 +1,482 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simnet runs many in-process network.Network instances over simulated
+// connections.
+//
+// Bytes written to a simulated connection are held by the Simulator until its
+// virtual clock reaches their delivery time, which is determined by the
+// latency, bandwidth and loss rate of the link they were written to. The
+// Simulator is also the clock of every node, so the timers of the nodes, such
+// as ping and gossip frequencies or reconnect backoffs, fire on the virtual
+// clock.
+//
+// The virtual clock only moves forward once every goroutine in the process is
+// blocked, so that everything the nodes do in response to a delivery or a timer
+// happens at the virtual time of that event. Given the same seed, the same
+// events happen at the same virtual times regardless of how fast the host is.
+// The order in which a node handles events that happen at the same virtual
+// time is still up to the Go scheduler.
+package simnet
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/hashing"
+	"github.com/f01c5700/avalanchego/utils/heap"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// nodePort is the port that every simulated node listens on.
+	nodePort = 9651
+	// firstEphemeralPort is the first port assigned to the dialing side of a
+	// connection.
+	firstEphemeralPort = 49152
+
+	DefaultResolution = time.Millisecond
+)
+
+var (
+	_ mockable.Source = (*Simulator)(nil)
+
+	errUnknownNode        = errors.New("unknown node")
+	errNegativeResolution = errors.New("resolution must be non-negative")
+)
+
+type Config struct {
+	// NetworkID is the network ID used by every node.
+	NetworkID uint32 `json:"networkID"`
+	// Seed is used to determine which writes are lost. Every link draws from
+	// its own source of randomness, so the writes on one link don't change
+	// which writes are lost on another.
+	Seed int64 `json:"seed"`
+	// DefaultLink is the config of every link that wasn't modified by SetLink.
+	DefaultLink LinkConfig `json:"defaultLink"`
+	// Resolution is the granularity of the virtual clock. The time of every
+	// event is rounded up to a multiple of Resolution, so that events that
+	// happen close together are applied together. A finer resolution is more
+	// accurate but slower, as the simulator waits for the nodes to become idle
+	// after every distinct event time. If 0, [DefaultResolution] is used.
+	Resolution time.Duration `json:"resolution"`
+}
+
+type linkKey struct {
+	from ids.NodeID
+	to   ids.NodeID
+}
+
+type endpoint struct {
+	nodeID   ids.NodeID
+	ip       netip.AddrPort
+	listener *listener
+}
+
+// event is the delivery of bytes to a connection, the expiry of a read
+// deadline or the firing of a timer.
+type event struct {
+	at time.Time
+	// seq breaks ties between events with the same time so that they happen
+	// in the order they were scheduled.
+	seq uint64
+
+	// to and data are set for deliveries.
+	to   *conn
+	data []byte
+
+	// expire is set for read deadlines.
+	expire *conn
+
+	// timer is set for timers.
+	timer *timer
+
+	// generation is set for read deadlines and timers. The event is ignored if
+	// the deadline or the timer changed after the event was scheduled.
+	generation uint64
+}
+
+func eventLess(a, b *event) bool {
+	if !a.at.Equal(b.at) {
+		return a.at.Before(b.at)
+	}
+	return a.seq < b.seq
+}
+
+// Simulator connects nodes over simulated links driven by a virtual clock.
+type Simulator struct {
+	config Config
+
+	// advanceLock ensures that events are applied in order when Advance is
+	// called concurrently.
+	advanceLock sync.Mutex
+	// stackBuf is used to check whether the nodes are idle. It is guarded by
+	// advanceLock.
+	stackBuf []byte
+
+	lock     sync.Mutex
+	now      time.Time
+	events   heap.Queue[*event]
+	nextSeq  uint64
+	nextPort uint16
+	numIPs   int
+
+	endpoints map[netip.AddrPort]*endpoint
+	links     map[linkKey]*link
+	// conns contains the dialing side of every open connection.
+	conns set.Set[*conn]
+	// groups maps nodes to their partition. Nodes can only communicate with
+	// nodes in the same partition. Nodes that aren't in the map are in
+	// partition 0.
+	groups map[ids.NodeID]int
+
+	nodes []*Node
+}
+
+func New(config Config) (*Simulator, error) {
+	if err := config.DefaultLink.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid default link: %w", err)
+	}
+	switch {
+	case config.Resolution < 0:
+		return nil, fmt.Errorf("%w: %s", errNegativeResolution, config.Resolution)
+	case config.Resolution == 0:
+		config.Resolution = DefaultResolution
+	}
+	return &Simulator{
+		config:    config,
+		now:       time.Unix(0, 0),
+		events:    heap.NewQueue(eventLess),
+		nextPort:  firstEphemeralPort,
+		endpoints: make(map[netip.AddrPort]*endpoint),
+		links:     make(map[linkKey]*link),
+		conns:     set.Set[*conn]{},
+		groups:    make(map[ids.NodeID]int),
+	}, nil
+}
+
+// Now returns the current time of the virtual clock.
+func (s *Simulator) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.now
+}
+
+// NewTicker returns a ticker that ticks every [d] on the virtual clock.
+func (s *Simulator) NewTicker(d time.Duration) mockable.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return &ticker{
+		timer: s.newTimer(d, d),
+	}
+}
+
+// NewTimer returns a timer that fires after [d] on the virtual clock.
+func (s *Simulator) NewTimer(d time.Duration) mockable.Timer {
+	return s.newTimer(d, 0)
+}
+
+// Advance moves the virtual clock forward by [duration]. Every event that
+// happens by the new time is applied in order. Before each event, and before
+// returning, Advance waits for the nodes to finish handling the previous
+// events.
+func (s *Simulator) Advance(duration time.Duration) {
+	s.advanceLock.Lock()
+	defer s.advanceLock.Unlock()
+
+	s.waitUntilIdle()
+	s.advance(duration)
+}
+
+// RunUntil repeatedly advances the virtual clock by [step] until [done]
+// returns true or [ctx] is cancelled. [done] is called while the nodes are
+// idle and must not cause them to do anything, such as sending a message.
+func (s *Simulator) RunUntil(ctx context.Context, step time.Duration, done func() bool) error {
+	s.advanceLock.Lock()
+	defer s.advanceLock.Unlock()
+
+	s.waitUntilIdle()
+	for !done() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.advance(step)
+	}
+	return nil
+}
+
+// advance moves the virtual clock forward by [duration].
+//
+// Assumes [s.advanceLock] is held and the nodes are idle.
+func (s *Simulator) advance(duration time.Duration) {
+	s.lock.Lock()
+	end := s.now.Add(duration)
+	s.lock.Unlock()
+
+	for {
+		events, ok := s.fireNextEvents(end)
+		if !ok {
+			return
+		}
+		for _, e := range events {
+			if e.expire != nil {
+				e.expire.expireReadDeadline()
+			} else {
+				e.to.deliver(e.data)
+			}
+		}
+		s.waitUntilIdle()
+	}
+}
+
+// fireNextEvents moves the virtual clock to the time of the next event that
+// may wake up a node. The timers scheduled at that time are fired and the
+// deliveries and read deadlines scheduled at that time are returned. If there
+// is no such event by [end], the virtual clock is moved to [end] and false is
+// returned.
+func (s *Simulator) fireNextEvents(end time.Time) ([]*event, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for {
+		next, ok := s.events.Peek()
+		if !ok || next.at.After(end) {
+			s.now = end
+			return nil, false
+		}
+
+		s.now = next.at
+		var (
+			events []*event
+			fired  bool
+		)
+		for {
+			next, ok := s.events.Peek()
+			if !ok || !next.at.Equal(s.now) {
+				break
+			}
+			_, _ = s.events.Pop()
+
+			switch {
+			case next.timer != nil:
+				fired = s.fire(next) || fired
+			case next.expire != nil:
+				if next.generation == next.expire.deadlineGeneration {
+					events = append(events, next)
+				}
+			default:
+				events = append(events, next)
+			}
+		}
+		if fired || len(events) > 0 {
+			return events, true
+		}
+	}
+}
+
+// SetLink sets the config of the link that carries bytes from [from] to [to].
+// The config applies to both existing and future connections.
+func (s *Simulator) SetLink(from ids.NodeID, to ids.NodeID, config LinkConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.getLink(from, to).config = config
+	return nil
+}
+
+// Partition splits the nodes into the provided groups. Nodes that aren't in
+// any of the groups form one additional group. Connections between nodes in
+// different groups are severed immediately and new connections between them
+// are refused until the partition is changed or healed.
+func (s *Simulator) Partition(groups ...[]ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.groups = make(map[ids.NodeID]int)
+	for i, group := range groups {
+		for _, nodeID := range group {
+			s.groups[nodeID] = i + 1
+		}
+	}
+
+	for c := range s.conns {
+		local := s.endpoints[netip.AddrPortFrom(c.local.Addr(), nodePort)]
+		remote := s.endpoints[c.remote]
+		if s.groups[local.nodeID] == s.groups[remote.nodeID] {
+			continue
+		}
+
+		c.sever()
+		c.peer.sever()
+		s.conns.Remove(c)
+	}
+}
+
+// Heal removes any partitions.
+func (s *Simulator) Heal() {
+	s.Partition()
+}
+
+// send schedules the delivery of [b] to the peer of [c]. A nil [b] schedules
+// the delivery of a close.
+func (s *Simulator) send(c *conn, b []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	deliverAt := c.link.deliveryTime(s.now, len(b), c.link.rng)
+	if deliverAt.Before(c.lastDeliveryAt) {
+		deliverAt = c.lastDeliveryAt
+	}
+	c.lastDeliveryAt = deliverAt
+
+	s.push(&event{
+		at:   deliverAt,
+		to:   c.peer,
+		data: slices.Clone(b),
+	})
+
+	if b == nil {
+		s.conns.Remove(c, c.peer)
+	}
+}
+
+// nextIP returns a new IP for a node.
+func (s *Simulator) nextIP() netip.AddrPort {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Private IPs are used, so the network must allow private IPs.
+	s.numIPs++
+	i := s.numIPs
+	return netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{10, 0, byte(i >> 8), byte(i)}),
+		nodePort,
+	)
+}
+
+func (s *Simulator) addEndpoint(nodeID ids.NodeID, ip netip.AddrPort) *listener {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e := &endpoint{
+		nodeID:   nodeID,
+		ip:       ip,
+		listener: newListener(ip),
+	}
+	s.endpoints[ip] = e
+	return e.listener
+}
+
+// connect creates a connection from the node listening on [from] to the node
+// listening on [to].
+func (s *Simulator) connect(from netip.AddrPort, to netip.AddrPort) (*conn, *conn, *listener, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	src, ok := s.endpoints[from]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: %s", errUnknownNode, from)
+	}
+	dst, ok := s.endpoints[to]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("%w: %s", errRefused, to)
+	}
+	if s.groups[src.nodeID] != s.groups[dst.nodeID] {
+		return nil, nil, nil, fmt.Errorf("%w: %s", errPartitioned, to)
+	}
+
+	clientIP := netip.AddrPortFrom(from.Addr(), s.nextPort)
+	s.nextPort++
+	if s.nextPort == 0 {
+		s.nextPort = firstEphemeralPort
+	}
+
+	client, server := newConnPair(
+		s,
+		clientIP,
+		s.getLink(src.nodeID, dst.nodeID),
+		to,
+		s.getLink(dst.nodeID, src.nodeID),
+	)
+	s.conns.Add(client)
+	return client, server, dst.listener, nil
+}
+
+func (s *Simulator) removeConn(c *conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.conns.Remove(c)
+}
+
+// Assumes [s.lock] is held.
+func (s *Simulator) getLink(from ids.NodeID, to ids.NodeID) *link {
+	key := linkKey{
+		from: from,
+		to:   to,
+	}
+	l, ok := s.links[key]
+	if !ok {
+		l = &link{
+			config: s.config.DefaultLink,
+			rng:    rand.New(rand.NewSource(linkSeed(s.config.Seed, key))), // #nosec G404
+		}
+		s.links[key] = l
+	}
+	return l
+}
+
+// setReadDeadline schedules the expiry of the read deadline of [c]. Returns
+// true if [deadline] already passed.
+func (s *Simulator) setReadDeadline(c *conn, deadline time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c.deadlineGeneration++
+	if deadline.IsZero() {
+		return false
+	}
+	if !deadline.After(s.now) {
+		return true
+	}
+	s.push(&event{
+		at:         deadline,
+		expire:     c,
+		generation: c.deadlineGeneration,
+	})
+	return false
+}
+
+// push schedules [e] at the first multiple of the resolution that isn't before
+// the time of [e].
+//
+// Assumes [s.lock] is held.
+func (s *Simulator) push(e *event) {
+	if remainder := e.at.Sub(time.Unix(0, 0)) % s.config.Resolution; remainder > 0 {
+		e.at = e.at.Add(s.config.Resolution - remainder)
+	}
+	e.seq = s.nextSeq
+	s.nextSeq++
+	s.events.Push(e)
+}
+
+// linkSeed returns the seed of the link identified by [key].
+func linkSeed(seed int64, key linkKey) int64 {
+	hash := hashing.ComputeHash256(append(key.from.Bytes(), key.to.Bytes()...))
+	return seed ^ int64(binary.BigEndian.Uint64(hash))
+}
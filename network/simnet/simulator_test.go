@@ -0,0 +1,354 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/utils/constants"
+)
+
+func TestLinkConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      LinkConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: LinkConfig{
+				Latency:           time.Second,
+				Bandwidth:         1,
+				LossRate:          .5,
+				RetransmitTimeout: time.Second,
+			},
+		},
+		{
+			name: "negative latency",
+			config: LinkConfig{
+				Latency: -1,
+			},
+			expectedErr: errNegativeLatency,
+		},
+		{
+			name: "loss rate of 1",
+			config: LinkConfig{
+				LossRate: 1,
+			},
+			expectedErr: errInvalidLossRate,
+		},
+		{
+			name: "negative retransmit timeout",
+			config: LinkConfig{
+				RetransmitTimeout: -1,
+			},
+			expectedErr: errNegativeRetransmitTimeout,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestLinkDeliveryTime(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		rng  = rand.New(rand.NewSource(0)) // #nosec G404
+		now  = time.Unix(0, 0)
+		link = &link{
+			config: LinkConfig{
+				Latency:   100 * time.Millisecond,
+				Bandwidth: 1000,
+			},
+		}
+	)
+
+	// 500 bytes take 500ms to transmit and then 100ms to arrive.
+	require.Equal(now.Add(600*time.Millisecond), link.deliveryTime(now, 500, rng))
+
+	// The second write must wait for the first write to be transmitted.
+	require.Equal(now.Add(1100*time.Millisecond), link.deliveryTime(now, 500, rng))
+
+	// Once the link is idle, writes are transmitted immediately.
+	later := now.Add(5 * time.Second)
+	require.Equal(later.Add(100*time.Millisecond), link.deliveryTime(later, 0, rng))
+}
+
+func TestLinkDeliveryTimeDeterministic(t *testing.T) {
+	config := LinkConfig{
+		Latency:           10 * time.Millisecond,
+		LossRate:          .5,
+		RetransmitTimeout: time.Second,
+	}
+	deliveryTimes := func() []time.Time {
+		var (
+			rng  = rand.New(rand.NewSource(1)) // #nosec G404
+			now  = time.Unix(0, 0)
+			link = &link{
+				config: config,
+			}
+			times = make([]time.Time, 100)
+		)
+		for i := range times {
+			times[i] = link.deliveryTime(now, 1, rng)
+		}
+		return times
+	}
+
+	times := deliveryTimes()
+	require.Equal(t, times, deliveryTimes())
+
+	var numLost int
+	for _, deliverAt := range times {
+		if deliverAt.Sub(time.Unix(0, 0)) >= time.Second {
+			numLost++
+		}
+	}
+	require.Positive(t, numLost)
+	require.Less(t, numLost, len(times))
+}
+
+// dial connects two endpoints that aren't running a network.
+func dial(t *testing.T, s *Simulator, from *nodeDialer, to *listener) (net.Conn, net.Conn) {
+	require := require.New(t)
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, err := to.Accept()
+		require.NoError(err)
+		accepted <- c
+	}()
+
+	client, err := from.Dial(context.Background(), to.ip)
+	require.NoError(err)
+	return client, <-accepted
+}
+
+func newTestEndpoints(t *testing.T, config Config) (*Simulator, ids.NodeID, *nodeDialer, ids.NodeID, *listener) {
+	s, err := New(config)
+	require.NoError(t, err)
+
+	var (
+		clientID = ids.GenerateTestNodeID()
+		clientIP = s.nextIP()
+		serverID = ids.GenerateTestNodeID()
+		serverIP = s.nextIP()
+	)
+	s.addEndpoint(clientID, clientIP)
+	return s, clientID, &nodeDialer{sim: s, ip: clientIP}, serverID, s.addEndpoint(serverID, serverIP)
+}
+
+func TestConnDelivery(t *testing.T) {
+	require := require.New(t)
+
+	s, _, dialer, _, listener := newTestEndpoints(t, Config{
+		DefaultLink: LinkConfig{
+			Latency:   100 * time.Millisecond,
+			Bandwidth: 1000,
+		},
+	})
+	client, server := dial(t, s, dialer, listener)
+
+	msg := make([]byte, 500)
+	_, err := client.Write(msg)
+	require.NoError(err)
+	require.NoError(client.Close())
+
+	s.Advance(599 * time.Millisecond)
+	require.Empty(server.(*conn).buffer)
+
+	s.Advance(time.Millisecond)
+	read, err := io.ReadAll(server)
+	require.NoError(err)
+	require.Equal(msg, read)
+
+	_, err = client.Read(make([]byte, 1))
+	require.ErrorIs(err, net.ErrClosed)
+}
+
+func TestPartition(t *testing.T) {
+	require := require.New(t)
+
+	s, clientID, dialer, serverID, listener := newTestEndpoints(t, Config{})
+	client, server := dial(t, s, dialer, listener)
+
+	_, err := client.Write([]byte{1})
+	require.NoError(err)
+
+	s.Partition([]ids.NodeID{clientID}, []ids.NodeID{serverID})
+
+	// Bytes that were in flight are never delivered.
+	s.Advance(time.Second)
+	_, err = server.Read(make([]byte, 1))
+	require.ErrorIs(err, errConnReset)
+
+	_, err = client.Write([]byte{1})
+	require.ErrorIs(err, errConnReset)
+
+	_, err = dialer.Dial(context.Background(), listener.ip)
+	require.ErrorIs(err, errPartitioned)
+
+	s.Heal()
+	client, server = dial(t, s, dialer, listener)
+
+	_, err = client.Write([]byte{1})
+	require.NoError(err)
+	s.Advance(0)
+
+	b := make([]byte, 1)
+	_, err = server.Read(b)
+	require.NoError(err)
+	require.Equal([]byte{1}, b)
+}
+
+func TestSimulatorNetworks(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	s, err := New(Config{
+		NetworkID: constants.LocalID,
+		DefaultLink: LinkConfig{
+			Latency:   50 * time.Millisecond,
+			Bandwidth: 10 * 1024 * 1024,
+		},
+	})
+	require.NoError(err)
+
+	const numNodes = 30
+	for i := 0; i < numNodes; i++ {
+		_, err := s.AddNode(NodeConfig{
+			Configure: func(config *network.Config) {
+				config.InitialReconnectDelay = 10 * time.Millisecond
+				config.MaxReconnectDelay = 100 * time.Millisecond
+			},
+		})
+		require.NoError(err)
+	}
+	s.ConnectAll()
+
+	nodes := s.Nodes()
+	allConnectedTo := func(numPeers int) func() bool {
+		return func() bool {
+			for _, node := range nodes {
+				if node.NumConnectedPeers() != numPeers {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	require.NoError(s.RunUntil(ctx, 10*time.Millisecond, allConnectedTo(numNodes-1)))
+
+	// The handshake requires at least one round trip.
+	require.GreaterOrEqual(s.Now().Sub(time.Unix(0, 0)), 100*time.Millisecond)
+
+	var (
+		groupA = make([]ids.NodeID, 0, numNodes/2)
+		groupB = make([]ids.NodeID, 0, numNodes/2)
+	)
+	for i, node := range nodes {
+		if i < numNodes/2 {
+			groupA = append(groupA, node.NodeID)
+		} else {
+			groupB = append(groupB, node.NodeID)
+		}
+	}
+	s.Partition(groupA, groupB)
+	require.NoError(s.RunUntil(ctx, 10*time.Millisecond, allConnectedTo(numNodes/2-1)))
+
+	s.Heal()
+	require.NoError(s.RunUntil(ctx, 10*time.Millisecond, allConnectedTo(numNodes-1)))
+
+	require.NoError(s.Close())
+}
+
+func TestSimulatorTimers(t *testing.T) {
+	require := require.New(t)
+
+	s, err := New(Config{})
+	require.NoError(err)
+
+	var (
+		timer        = s.NewTimer(time.Second)
+		ticker       = s.NewTicker(time.Second)
+		stopped      = s.NewTimer(time.Second)
+		expectNoTick = func(c <-chan time.Time) {
+			select {
+			case <-c:
+				require.FailNow("unexpected tick")
+			default:
+			}
+		}
+	)
+	require.True(stopped.Stop())
+	require.False(stopped.Stop())
+
+	s.Advance(999 * time.Millisecond)
+	expectNoTick(timer.C())
+	expectNoTick(ticker.C())
+
+	s.Advance(time.Millisecond)
+	require.Equal(time.Unix(1, 0), <-timer.C())
+	require.Equal(time.Unix(1, 0), <-ticker.C())
+	require.False(timer.Stop())
+
+	// Ticks are dropped if they aren't received.
+	s.Advance(2 * time.Second)
+	require.Equal(time.Unix(2, 0), <-ticker.C())
+	expectNoTick(ticker.C())
+
+	ticker.Stop()
+	s.Advance(time.Second)
+	expectNoTick(ticker.C())
+	expectNoTick(stopped.C())
+	require.Equal(time.Unix(4, 0), s.Now())
+}
+
+func TestConnReadDeadline(t *testing.T) {
+	require := require.New(t)
+
+	s, _, dialer, _, listener := newTestEndpoints(t, Config{})
+	client, _ := dial(t, s, dialer, listener)
+
+	require.NoError(client.SetReadDeadline(s.Now().Add(time.Second)))
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := client.Read(make([]byte, 1))
+		readErr <- err
+	}()
+
+	// The deadline is measured on the virtual clock, so the read only fails
+	// once the clock reaches it.
+	s.Advance(999 * time.Millisecond)
+	select {
+	case err := <-readErr:
+		require.FailNow("read returned early", "err: %v", err)
+	default:
+	}
+
+	s.Advance(time.Millisecond)
+	require.ErrorIs(<-readErr, os.ErrDeadlineExceeded)
+
+	// A deadline that already passed fails reads immediately.
+	require.NoError(client.SetReadDeadline(time.Time{}))
+	require.NoError(client.SetReadDeadline(s.Now()))
+	_, err := client.Read(make([]byte, 1))
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+}
@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"time"
+
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
+)
+
+var (
+	_ mockable.Ticker = (*ticker)(nil)
+	_ mockable.Timer  = (*timer)(nil)
+)
+
+// timer fires on the virtual clock of a Simulator. Like [time.Timer], it
+// delivers the time it fired at on a channel with a buffer of one and drops
+// the tick if the buffer is full.
+type timer struct {
+	sim *Simulator
+	// period is the interval between ticks of a ticker and 0 for a timer.
+	period time.Duration
+	c      chan time.Time
+
+	// The following fields are guarded by the simulator's lock.
+
+	// scheduled is true if the timer will fire.
+	scheduled bool
+	// generation is incremented whenever the timer is stopped so that its
+	// previously scheduled event is ignored.
+	generation uint64
+}
+
+func (s *Simulator) newTimer(d time.Duration, period time.Duration) *timer {
+	t := &timer{
+		sim:    s,
+		period: period,
+		c:      make(chan time.Time, 1),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.schedule(t, s.now.Add(max(d, 0)))
+	return t
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *timer) Stop() bool {
+	t.sim.lock.Lock()
+	defer t.sim.lock.Unlock()
+
+	wasScheduled := t.scheduled
+	t.scheduled = false
+	t.generation++
+	return wasScheduled
+}
+
+// ticker is a timer that ticks every period.
+type ticker struct {
+	timer *timer
+}
+
+func (t *ticker) C() <-chan time.Time {
+	return t.timer.c
+}
+
+func (t *ticker) Stop() {
+	t.timer.Stop()
+}
+
+// schedule makes [t] fire at [at].
+//
+// Assumes [s.lock] is held.
+func (s *Simulator) schedule(t *timer, at time.Time) {
+	t.scheduled = true
+	s.push(&event{
+		at:         at,
+		timer:      t,
+		generation: t.generation,
+	})
+}
+
+// fire fires the timer of [e] unless it was stopped after [e] was scheduled.
+// Returns true if a tick was delivered.
+//
+// Assumes [s.lock] is held.
+func (s *Simulator) fire(e *event) bool {
+	t := e.timer
+	if !t.scheduled || t.generation != e.generation {
+		return false
+	}
+
+	if t.period > 0 {
+		s.schedule(t, e.at.Add(t.period))
+	} else {
+		t.scheduled = false
+	}
+
+	select {
+	case t.c <- e.at:
+		return true
+	default:
+		return false
+	}
+}
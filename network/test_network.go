@@ -91,11 +91,50 @@ func NewTestNetwork(
 		return nil, err
 	}
 
+	config, err := NewTestNetworkConfig(
+		metrics,
+		networkID,
+		currentValidators,
+		trackedSubnets,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNetwork(
+		config,
+		upgrade.InitiallyActiveTime,
+		msgCreator,
+		metrics,
+		log,
+		newNoopListener(),
+		dialer.NewDialer(
+			constants.NetworkType,
+			config.DialerConfig,
+			log,
+		),
+		router,
+	)
+}
+
+// NewTestNetworkConfig returns a network config populated with the default
+// values and a newly generated node identity.
+func NewTestNetworkConfig(
+	metrics prometheus.Registerer,
+	networkID uint32,
+	currentValidators validators.Manager,
+	trackedSubnets set.Set[ids.ID],
+) (*Config, error) {
 	tlsCert, err := staking.NewTLSCert()
 	if err != nil {
 		return nil, err
 	}
 
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	if err != nil {
+		return nil, err
+	}
+
 	blsKey, err := bls.NewSecretKey()
 	if err != nil {
 		return nil, err
@@ -114,124 +153,109 @@ func NewTestNetwork(
 		return nil, err
 	}
 
-	return NewNetwork(
-		&Config{
-			HealthConfig: HealthConfig{
-				Enabled:                      true,
-				MinConnectedPeers:            constants.DefaultNetworkHealthMinPeers,
-				MaxTimeSinceMsgReceived:      constants.DefaultNetworkHealthMaxTimeSinceMsgReceived,
-				MaxTimeSinceMsgSent:          constants.DefaultNetworkHealthMaxTimeSinceMsgSent,
-				MaxPortionSendQueueBytesFull: constants.DefaultNetworkHealthMaxPortionSendQueueFill,
-				MaxSendFailRate:              constants.DefaultNetworkHealthMaxSendFailRate,
-				SendFailRateHalflife:         constants.DefaultHealthCheckAveragerHalflife,
-			},
-			PeerListGossipConfig: PeerListGossipConfig{
-				PeerListNumValidatorIPs: constants.DefaultNetworkPeerListNumValidatorIPs,
-				PeerListPullGossipFreq:  constants.DefaultNetworkPeerListPullGossipFreq,
-				PeerListBloomResetFreq:  constants.DefaultNetworkPeerListBloomResetFreq,
-			},
-			TimeoutConfig: TimeoutConfig{
-				PingPongTimeout:      constants.DefaultPingPongTimeout,
-				ReadHandshakeTimeout: constants.DefaultNetworkReadHandshakeTimeout,
-			},
-			DelayConfig: DelayConfig{
-				InitialReconnectDelay: constants.DefaultNetworkInitialReconnectDelay,
-				MaxReconnectDelay:     constants.DefaultNetworkMaxReconnectDelay,
+	return &Config{
+		HealthConfig: HealthConfig{
+			Enabled:                      true,
+			MinConnectedPeers:            constants.DefaultNetworkHealthMinPeers,
+			MaxTimeSinceMsgReceived:      constants.DefaultNetworkHealthMaxTimeSinceMsgReceived,
+			MaxTimeSinceMsgSent:          constants.DefaultNetworkHealthMaxTimeSinceMsgSent,
+			MaxPortionSendQueueBytesFull: constants.DefaultNetworkHealthMaxPortionSendQueueFill,
+			MaxSendFailRate:              constants.DefaultNetworkHealthMaxSendFailRate,
+			SendFailRateHalflife:         constants.DefaultHealthCheckAveragerHalflife,
+		},
+		PeerListGossipConfig: PeerListGossipConfig{
+			PeerListNumValidatorIPs: constants.DefaultNetworkPeerListNumValidatorIPs,
+			PeerListPullGossipFreq:  constants.DefaultNetworkPeerListPullGossipFreq,
+			PeerListBloomResetFreq:  constants.DefaultNetworkPeerListBloomResetFreq,
+		},
+		TimeoutConfig: TimeoutConfig{
+			PingPongTimeout:      constants.DefaultPingPongTimeout,
+			ReadHandshakeTimeout: constants.DefaultNetworkReadHandshakeTimeout,
+		},
+		DelayConfig: DelayConfig{
+			InitialReconnectDelay: constants.DefaultNetworkInitialReconnectDelay,
+			MaxReconnectDelay:     constants.DefaultNetworkMaxReconnectDelay,
+		},
+		ThrottlerConfig: ThrottlerConfig{
+			InboundConnUpgradeThrottlerConfig: throttling.InboundConnUpgradeThrottlerConfig{
+				UpgradeCooldown:        constants.DefaultInboundConnUpgradeThrottlerCooldown,
+				MaxRecentConnsUpgraded: int(math.Ceil(constants.DefaultInboundThrottlerMaxConnsPerSec * constants.DefaultInboundConnUpgradeThrottlerCooldown.Seconds())),
 			},
-			ThrottlerConfig: ThrottlerConfig{
-				InboundConnUpgradeThrottlerConfig: throttling.InboundConnUpgradeThrottlerConfig{
-					UpgradeCooldown:        constants.DefaultInboundConnUpgradeThrottlerCooldown,
-					MaxRecentConnsUpgraded: int(math.Ceil(constants.DefaultInboundThrottlerMaxConnsPerSec * constants.DefaultInboundConnUpgradeThrottlerCooldown.Seconds())),
+			InboundMsgThrottlerConfig: throttling.InboundMsgThrottlerConfig{
+				MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
+					VdrAllocSize:        constants.DefaultInboundThrottlerVdrAllocSize,
+					AtLargeAllocSize:    constants.DefaultInboundThrottlerAtLargeAllocSize,
+					NodeMaxAtLargeBytes: constants.DefaultInboundThrottlerNodeMaxAtLargeBytes,
+				},
+				BandwidthThrottlerConfig: throttling.BandwidthThrottlerConfig{
+					RefillRate:   constants.DefaultInboundThrottlerBandwidthRefillRate,
+					MaxBurstSize: constants.DefaultInboundThrottlerBandwidthMaxBurstSize,
 				},
-				InboundMsgThrottlerConfig: throttling.InboundMsgThrottlerConfig{
-					MsgByteThrottlerConfig: throttling.MsgByteThrottlerConfig{
-						VdrAllocSize:        constants.DefaultInboundThrottlerVdrAllocSize,
-						AtLargeAllocSize:    constants.DefaultInboundThrottlerAtLargeAllocSize,
-						NodeMaxAtLargeBytes: constants.DefaultInboundThrottlerNodeMaxAtLargeBytes,
-					},
-					BandwidthThrottlerConfig: throttling.BandwidthThrottlerConfig{
-						RefillRate:   constants.DefaultInboundThrottlerBandwidthRefillRate,
-						MaxBurstSize: constants.DefaultInboundThrottlerBandwidthMaxBurstSize,
-					},
-					CPUThrottlerConfig: throttling.SystemThrottlerConfig{
-						MaxRecheckDelay: constants.DefaultInboundThrottlerCPUMaxRecheckDelay,
-					},
-					DiskThrottlerConfig: throttling.SystemThrottlerConfig{
-						MaxRecheckDelay: constants.DefaultInboundThrottlerDiskMaxRecheckDelay,
-					},
-					MaxProcessingMsgsPerNode: constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode,
+				CPUThrottlerConfig: throttling.SystemThrottlerConfig{
+					MaxRecheckDelay: constants.DefaultInboundThrottlerCPUMaxRecheckDelay,
 				},
-				OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
-					VdrAllocSize:        constants.DefaultOutboundThrottlerVdrAllocSize,
-					AtLargeAllocSize:    constants.DefaultOutboundThrottlerAtLargeAllocSize,
-					NodeMaxAtLargeBytes: constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes,
+				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
+					MaxRecheckDelay: constants.DefaultInboundThrottlerDiskMaxRecheckDelay,
 				},
-				MaxInboundConnsPerSec: constants.DefaultInboundThrottlerMaxConnsPerSec,
+				MaxProcessingMsgsPerNode: constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode,
 			},
-			ProxyEnabled:           constants.DefaultNetworkTCPProxyEnabled,
-			ProxyReadHeaderTimeout: constants.DefaultNetworkTCPProxyReadTimeout,
-			DialerConfig: dialer.Config{
-				ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
-				ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
+				VdrAllocSize:        constants.DefaultOutboundThrottlerVdrAllocSize,
+				AtLargeAllocSize:    constants.DefaultOutboundThrottlerAtLargeAllocSize,
+				NodeMaxAtLargeBytes: constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes,
 			},
-			TLSConfig: peer.TLSConfig(*tlsCert, nil),
-			MyIPPort: utils.NewAtomic(netip.AddrPortFrom(
-				netip.IPv4Unspecified(),
-				1,
-			)),
-			NetworkID:                    networkID,
-			MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
-			PingFrequency:                constants.DefaultPingFrequency,
-			AllowPrivateIPs:              !constants.ProductionNetworkIDs.Contains(networkID),
-			CompressionType:              constants.DefaultNetworkCompressionType,
-			TLSKey:                       tlsCert.PrivateKey.(crypto.Signer),
-			BLSKey:                       blsKey,
-			TrackedSubnets:               trackedSubnets,
-			Beacons:                      validators.NewManager(),
-			Validators:                   currentValidators,
-			UptimeCalculator:             uptime.NoOpCalculator,
-			UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
-			RequireValidatorToConnect:    constants.DefaultNetworkRequireValidatorToConnect,
-			MaximumInboundMessageTimeout: constants.DefaultNetworkMaximumInboundTimeout,
-			PeerReadBufferSize:           constants.DefaultNetworkPeerReadBufferSize,
-			PeerWriteBufferSize:          constants.DefaultNetworkPeerWriteBufferSize,
-			ResourceTracker:              resourceTracker,
-			CPUTargeter: tracker.NewTargeter(
-				logging.NoLog{},
-				&tracker.TargeterConfig{
-					VdrAlloc:           float64(runtime.NumCPU()),
-					MaxNonVdrUsage:     .8 * float64(runtime.NumCPU()),
-					MaxNonVdrNodeUsage: float64(runtime.NumCPU()) / 8,
-				},
-				currentValidators,
-				resourceTracker.CPUTracker(),
-			),
-			DiskTargeter: tracker.NewTargeter(
-				logging.NoLog{},
-				&tracker.TargeterConfig{
-					VdrAlloc:           1000 * units.GiB,
-					MaxNonVdrUsage:     1000 * units.GiB,
-					MaxNonVdrNodeUsage: 1000 * units.GiB,
-				},
-				currentValidators,
-				resourceTracker.DiskTracker(),
-			),
+			MaxInboundConnsPerSec: constants.DefaultInboundThrottlerMaxConnsPerSec,
 		},
-		upgrade.InitiallyActiveTime,
-		msgCreator,
-		metrics,
-		log,
-		newNoopListener(),
-		dialer.NewDialer(
-			constants.NetworkType,
-			dialer.Config{
-				ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
-				ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+		ProxyEnabled:           constants.DefaultNetworkTCPProxyEnabled,
+		ProxyReadHeaderTimeout: constants.DefaultNetworkTCPProxyReadTimeout,
+		DialerConfig: dialer.Config{
+			ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
+			ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+		},
+		TLSConfig: peer.TLSConfig(*tlsCert, nil),
+		MyNodeID:  ids.NodeIDFromCert(cert),
+		MyIPPort: utils.NewAtomic(netip.AddrPortFrom(
+			netip.IPv4Unspecified(),
+			1,
+		)),
+		NetworkID:                    networkID,
+		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
+		PingFrequency:                constants.DefaultPingFrequency,
+		AllowPrivateIPs:              !constants.ProductionNetworkIDs.Contains(networkID),
+		CompressionType:              constants.DefaultNetworkCompressionType,
+		TLSKey:                       tlsCert.PrivateKey.(crypto.Signer),
+		BLSKey:                       blsKey,
+		TrackedSubnets:               trackedSubnets,
+		Beacons:                      validators.NewManager(),
+		Validators:                   currentValidators,
+		UptimeCalculator:             uptime.NoOpCalculator,
+		UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
+		RequireValidatorToConnect:    constants.DefaultNetworkRequireValidatorToConnect,
+		MaximumInboundMessageTimeout: constants.DefaultNetworkMaximumInboundTimeout,
+		PeerReadBufferSize:           constants.DefaultNetworkPeerReadBufferSize,
+		PeerWriteBufferSize:          constants.DefaultNetworkPeerWriteBufferSize,
+		ResourceTracker:              resourceTracker,
+		CPUTargeter: tracker.NewTargeter(
+			logging.NoLog{},
+			&tracker.TargeterConfig{
+				VdrAlloc:           float64(runtime.NumCPU()),
+				MaxNonVdrUsage:     .8 * float64(runtime.NumCPU()),
+				MaxNonVdrNodeUsage: float64(runtime.NumCPU()) / 8,
 			},
-			log,
+			currentValidators,
+			resourceTracker.CPUTracker(),
 		),
-		router,
-	)
+		DiskTargeter: tracker.NewTargeter(
+			logging.NoLog{},
+			&tracker.TargeterConfig{
+				VdrAlloc:           1000 * units.GiB,
+				MaxNonVdrUsage:     1000 * units.GiB,
+				MaxNonVdrNodeUsage: 1000 * units.GiB,
+			},
+			currentValidators,
+			resourceTracker.DiskTracker(),
+		),
+	}, nil
 }
 
 type nodeIDConnector struct {
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/snow/networking/tracker"
+	"github.com/f01c5700/avalanchego/utils/constants"
+)
+
+var (
+	errNegativeMaxInboundConnsPerSec = errors.New("max inbound conns per sec must be non-negative")
+	errNonPositiveUpgradeCooldown    = errors.New("inbound conn upgrade cooldown must be positive")
+	errNodeMaxAtLargeBytesTooSmall   = errors.New("node max at-large bytes must be at least the max message size")
+	errAtLargeAllocSizeTooSmall      = errors.New("at-large alloc size must be at least the node max at-large bytes")
+	errMaxBurstSizeTooSmall          = errors.New("bandwidth max burst size must be at least the max message size")
+	errListenerNotThrottled          = errors.New("listener does not support throttling")
+	errInvalidTargeterConfig         = errors.New("invalid targeter config")
+	errUpgradeThrottlingDisabled     = errors.New("inbound connection upgrade throttling is disabled")
+)
+
+// DynamicThrottlerConfig contains the throttler limits that can be modified
+// while the node is running.
+//
+// When passed to SetThrottlerConfig, nil fields are left unchanged.
+type DynamicThrottlerConfig struct {
+	// MaxInboundConnsPerSec is the maximum number of inbound connections
+	// accepted per second.
+	MaxInboundConnsPerSec *float64 `json:"maxInboundConnsPerSec,omitempty"`
+
+	// InboundConnUpgradeCooldown is the minimum amount of time between
+	// upgrades of inbound connections from the same IP.
+	InboundConnUpgradeCooldown *time.Duration `json:"inboundConnUpgradeCooldown,omitempty"`
+
+	InboundByteThrottlerConfig      *throttling.MsgByteThrottlerConfig   `json:"inboundByteThrottlerConfig,omitempty"`
+	InboundBandwidthThrottlerConfig *throttling.BandwidthThrottlerConfig `json:"inboundBandwidthThrottlerConfig,omitempty"`
+	OutboundByteThrottlerConfig     *throttling.MsgByteThrottlerConfig   `json:"outboundByteThrottlerConfig,omitempty"`
+	CPUTargeterConfig               *tracker.TargeterConfig              `json:"cpuTargeterConfig,omitempty"`
+	DiskTargeterConfig              *tracker.TargeterConfig              `json:"diskTargeterConfig,omitempty"`
+}
+
+func (n *network) ThrottlerConfig() DynamicThrottlerConfig {
+	n.throttlerConfigLock.Lock()
+	defer n.throttlerConfigLock.Unlock()
+
+	return n.throttlerConfig()
+}
+
+// Assumes [n.throttlerConfigLock] is held.
+func (n *network) throttlerConfig() DynamicThrottlerConfig {
+	var (
+		inboundMsgThrottler        = n.peerConfig.InboundMsgThrottler
+		inboundConnUpgradeCooldown = n.inboundConnUpgradeThrottler.UpgradeCooldown()
+		inboundByteThrottlerConfig = inboundMsgThrottler.MsgByteThrottlerConfig()
+		inboundBandwidthConfig     = inboundMsgThrottler.BandwidthThrottlerConfig()
+		outboundByteConfig         = n.outboundMsgThrottler.Config()
+		cpuTargeterConfig          = n.config.CPUTargeter.Config()
+		diskTargeterConfig         = n.config.DiskTargeter.Config()
+	)
+	config := DynamicThrottlerConfig{
+		InboundConnUpgradeCooldown:      &inboundConnUpgradeCooldown,
+		InboundByteThrottlerConfig:      &inboundByteThrottlerConfig,
+		InboundBandwidthThrottlerConfig: &inboundBandwidthConfig,
+		OutboundByteThrottlerConfig:     &outboundByteConfig,
+		CPUTargeterConfig:               &cpuTargeterConfig,
+		DiskTargeterConfig:              &diskTargeterConfig,
+	}
+	if n.throttledListener != nil {
+		maxInboundConnsPerSec := n.throttledListener.MaxConnsPerSec()
+		config.MaxInboundConnsPerSec = &maxInboundConnsPerSec
+	}
+	return config
+}
+
+// SetThrottlerConfig verifies all of the provided limits before applying any
+// of them, so either all of the limits are updated or none are. Once verified,
+// applying the limits can not fail.
+func (n *network) SetThrottlerConfig(config DynamicThrottlerConfig) error {
+	n.throttlerConfigLock.Lock()
+	defer n.throttlerConfigLock.Unlock()
+
+	if err := n.verifyThrottlerConfig(config); err != nil {
+		return err
+	}
+
+	if config.MaxInboundConnsPerSec != nil {
+		n.throttledListener.SetMaxConnsPerSec(*config.MaxInboundConnsPerSec)
+	}
+	if config.InboundConnUpgradeCooldown != nil {
+		n.inboundConnUpgradeThrottler.SetUpgradeCooldown(*config.InboundConnUpgradeCooldown)
+	}
+	if config.InboundByteThrottlerConfig != nil {
+		n.peerConfig.InboundMsgThrottler.SetMsgByteThrottlerConfig(*config.InboundByteThrottlerConfig)
+	}
+	if config.InboundBandwidthThrottlerConfig != nil {
+		n.peerConfig.InboundMsgThrottler.SetBandwidthThrottlerConfig(*config.InboundBandwidthThrottlerConfig)
+	}
+	if config.OutboundByteThrottlerConfig != nil {
+		n.outboundMsgThrottler.SetConfig(*config.OutboundByteThrottlerConfig)
+	}
+	if config.CPUTargeterConfig != nil {
+		n.config.CPUTargeter.SetConfig(*config.CPUTargeterConfig)
+	}
+	if config.DiskTargeterConfig != nil {
+		n.config.DiskTargeter.SetConfig(*config.DiskTargeterConfig)
+	}
+
+	n.peerConfig.Log.Info("updated throttler config",
+		zap.Reflect("config", config),
+	)
+	n.updateThrottlerMetrics()
+	return nil
+}
+
+// Assumes [n.throttlerConfigLock] is held.
+func (n *network) verifyThrottlerConfig(config DynamicThrottlerConfig) error {
+	if config.MaxInboundConnsPerSec != nil {
+		if n.throttledListener == nil {
+			return errListenerNotThrottled
+		}
+		if *config.MaxInboundConnsPerSec < 0 {
+			return fmt.Errorf("%w: %f", errNegativeMaxInboundConnsPerSec, *config.MaxInboundConnsPerSec)
+		}
+	}
+	if config.InboundConnUpgradeCooldown != nil {
+		if n.inboundConnUpgradeThrottler.UpgradeCooldown() <= 0 {
+			return errUpgradeThrottlingDisabled
+		}
+		if *config.InboundConnUpgradeCooldown <= 0 {
+			return fmt.Errorf("%w: %s", errNonPositiveUpgradeCooldown, *config.InboundConnUpgradeCooldown)
+		}
+	}
+	if c := config.InboundByteThrottlerConfig; c != nil {
+		if c.NodeMaxAtLargeBytes < constants.DefaultMaxMessageSize {
+			return fmt.Errorf("%w: %d < %d", errNodeMaxAtLargeBytesTooSmall, c.NodeMaxAtLargeBytes, constants.DefaultMaxMessageSize)
+		}
+		if c.AtLargeAllocSize < c.NodeMaxAtLargeBytes {
+			return fmt.Errorf("%w: %d < %d", errAtLargeAllocSizeTooSmall, c.AtLargeAllocSize, c.NodeMaxAtLargeBytes)
+		}
+	}
+	if c := config.InboundBandwidthThrottlerConfig; c != nil && c.MaxBurstSize < constants.DefaultMaxMessageSize {
+		return fmt.Errorf("%w: %d < %d", errMaxBurstSizeTooSmall, c.MaxBurstSize, constants.DefaultMaxMessageSize)
+	}
+	if c := config.CPUTargeterConfig; c != nil {
+		if err := c.Verify(); err != nil {
+			return fmt.Errorf("%w for cpu: %w", errInvalidTargeterConfig, err)
+		}
+	}
+	if c := config.DiskTargeterConfig; c != nil {
+		if err := c.Verify(); err != nil {
+			return fmt.Errorf("%w for disk: %w", errInvalidTargeterConfig, err)
+		}
+	}
+	return nil
+}
+
+// updateThrottlerMetrics reports the current throttler limits.
+//
+// Assumes [n.throttlerConfigLock] is held.
+func (n *network) updateThrottlerMetrics() {
+	config := n.throttlerConfig()
+	limits := n.metrics.throttlerLimits
+	if config.MaxInboundConnsPerSec != nil {
+		limits.WithLabelValues("max_inbound_conns_per_sec").Set(*config.MaxInboundConnsPerSec)
+	}
+	limits.WithLabelValues("inbound_conn_upgrade_cooldown").Set(float64(*config.InboundConnUpgradeCooldown))
+	limits.WithLabelValues("inbound_vdr_alloc_size").Set(float64(config.InboundByteThrottlerConfig.VdrAllocSize))
+	limits.WithLabelValues("inbound_at_large_alloc_size").Set(float64(config.InboundByteThrottlerConfig.AtLargeAllocSize))
+	limits.WithLabelValues("inbound_node_max_at_large_bytes").Set(float64(config.InboundByteThrottlerConfig.NodeMaxAtLargeBytes))
+	limits.WithLabelValues("inbound_bandwidth_refill_rate").Set(float64(config.InboundBandwidthThrottlerConfig.RefillRate))
+	limits.WithLabelValues("inbound_bandwidth_max_burst_size").Set(float64(config.InboundBandwidthThrottlerConfig.MaxBurstSize))
+	limits.WithLabelValues("outbound_vdr_alloc_size").Set(float64(config.OutboundByteThrottlerConfig.VdrAllocSize))
+	limits.WithLabelValues("outbound_at_large_alloc_size").Set(float64(config.OutboundByteThrottlerConfig.AtLargeAllocSize))
+	limits.WithLabelValues("outbound_node_max_at_large_bytes").Set(float64(config.OutboundByteThrottlerConfig.NodeMaxAtLargeBytes))
+	limits.WithLabelValues("cpu_vdr_alloc").Set(config.CPUTargeterConfig.VdrAlloc)
+	limits.WithLabelValues("cpu_max_non_vdr_usage").Set(config.CPUTargeterConfig.MaxNonVdrUsage)
+	limits.WithLabelValues("cpu_max_non_vdr_node_usage").Set(config.CPUTargeterConfig.MaxNonVdrNodeUsage)
+	limits.WithLabelValues("disk_vdr_alloc").Set(config.DiskTargeterConfig.VdrAlloc)
+	limits.WithLabelValues("disk_max_non_vdr_usage").Set(config.DiskTargeterConfig.MaxNonVdrUsage)
+	limits.WithLabelValues("disk_max_non_vdr_node_usage").Set(config.DiskTargeterConfig.MaxNonVdrNodeUsage)
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/snow/networking/tracker"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/upgrade"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/units"
+)
+
+func newThrottlerConfigTestNetwork(t *testing.T) *network {
+	require := require.New(t)
+
+	dialer, listeners, _, configs := newTestNetwork(t, 1)
+	config := configs[0]
+	config.Beacons = validators.NewManager()
+	config.Validators = validators.NewManager()
+	// The default targeters are shared between tests, so they must not be
+	// modified.
+	config.CPUTargeter = newDefaultTargeter(config.ResourceTracker.CPUTracker())
+	config.DiskTargeter = newDefaultTargeter(config.ResourceTracker.DiskTracker())
+
+	net, err := NewNetwork(
+		config,
+		upgrade.InitiallyActiveTime,
+		newMessageCreator(t),
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		throttling.NewThrottledListener(listeners[0], config.ThrottlerConfig.MaxInboundConnsPerSec),
+		dialer,
+		&testHandler{},
+	)
+	require.NoError(err)
+	return net.(*network)
+}
+
+func TestSetThrottlerConfig(t *testing.T) {
+	require := require.New(t)
+
+	n := newThrottlerConfigTestNetwork(t)
+
+	initialConfig := n.ThrottlerConfig()
+	require.NotNil(initialConfig.MaxInboundConnsPerSec)
+	require.Equal(
+		defaultThrottlerConfig.InboundMsgThrottlerConfig.MsgByteThrottlerConfig,
+		*initialConfig.InboundByteThrottlerConfig,
+	)
+	require.Equal(
+		float64(defaultThrottlerConfig.OutboundMsgThrottlerConfig.VdrAllocSize),
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("outbound_vdr_alloc_size")),
+	)
+
+	var (
+		maxInboundConnsPerSec = float64(5)
+		upgradeCooldown       = 3 * time.Second
+		outboundConfig        = throttling.MsgByteThrottlerConfig{
+			VdrAllocSize:        units.MiB,
+			AtLargeAllocSize:    2 * units.MiB,
+			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+		}
+		cpuConfig = tracker.TargeterConfig{
+			VdrAlloc:           1,
+			MaxNonVdrUsage:     2,
+			MaxNonVdrNodeUsage: 3,
+		}
+	)
+	require.NoError(n.SetThrottlerConfig(DynamicThrottlerConfig{
+		MaxInboundConnsPerSec:       &maxInboundConnsPerSec,
+		InboundConnUpgradeCooldown:  &upgradeCooldown,
+		OutboundByteThrottlerConfig: &outboundConfig,
+		CPUTargeterConfig:           &cpuConfig,
+	}))
+
+	updatedConfig := n.ThrottlerConfig()
+	require.Equal(maxInboundConnsPerSec, *updatedConfig.MaxInboundConnsPerSec)
+	require.Equal(upgradeCooldown, *updatedConfig.InboundConnUpgradeCooldown)
+	require.Equal(outboundConfig, *updatedConfig.OutboundByteThrottlerConfig)
+	require.Equal(cpuConfig, *updatedConfig.CPUTargeterConfig)
+
+	// Limits that weren't provided should be unchanged.
+	require.Equal(initialConfig.InboundByteThrottlerConfig, updatedConfig.InboundByteThrottlerConfig)
+	require.Equal(initialConfig.DiskTargeterConfig, updatedConfig.DiskTargeterConfig)
+
+	require.Equal(
+		float64(outboundConfig.VdrAllocSize),
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("outbound_vdr_alloc_size")),
+	)
+	require.Equal(
+		float64(upgradeCooldown),
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("inbound_conn_upgrade_cooldown")),
+	)
+}
+
+// Limits that can't be applied must be rejected before any of the other limits
+// are applied.
+func TestSetThrottlerConfigPartialFailure(t *testing.T) {
+	require := require.New(t)
+
+	n := newThrottlerConfigTestNetwork(t)
+	initialConfig := n.ThrottlerConfig()
+
+	var (
+		maxInboundConnsPerSec = float64(5)
+		upgradeCooldown       = 3 * time.Second
+		byteConfig            = throttling.MsgByteThrottlerConfig{
+			VdrAllocSize:        units.MiB,
+			AtLargeAllocSize:    2 * constants.DefaultMaxMessageSize,
+			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+		}
+		bandwidthConfig = throttling.BandwidthThrottlerConfig{
+			RefillRate:   units.MiB,
+			MaxBurstSize: constants.DefaultMaxMessageSize,
+		}
+		cpuConfig = tracker.TargeterConfig{
+			VdrAlloc:           1,
+			MaxNonVdrUsage:     2,
+			MaxNonVdrNodeUsage: 3,
+		}
+		// The disk targeter is the last limit to be applied.
+		invalidDiskConfig = tracker.TargeterConfig{
+			MaxNonVdrNodeUsage: -1,
+		}
+	)
+	err := n.SetThrottlerConfig(DynamicThrottlerConfig{
+		MaxInboundConnsPerSec:           &maxInboundConnsPerSec,
+		InboundConnUpgradeCooldown:      &upgradeCooldown,
+		InboundByteThrottlerConfig:      &byteConfig,
+		InboundBandwidthThrottlerConfig: &bandwidthConfig,
+		OutboundByteThrottlerConfig:     &byteConfig,
+		CPUTargeterConfig:               &cpuConfig,
+		DiskTargeterConfig:              &invalidDiskConfig,
+	})
+	require.ErrorIs(err, errInvalidTargeterConfig)
+	require.Equal(initialConfig, n.ThrottlerConfig())
+
+	// The reported limits should be unchanged.
+	require.Equal(
+		*initialConfig.MaxInboundConnsPerSec,
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("max_inbound_conns_per_sec")),
+	)
+	require.Equal(
+		float64(*initialConfig.InboundConnUpgradeCooldown),
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("inbound_conn_upgrade_cooldown")),
+	)
+	require.Equal(
+		initialConfig.CPUTargeterConfig.VdrAlloc,
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("cpu_vdr_alloc")),
+	)
+
+	// Once the invalid limit is fixed, all of the limits are applied.
+	diskConfig := tracker.TargeterConfig{
+		VdrAlloc:           4,
+		MaxNonVdrUsage:     5,
+		MaxNonVdrNodeUsage: 6,
+	}
+	require.NoError(n.SetThrottlerConfig(DynamicThrottlerConfig{
+		MaxInboundConnsPerSec:           &maxInboundConnsPerSec,
+		InboundConnUpgradeCooldown:      &upgradeCooldown,
+		InboundByteThrottlerConfig:      &byteConfig,
+		InboundBandwidthThrottlerConfig: &bandwidthConfig,
+		OutboundByteThrottlerConfig:     &byteConfig,
+		CPUTargeterConfig:               &cpuConfig,
+		DiskTargeterConfig:              &diskConfig,
+	}))
+	require.Equal(
+		DynamicThrottlerConfig{
+			MaxInboundConnsPerSec:           &maxInboundConnsPerSec,
+			InboundConnUpgradeCooldown:      &upgradeCooldown,
+			InboundByteThrottlerConfig:      &byteConfig,
+			InboundBandwidthThrottlerConfig: &bandwidthConfig,
+			OutboundByteThrottlerConfig:     &byteConfig,
+			CPUTargeterConfig:               &cpuConfig,
+			DiskTargeterConfig:              &diskConfig,
+		},
+		n.ThrottlerConfig(),
+	)
+	require.Equal(
+		diskConfig.VdrAlloc,
+		testutil.ToFloat64(n.metrics.throttlerLimits.WithLabelValues("disk_vdr_alloc")),
+	)
+}
+
+func TestSetThrottlerConfigVerify(t *testing.T) {
+	var (
+		negative        = float64(-1)
+		zeroCooldown    = time.Duration(0)
+		smallByteConfig = throttling.MsgByteThrottlerConfig{
+			AtLargeAllocSize:    units.MiB,
+			NodeMaxAtLargeBytes: units.MiB,
+		}
+		smallAtLargeConfig = throttling.MsgByteThrottlerConfig{
+			AtLargeAllocSize:    units.MiB,
+			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
+		}
+		smallBurstConfig = throttling.BandwidthThrottlerConfig{
+			RefillRate:   units.MiB,
+			MaxBurstSize: units.MiB,
+		}
+		negativeTargeterConfig = tracker.TargeterConfig{
+			VdrAlloc: -1,
+		}
+	)
+	tests := []struct {
+		name        string
+		config      DynamicThrottlerConfig
+		expectedErr error
+	}{
+		{
+			name: "negative max inbound conns per sec",
+			config: DynamicThrottlerConfig{
+				MaxInboundConnsPerSec: &negative,
+			},
+			expectedErr: errNegativeMaxInboundConnsPerSec,
+		},
+		{
+			name: "zero upgrade cooldown",
+			config: DynamicThrottlerConfig{
+				InboundConnUpgradeCooldown: &zeroCooldown,
+			},
+			expectedErr: errNonPositiveUpgradeCooldown,
+		},
+		{
+			name: "node max at-large bytes too small",
+			config: DynamicThrottlerConfig{
+				InboundByteThrottlerConfig: &smallByteConfig,
+			},
+			expectedErr: errNodeMaxAtLargeBytesTooSmall,
+		},
+		{
+			name: "at-large alloc size too small",
+			config: DynamicThrottlerConfig{
+				InboundByteThrottlerConfig: &smallAtLargeConfig,
+			},
+			expectedErr: errAtLargeAllocSizeTooSmall,
+		},
+		{
+			name: "max burst size too small",
+			config: DynamicThrottlerConfig{
+				InboundBandwidthThrottlerConfig: &smallBurstConfig,
+			},
+			expectedErr: errMaxBurstSizeTooSmall,
+		},
+		{
+			name: "negative disk targeter alloc",
+			config: DynamicThrottlerConfig{
+				DiskTargeterConfig: &negativeTargeterConfig,
+			},
+			expectedErr: errInvalidTargeterConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			n := newThrottlerConfigTestNetwork(t)
+			initialConfig := n.ThrottlerConfig()
+
+			// Valid limits provided alongside invalid limits must not be
+			// applied.
+			if test.config.MaxInboundConnsPerSec == nil {
+				rate := float64(1)
+				test.config.MaxInboundConnsPerSec = &rate
+			}
+
+			err := n.SetThrottlerConfig(test.config)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(initialConfig, n.ThrottlerConfig())
+		})
+	}
+}
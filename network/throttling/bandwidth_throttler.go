@@ -47,6 +47,14 @@ type bandwidthThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// Config returns the current refill rate and burst size of this throttler.
+	Config() BandwidthThrottlerConfig
+
+	// SetConfig updates the refill rate and burst size of every node's
+	// bandwidth allocation.
+	// It's safe to call SetConfig concurrently with Acquire.
+	SetConfig(config BandwidthThrottlerConfig)
 }
 
 type BandwidthThrottlerConfig struct {
@@ -146,6 +154,26 @@ func (t *bandwidthThrottlerImpl) AddNode(nodeID ids.NodeID) {
 	t.limiters[nodeID] = rate.NewLimiter(rate.Limit(t.RefillRate), int(t.MaxBurstSize))
 }
 
+// See BandwidthThrottler.
+func (t *bandwidthThrottlerImpl) Config() BandwidthThrottlerConfig {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.BandwidthThrottlerConfig
+}
+
+// See BandwidthThrottler.
+func (t *bandwidthThrottlerImpl) SetConfig(config BandwidthThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.BandwidthThrottlerConfig = config
+	for _, limiter := range t.limiters {
+		limiter.SetLimit(rate.Limit(config.RefillRate))
+		limiter.SetBurst(int(config.MaxBurstSize))
+	}
+}
+
 // See BandwidthThrottler.
 func (t *bandwidthThrottlerImpl) RemoveNode(nodeID ids.NodeID) {
 	t.lock.Lock()
@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/utils/logging"
@@ -59,3 +60,38 @@ func TestBandwidthThrottler(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBandwidthThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+	throttler, err := newBandwidthThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		BandwidthThrottlerConfig{
+			RefillRate:   8,
+			MaxBurstSize: 10,
+		},
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+
+	newConfig := BandwidthThrottlerConfig{
+		RefillRate:   16,
+		MaxBurstSize: 20,
+	}
+	throttler.SetConfig(newConfig)
+	require.Equal(newConfig, throttler.Config())
+
+	// Existing nodes should be updated.
+	limiter := throttler.(*bandwidthThrottlerImpl).limiters[nodeID]
+	require.Equal(rate.Limit(newConfig.RefillRate), limiter.Limit())
+	require.Equal(int(newConfig.MaxBurstSize), limiter.Burst())
+
+	// New nodes should use the new config.
+	otherNodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(otherNodeID)
+	limiter = throttler.(*bandwidthThrottlerImpl).limiters[otherNodeID]
+	require.Equal(rate.Limit(newConfig.RefillRate), limiter.Limit())
+	require.Equal(int(newConfig.MaxBurstSize), limiter.Burst())
+}
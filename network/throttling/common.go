@@ -37,4 +37,106 @@ type commonMsgThrottler struct {
 	nodeToAtLargeBytesUsed map[ids.NodeID]uint64
 	// Max number of unprocessed bytes from validators
 	maxVdrBytes uint64
+	// Max number of unprocessed bytes from the at-large allocation
+	atLargeAllocSize uint64
+	// Number of bytes that must be released before [remainingVdrBytes] can
+	// grow. Non-zero only if [maxVdrBytes] was reduced below the number of
+	// bytes that were in use.
+	vdrBytesDebt uint64
+	// Number of bytes that must be released before [remainingAtLargeBytes]
+	// can grow. Non-zero only if [atLargeAllocSize] was reduced below the
+	// number of bytes that were in use.
+	atLargeBytesDebt uint64
+}
+
+func newCommonMsgThrottler(
+	log logging.Logger,
+	vdrs validators.Manager,
+	config MsgByteThrottlerConfig,
+) commonMsgThrottler {
+	return commonMsgThrottler{
+		log:                    log,
+		vdrs:                   vdrs,
+		maxVdrBytes:            config.VdrAllocSize,
+		atLargeAllocSize:       config.AtLargeAllocSize,
+		remainingVdrBytes:      config.VdrAllocSize,
+		remainingAtLargeBytes:  config.AtLargeAllocSize,
+		nodeMaxAtLargeBytes:    config.NodeMaxAtLargeBytes,
+		nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
+		nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
+	}
+}
+
+// Config returns the current allocation sizes of this throttler.
+func (t *commonMsgThrottler) Config() MsgByteThrottlerConfig {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return MsgByteThrottlerConfig{
+		VdrAllocSize:        t.maxVdrBytes,
+		AtLargeAllocSize:    t.atLargeAllocSize,
+		NodeMaxAtLargeBytes: t.nodeMaxAtLargeBytes,
+	}
+}
+
+// resize updates the allocation sizes to [config]. Bytes that are currently
+// in use are not revoked. If an allocation shrinks below the number of bytes
+// in use, the allocation stays empty until enough bytes are released.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) resize(config MsgByteThrottlerConfig) {
+	t.remainingVdrBytes, t.vdrBytesDebt = resizeAllocation(
+		t.maxVdrBytes,
+		config.VdrAllocSize,
+		t.remainingVdrBytes,
+		t.vdrBytesDebt,
+	)
+	t.remainingAtLargeBytes, t.atLargeBytesDebt = resizeAllocation(
+		t.atLargeAllocSize,
+		config.AtLargeAllocSize,
+		t.remainingAtLargeBytes,
+		t.atLargeBytesDebt,
+	)
+	t.maxVdrBytes = config.VdrAllocSize
+	t.atLargeAllocSize = config.AtLargeAllocSize
+	t.nodeMaxAtLargeBytes = config.NodeMaxAtLargeBytes
+}
+
+// resizeAllocation returns the remaining bytes and the debt of an allocation
+// of [oldSize] bytes that is resized to [newSize] bytes.
+func resizeAllocation(oldSize, newSize, remaining, debt uint64) (uint64, uint64) {
+	used := oldSize - remaining + debt
+	if used > newSize {
+		return 0, used - newSize
+	}
+	return newSize - used, 0
+}
+
+// Returns the number of bytes [nodeID] may take from the at-large allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) atLargeBytesAllowed(nodeID ids.NodeID) uint64 {
+	used := t.nodeToAtLargeBytesUsed[nodeID]
+	if used >= t.nodeMaxAtLargeBytes {
+		return 0
+	}
+	return t.nodeMaxAtLargeBytes - used
+}
+
+// Returns [numBytes] to the validator allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnVdrBytes(numBytes uint64) {
+	paid := min(numBytes, t.vdrBytesDebt)
+	t.vdrBytesDebt -= paid
+	t.remainingVdrBytes += numBytes - paid
+}
+
+// Returns [numBytes] to the at-large allocation.
+//
+// Assumes [t.lock] is held.
+func (t *commonMsgThrottler) returnAtLargeBytes(numBytes uint64) {
+	paid := min(numBytes, t.atLargeBytesDebt)
+	t.atLargeBytesDebt -= paid
+	t.remainingAtLargeBytes += numBytes - paid
 }
@@ -10,12 +10,27 @@ import (
 	"golang.org/x/time/rate"
 )
 
-var _ net.Listener = (*throttledListener)(nil)
+var _ ThrottledListener = (*throttledListener)(nil)
 
-// Wraps [listener] and returns a net.Listener that will accept at most
+// ThrottledListener is a net.Listener whose rate of accepting incoming
+// connections can be updated while it is in use.
+type ThrottledListener interface {
+	net.Listener
+
+	// MaxConnsPerSec returns the maximum number of connections accepted per
+	// second.
+	MaxConnsPerSec() float64
+
+	// SetMaxConnsPerSec updates the maximum number of connections accepted per
+	// second.
+	// [maxConnsPerSec] must be non-negative.
+	SetMaxConnsPerSec(maxConnsPerSec float64)
+}
+
+// Wraps [listener] and returns a ThrottledListener that will accept at most
 // [maxConnsPerSec] connections per second.
 // [maxConnsPerSec] must be non-negative.
-func NewThrottledListener(listener net.Listener, maxConnsPerSec float64) net.Listener {
+func NewThrottledListener(listener net.Listener, maxConnsPerSec float64) ThrottledListener {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &throttledListener{
 		ctx:           ctx,
@@ -60,3 +75,12 @@ func (l *throttledListener) Close() error {
 func (l *throttledListener) Addr() net.Addr {
 	return l.listener.Addr()
 }
+
+func (l *throttledListener) MaxConnsPerSec() float64 {
+	return float64(l.limiter.Limit())
+}
+
+func (l *throttledListener) SetMaxConnsPerSec(maxConnsPerSec float64) {
+	l.limiter.SetLimit(rate.Limit(maxConnsPerSec))
+	l.limiter.SetBurst(int(maxConnsPerSec) + 1)
+}
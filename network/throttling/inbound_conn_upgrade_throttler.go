@@ -39,6 +39,15 @@ type InboundConnUpgradeThrottler interface {
 	// If [ip] is a local IP, this method always returns true.
 	// Must not be called after [Stop] has been called.
 	ShouldUpgrade(ip netip.AddrPort) bool
+	// UpgradeCooldown returns the current minimum amount of time between
+	// upgrades of inbound connections from the same IP.
+	UpgradeCooldown() time.Duration
+	// SetUpgradeCooldown updates the minimum amount of time between upgrades
+	// of inbound connections from the same IP. The new cooldown also applies
+	// to IPs that are currently cooling down.
+	// [cooldown] must be positive. If this throttler was created with
+	// throttling disabled, this is a noop.
+	SetUpgradeCooldown(cooldown time.Duration)
 }
 
 type InboundConnUpgradeThrottlerConfig struct {
@@ -64,6 +73,7 @@ func NewInboundConnUpgradeThrottler(log logging.Logger, config InboundConnUpgrad
 		InboundConnUpgradeThrottlerConfig: config,
 		log:                               log,
 		done:                              make(chan struct{}),
+		cooldownChanged:                   make(chan struct{}, 1),
 		recentIPsAndTimes:                 make(chan ipAndTime, config.MaxRecentConnsUpgraded),
 	}
 }
@@ -79,9 +89,15 @@ func (*noInboundConnUpgradeThrottler) ShouldUpgrade(netip.AddrPort) bool {
 	return true
 }
 
+func (*noInboundConnUpgradeThrottler) UpgradeCooldown() time.Duration {
+	return 0
+}
+
+func (*noInboundConnUpgradeThrottler) SetUpgradeCooldown(time.Duration) {}
+
 type ipAndTime struct {
-	ip                netip.Addr
-	cooldownElapsedAt time.Time
+	ip         netip.Addr
+	upgradedAt time.Time
 }
 
 type inboundConnUpgradeThrottler struct {
@@ -92,6 +108,9 @@ type inboundConnUpgradeThrottler struct {
 	clock mockable.Clock
 	// When [done] is closed, Dispatch returns.
 	done chan struct{}
+	// Signalled when the cooldown changes so that Dispatch re-evaluates when
+	// the next IP should be removed.
+	cooldownChanged chan struct{}
 	// IP --> Present if ShouldUpgrade(ipStr) returned true
 	// within the last [UpgradeCooldown].
 	recentIPs set.Set[netip.Addr]
@@ -122,8 +141,8 @@ func (n *inboundConnUpgradeThrottler) ShouldUpgrade(addrPort netip.AddrPort) boo
 
 	select {
 	case n.recentIPsAndTimes <- ipAndTime{
-		ip:                addr,
-		cooldownElapsedAt: n.clock.Time().Add(n.UpgradeCooldown),
+		ip:         addr,
+		upgradedAt: n.clock.Time(),
 	}:
 		n.recentIPs.Add(addr)
 		return true
@@ -139,24 +158,59 @@ func (n *inboundConnUpgradeThrottler) Dispatch() {
 	for {
 		select {
 		case next := <-n.recentIPsAndTimes:
-			// Sleep until it's time to remove the next IP
-			timer.Reset(next.cooldownElapsedAt.Sub(n.clock.Time()))
-
-			select {
-			case <-timer.C:
-				// Remove the next IP (we'd upgrade another inbound connection from it)
-				n.lock.Lock()
-				n.recentIPs.Remove(next.ip)
-				n.lock.Unlock()
-			case <-n.done:
+			if !n.awaitCooldown(timer, next) {
 				return
 			}
+			// Remove the next IP (we'd upgrade another inbound connection from it)
+			n.lock.Lock()
+			n.recentIPs.Remove(next.ip)
+			n.lock.Unlock()
 		case <-n.done:
 			return
 		}
 	}
 }
 
+// awaitCooldown blocks until the cooldown of [next] has elapsed. If the
+// cooldown changes while waiting, the remaining time is recalculated.
+// Returns false if [Stop] was called.
+func (n *inboundConnUpgradeThrottler) awaitCooldown(timer *time.Timer, next ipAndTime) bool {
+	for {
+		// Sleep until it's time to remove the next IP
+		cooldownElapsedAt := next.upgradedAt.Add(n.UpgradeCooldown())
+		timer.Reset(cooldownElapsedAt.Sub(n.clock.Time()))
+
+		select {
+		case <-timer.C:
+			return true
+		case <-n.cooldownChanged:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-n.done:
+			return false
+		}
+	}
+}
+
+func (n *inboundConnUpgradeThrottler) UpgradeCooldown() time.Duration {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.InboundConnUpgradeThrottlerConfig.UpgradeCooldown
+}
+
+func (n *inboundConnUpgradeThrottler) SetUpgradeCooldown(cooldown time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.InboundConnUpgradeThrottlerConfig.UpgradeCooldown = cooldown
+	select {
+	case n.cooldownChanged <- struct{}{}:
+	default:
+	}
+}
+
 func (n *inboundConnUpgradeThrottler) Stop() {
 	close(n.done)
 }
@@ -104,3 +104,28 @@ func TestInboundConnUpgradeThrottler(t *testing.T) {
 		require.FailNow("should be done")
 	}
 }
+
+func TestInboundConnUpgradeThrottlerSetUpgradeCooldown(t *testing.T) {
+	require := require.New(t)
+
+	throttler := NewInboundConnUpgradeThrottler(
+		logging.NoLog{},
+		InboundConnUpgradeThrottlerConfig{
+			UpgradeCooldown:        time.Hour,
+			MaxRecentConnsUpgraded: 3,
+		},
+	)
+	go throttler.Dispatch()
+	defer throttler.Stop()
+
+	require.True(throttler.ShouldUpgrade(host1))
+	require.False(throttler.ShouldUpgrade(host1))
+
+	// Shrinking the cooldown should also apply to IPs that are currently
+	// cooling down
+	throttler.SetUpgradeCooldown(time.Millisecond)
+	require.Equal(time.Millisecond, throttler.UpgradeCooldown())
+	require.Eventually(func() bool {
+		return throttler.ShouldUpgrade(host1)
+	}, 5*time.Second, time.Millisecond)
+}
@@ -28,16 +28,7 @@ func newInboundMsgByteThrottler(
 	config MsgByteThrottlerConfig,
 ) (*inboundMsgByteThrottler, error) {
 	t := &inboundMsgByteThrottler{
-		commonMsgThrottler: commonMsgThrottler{
-			log:                    log,
-			vdrs:                   vdrs,
-			maxVdrBytes:            config.VdrAllocSize,
-			remainingVdrBytes:      config.VdrAllocSize,
-			remainingAtLargeBytes:  config.AtLargeAllocSize,
-			nodeMaxAtLargeBytes:    config.NodeMaxAtLargeBytes,
-			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
-			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
-		},
+		commonMsgThrottler: newCommonMsgThrottler(log, vdrs, config),
 		waitingToAcquire:   linked.NewHashmap[uint64, *msgMetadata](),
 		nodeToWaitingMsgID: make(map[ids.NodeID]uint64),
 	}
@@ -109,7 +100,7 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 		// only give as many bytes as needed
 		metadata.bytesNeeded,
 		// don't exceed per-node limit
-		t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[nodeID],
+		t.atLargeBytesAllowed(nodeID),
 		// don't give more bytes than are in the allocation
 		t.remainingAtLargeBytes,
 	)
@@ -127,29 +118,7 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 	}
 
 	// Take as many bytes as we can from [nodeID]'s validator allocation.
-	// Calculate [nodeID]'s validator allocation size based on its weight
-	vdrAllocationSize := uint64(0)
-	weight := t.vdrs.GetWeight(constants.PrimaryNetworkID, nodeID)
-	if weight != 0 {
-		totalWeight, err := t.vdrs.TotalWeight(constants.PrimaryNetworkID)
-		if err != nil {
-			t.log.Error("couldn't get total weight of primary network",
-				zap.Error(err),
-			)
-		} else {
-			vdrAllocationSize = uint64(float64(t.maxVdrBytes) * float64(weight) / float64(totalWeight))
-		}
-	}
-	vdrBytesAlreadyUsed := t.nodeToVdrBytesUsed[nodeID]
-	// [vdrBytesAllowed] is the number of bytes this node
-	// may take from its validator allocation.
-	vdrBytesAllowed := vdrAllocationSize
-	if vdrBytesAlreadyUsed >= vdrAllocationSize {
-		// We're already using all the bytes we can from the validator allocation
-		vdrBytesAllowed = 0
-	} else {
-		vdrBytesAllowed -= vdrBytesAlreadyUsed
-	}
+	vdrBytesAllowed := t.vdrBytesAllowed(nodeID)
 	vdrBytesUsed := min(t.remainingVdrBytes, metadata.bytesNeeded, vdrBytesAllowed)
 	if vdrBytesUsed > 0 {
 		// Mark that [nodeID] used [vdrBytesUsed] from its validator allocation
@@ -221,46 +190,13 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 	atLargeBytesToReturn := releasedBytes - vdrBytesToReturn
 	if atLargeBytesToReturn > 0 {
 		// Mark that [nodeID] has released these bytes.
-		t.remainingAtLargeBytes += atLargeBytesToReturn
+		t.returnAtLargeBytes(atLargeBytesToReturn)
 		t.nodeToAtLargeBytesUsed[nodeID] -= atLargeBytesToReturn
 		if t.nodeToAtLargeBytesUsed[nodeID] == 0 {
 			delete(t.nodeToAtLargeBytesUsed, nodeID)
 		}
 
-		// Iterates over messages waiting to acquire bytes from oldest
-		// (waiting the longest) to newest. Try to give bytes to the
-		// oldest message, then next oldest, etc. until there are no
-		// waiting messages or we exhaust the bytes.
-		iter := t.waitingToAcquire.NewIterator()
-		for t.remainingAtLargeBytes > 0 && iter.Next() {
-			msg := iter.Value()
-			// From the at-large allocation, take the maximum number of bytes
-			// without exceeding the per-node limit on taking from at-large pool.
-			atLargeBytesGiven := min(
-				// don't give [msg] too many bytes
-				msg.bytesNeeded,
-				// don't exceed per-node limit
-				t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[msg.nodeID],
-				// don't give more bytes than are in the allocation
-				t.remainingAtLargeBytes,
-			)
-			if atLargeBytesGiven > 0 {
-				// Mark that we gave [atLargeBytesGiven] to [msg]
-				t.nodeToAtLargeBytesUsed[msg.nodeID] += atLargeBytesGiven
-				t.remainingAtLargeBytes -= atLargeBytesGiven
-				atLargeBytesToReturn -= atLargeBytesGiven
-				msg.bytesNeeded -= atLargeBytesGiven
-			}
-			if msg.bytesNeeded == 0 {
-				// [msg] has acquired enough bytes to be read.
-				// Unblock the corresponding thread in Acquire
-				close(msg.closeOnAcquireChan)
-				// Mark that this message is no longer waiting to acquire bytes
-				delete(t.nodeToWaitingMsgID, msg.nodeID)
-
-				t.waitingToAcquire.Delete(iter.Key())
-			}
-		}
+		t.grantAtLargeBytes()
 	}
 
 	// Get the message from [nodeID], if any, waiting to acquire
@@ -293,10 +229,121 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 		if t.nodeToVdrBytesUsed[nodeID] == 0 {
 			delete(t.nodeToVdrBytesUsed, nodeID)
 		}
-		t.remainingVdrBytes += vdrBytesToReturn
+		t.returnVdrBytes(vdrBytesToReturn)
+	}
+}
+
+// Returns the number of bytes [nodeID] may still take from its validator
+// allocation. The allocation size is proportional to [nodeID]'s weight.
+//
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) vdrBytesAllowed(nodeID ids.NodeID) uint64 {
+	weight := t.vdrs.GetWeight(constants.PrimaryNetworkID, nodeID)
+	if weight == 0 {
+		return 0
+	}
+	totalWeight, err := t.vdrs.TotalWeight(constants.PrimaryNetworkID)
+	if err != nil {
+		t.log.Error("couldn't get total weight of primary network",
+			zap.Error(err),
+		)
+		return 0
+	}
+	vdrAllocationSize := uint64(float64(t.maxVdrBytes) * float64(weight) / float64(totalWeight))
+	vdrBytesAlreadyUsed := t.nodeToVdrBytesUsed[nodeID]
+	if vdrBytesAlreadyUsed >= vdrAllocationSize {
+		// We're already using all the bytes we can from the validator allocation
+		return 0
+	}
+	return vdrAllocationSize - vdrBytesAlreadyUsed
+}
+
+// Gives bytes from the validator allocation to the messages that are waiting
+// to acquire bytes, up to the validator allocation size of their senders.
+//
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) grantVdrBytes() {
+	iter := t.waitingToAcquire.NewIterator()
+	for t.remainingVdrBytes > 0 && iter.Next() {
+		msg := iter.Value()
+		vdrBytesGiven := min(
+			// don't give [msg] too many bytes
+			msg.bytesNeeded,
+			// don't exceed the sender's validator allocation
+			t.vdrBytesAllowed(msg.nodeID),
+			// don't give more bytes than are in the allocation
+			t.remainingVdrBytes,
+		)
+		if vdrBytesGiven > 0 {
+			// Mark that we gave [vdrBytesGiven] to [msg]
+			t.nodeToVdrBytesUsed[msg.nodeID] += vdrBytesGiven
+			t.remainingVdrBytes -= vdrBytesGiven
+			msg.bytesNeeded -= vdrBytesGiven
+		}
+		if msg.bytesNeeded == 0 {
+			// Unblock the corresponding thread in Acquire
+			close(msg.closeOnAcquireChan)
+			delete(t.nodeToWaitingMsgID, msg.nodeID)
+			t.waitingToAcquire.Delete(iter.Key())
+		}
 	}
 }
 
+// Gives bytes from the at-large allocation to the messages that are waiting
+// to acquire bytes.
+//
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) grantAtLargeBytes() {
+	// Iterates over messages waiting to acquire bytes from oldest
+	// (waiting the longest) to newest. Try to give bytes to the
+	// oldest message, then next oldest, etc. until there are no
+	// waiting messages or we exhaust the bytes.
+	iter := t.waitingToAcquire.NewIterator()
+	for t.remainingAtLargeBytes > 0 && iter.Next() {
+		msg := iter.Value()
+		// From the at-large allocation, take the maximum number of bytes
+		// without exceeding the per-node limit on taking from at-large pool.
+		atLargeBytesGiven := min(
+			// don't give [msg] too many bytes
+			msg.bytesNeeded,
+			// don't exceed per-node limit
+			t.atLargeBytesAllowed(msg.nodeID),
+			// don't give more bytes than are in the allocation
+			t.remainingAtLargeBytes,
+		)
+		if atLargeBytesGiven > 0 {
+			// Mark that we gave [atLargeBytesGiven] to [msg]
+			t.nodeToAtLargeBytesUsed[msg.nodeID] += atLargeBytesGiven
+			t.remainingAtLargeBytes -= atLargeBytesGiven
+			msg.bytesNeeded -= atLargeBytesGiven
+		}
+		if msg.bytesNeeded == 0 {
+			// [msg] has acquired enough bytes to be read.
+			// Unblock the corresponding thread in Acquire
+			close(msg.closeOnAcquireChan)
+			// Mark that this message is no longer waiting to acquire bytes
+			delete(t.nodeToWaitingMsgID, msg.nodeID)
+
+			t.waitingToAcquire.Delete(iter.Key())
+		}
+	}
+}
+
+// SetConfig updates the allocation sizes and the per-node at-large limit of
+// this throttler. Messages that have already acquired bytes are not affected.
+// Messages waiting to acquire bytes are re-evaluated against the new
+// validator allocation sizes and the new per-node at-large limit.
+func (t *inboundMsgByteThrottler) SetConfig(config MsgByteThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.resize(config)
+	t.grantVdrBytes()
+	t.grantAtLargeBytes()
+	t.metrics.remainingAtLargeBytes.Set(float64(t.remainingAtLargeBytes))
+	t.metrics.remainingVdrBytes.Set(float64(t.remainingVdrBytes))
+}
+
 type inboundMsgByteThrottlerMetrics struct {
 	acquireLatency        metric.Averager
 	remainingAtLargeBytes prometheus.Gauge
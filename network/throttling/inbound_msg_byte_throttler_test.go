@@ -442,3 +442,76 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 	// next non validator message should finish
 	<-done
 }
+
+// Test that messages waiting to acquire bytes are re-evaluated when the
+// config changes
+func TestInboundMsgByteThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        1024,
+		AtLargeAllocSize:    1024,
+		NodeMaxAtLargeBytes: 0,
+	}
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+	nonVdrID := ids.GenerateTestNodeID()
+
+	throttler, err := newInboundMsgByteThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		vdrs,
+		config,
+	)
+	require.NoError(err)
+
+	// validator uses its entire validator allocation
+	throttler.Acquire(context.Background(), config.VdrAllocSize, vdrID)
+
+	// validator and nonvalidator can't acquire any more bytes
+	doneVdr := make(chan struct{})
+	go func() {
+		throttler.Acquire(context.Background(), config.VdrAllocSize, vdrID)
+		close(doneVdr)
+	}()
+	doneNonVdr := make(chan struct{})
+	go func() {
+		throttler.Acquire(context.Background(), 1, nonVdrID)
+		close(doneNonVdr)
+	}()
+	require.Eventually(func() bool {
+		throttler.lock.Lock()
+		defer throttler.lock.Unlock()
+		return throttler.waitingToAcquire.Len() == 2
+	}, time.Second, time.Millisecond)
+
+	// Growing the validator allocation should unblock the validator
+	config.VdrAllocSize *= 2
+	throttler.SetConfig(config)
+	select {
+	case <-doneVdr:
+	case <-time.After(time.Second):
+		require.FailNow("validator should acquire bytes from the grown validator allocation")
+	}
+	select {
+	case <-doneNonVdr:
+		require.FailNow("nonvalidator should still be blocking")
+	default:
+	}
+
+	// Raising the per-node at-large limit should unblock the nonvalidator
+	config.NodeMaxAtLargeBytes = 1
+	throttler.SetConfig(config)
+	select {
+	case <-doneNonVdr:
+	case <-time.After(time.Second):
+		require.FailNow("nonvalidator should acquire bytes from the at-large allocation")
+	}
+
+	throttler.lock.Lock()
+	require.Zero(throttler.waitingToAcquire.Len())
+	require.Empty(throttler.nodeToWaitingMsgID)
+	require.Equal(uint64(2*1024), throttler.nodeToVdrBytesUsed[vdrID])
+	require.Equal(uint64(1), throttler.nodeToAtLargeBytesUsed[nonVdrID])
+	throttler.lock.Unlock()
+}
@@ -40,6 +40,22 @@ type InboundMsgThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// MsgByteThrottlerConfig returns the current allocation sizes of the
+	// inbound message byte throttler.
+	MsgByteThrottlerConfig() MsgByteThrottlerConfig
+
+	// SetMsgByteThrottlerConfig updates the allocation sizes of the inbound
+	// message byte throttler.
+	SetMsgByteThrottlerConfig(config MsgByteThrottlerConfig)
+
+	// BandwidthThrottlerConfig returns the current configuration of the
+	// inbound bandwidth throttler.
+	BandwidthThrottlerConfig() BandwidthThrottlerConfig
+
+	// SetBandwidthThrottlerConfig updates the configuration of the inbound
+	// bandwidth throttler.
+	SetBandwidthThrottlerConfig(config BandwidthThrottlerConfig)
 }
 
 type InboundMsgThrottlerConfig struct {
@@ -174,3 +190,19 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
 }
+
+func (t *inboundMsgThrottler) MsgByteThrottlerConfig() MsgByteThrottlerConfig {
+	return t.byteThrottler.Config()
+}
+
+func (t *inboundMsgThrottler) SetMsgByteThrottlerConfig(config MsgByteThrottlerConfig) {
+	t.byteThrottler.SetConfig(config)
+}
+
+func (t *inboundMsgThrottler) BandwidthThrottlerConfig() BandwidthThrottlerConfig {
+	return t.bandwidthThrottler.Config()
+}
+
+func (t *inboundMsgThrottler) SetBandwidthThrottlerConfig(config BandwidthThrottlerConfig) {
+	t.bandwidthThrottler.SetConfig(config)
+}
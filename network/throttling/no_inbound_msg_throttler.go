@@ -26,3 +26,15 @@ func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) Relea
 func (*noInboundMsgThrottler) AddNode(ids.NodeID) {}
 
 func (*noInboundMsgThrottler) RemoveNode(ids.NodeID) {}
+
+func (*noInboundMsgThrottler) MsgByteThrottlerConfig() MsgByteThrottlerConfig {
+	return MsgByteThrottlerConfig{}
+}
+
+func (*noInboundMsgThrottler) SetMsgByteThrottlerConfig(MsgByteThrottlerConfig) {}
+
+func (*noInboundMsgThrottler) BandwidthThrottlerConfig() BandwidthThrottlerConfig {
+	return BandwidthThrottlerConfig{}
+}
+
+func (*noInboundMsgThrottler) SetBandwidthThrottlerConfig(BandwidthThrottlerConfig) {}
@@ -266,3 +266,53 @@ func testMsgWithSize(ctrl *gomock.Controller, size uint64) message.OutboundMessa
 	msg.EXPECT().Bytes().Return(make([]byte, size)).AnyTimes()
 	return msg
 }
+
+func TestSybilOutboundMsgThrottlerSetConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        0,
+		AtLargeAllocSize:    1024,
+		NodeMaxAtLargeBytes: 1024,
+	}
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		config,
+	)
+	require.NoError(err)
+	require.Equal(config, throttlerIntf.Config())
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+
+	nodeID := ids.GenerateTestNodeID()
+	msg := testMsgWithSize(ctrl, 768)
+	require.True(throttlerIntf.Acquire(msg, nodeID))
+	require.Equal(uint64(256), throttler.remainingAtLargeBytes)
+
+	// Shrink the allocation below the number of bytes in use.
+	shrunkConfig := MsgByteThrottlerConfig{
+		VdrAllocSize:        0,
+		AtLargeAllocSize:    512,
+		NodeMaxAtLargeBytes: 512,
+	}
+	throttlerIntf.SetConfig(shrunkConfig)
+	require.Equal(shrunkConfig, throttlerIntf.Config())
+	require.Zero(throttler.remainingAtLargeBytes)
+	require.Equal(uint64(256), throttler.atLargeBytesDebt)
+
+	// The node is over its new per-node limit, so it can't acquire more.
+	require.False(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), ids.GenerateTestNodeID()))
+	require.False(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1), nodeID))
+
+	// Releasing the bytes should pay off the debt before refilling the
+	// allocation.
+	throttlerIntf.Release(msg, nodeID)
+	require.Zero(throttler.atLargeBytesDebt)
+	require.Equal(shrunkConfig.AtLargeAllocSize, throttler.remainingAtLargeBytes)
+
+	// Growing the allocation should make the new bytes available.
+	throttlerIntf.SetConfig(config)
+	require.Equal(config.AtLargeAllocSize, throttler.remainingAtLargeBytes)
+	require.True(throttlerIntf.Acquire(testMsgWithSize(ctrl, 1024), nodeID))
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/f01c5700/avalanchego/genesis"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/network"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/bootstrap"
 	"github.com/f01c5700/avalanchego/snow/networking/benchlist"
 	"github.com/f01c5700/avalanchego/snow/networking/router"
+	"github.com/f01c5700/avalanchego/snow/networking/sender"
 	"github.com/f01c5700/avalanchego/snow/networking/tracker"
 	"github.com/f01c5700/avalanchego/subnets"
 	"github.com/f01c5700/avalanchego/trace"
@@ -57,6 +60,8 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+
+	ConsensusEventsAPIEnabled bool `json:"consensusEventsAPIEnabled"`
 }
 
 type IPConfig struct {
@@ -104,6 +109,17 @@ type BootstrapConfig struct {
 	// ancestors while responding to a GetAncestors message
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
+	// Number of goroutines that parse and pre-verify blocks ahead of their
+	// execution, if the VM enables parallel parsing
+	BootstrapParseWorkers int `json:"bootstrapParseWorkers"`
+
+	// Strategy used to select the peers to fetch containers from
+	BootstrapPeerSelectionStrategy p2p.PeerSelectionStrategy `json:"bootstrapPeerSelectionStrategy"`
+
+	// Blocks that chains can bootstrap to without first polling the beacons
+	// for their accepted frontier, keyed by chainID
+	BootstrapCheckpoints map[ids.ID]bootstrap.Checkpoint `json:"bootstrapCheckpoints"`
+
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
 }
 
@@ -169,6 +185,8 @@ type Config struct {
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
 	// handle App messages per chain.
 	ConsensusAppConcurrency int `json:"consensusAppConcurrency"`
+	// ByzantineConfig makes the snowman engines deviate from the protocol.
+	ByzantineConfig sender.ByzantineConfig `json:"byzantineConfig"`
 
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 
@@ -49,7 +49,9 @@ import (
 	"github.com/f01c5700/avalanchego/network/dialer"
 	"github.com/f01c5700/avalanchego/network/peer"
 	"github.com/f01c5700/avalanchego/network/throttling"
+	"github.com/f01c5700/avalanchego/pubsub"
 	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/networking/benchlist"
 	"github.com/f01c5700/avalanchego/snow/networking/router"
 	"github.com/f01c5700/avalanchego/snow/networking/timeout"
@@ -111,6 +113,7 @@ var (
 
 	indexerDBPrefix  = []byte{0x00}
 	keystoreDBPrefix = []byte("keystore")
+	networkDBPrefix  = []byte("network")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -245,6 +248,9 @@ func New(
 	if err := n.addDefaultVMAliases(); err != nil {
 		return nil, fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
+	if err := n.initConsensusEventsAPI(); err != nil { // Start the Consensus Events API
+		return nil, fmt.Errorf("couldn't initialize consensus events API: %w", err)
+	}
 	if err := n.initChainManager(n.Config.AvaxAssetID); err != nil { // Set up the chain manager
 		return nil, fmt.Errorf("couldn't initialize chain manager: %w", err)
 	}
@@ -305,6 +311,9 @@ type Node struct {
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
+	// Streams consensus events. Nil if the consensus events API is disabled.
+	consensusEventServer *pubsub.ConsensusEventServer
+
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
@@ -395,11 +404,11 @@ type Node struct {
 
 	// Specifies how much CPU usage each peer can cause before
 	// we rate-limit them.
-	cpuTargeter tracker.Targeter
+	cpuTargeter tracker.DynamicTargeter
 
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
-	diskTargeter tracker.Targeter
+	diskTargeter tracker.DynamicTargeter
 
 	// Closed when a sufficient amount of bootstrap nodes are connected to
 	onSufficientlyConnected chan struct{}
@@ -629,6 +638,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.AddressBookDB = prefixdb.New(networkDBPrefix, n.DB)
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -1088,6 +1098,12 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	}
 	cChainID := createEVMTx.ID()
 
+	if n.Config.ByzantineConfig.Enabled() {
+		n.Log.Warn("snowman engines are configured to be byzantine",
+			zap.Reflect("config", n.Config.ByzantineConfig),
+		)
+	}
+
 	// If any of these chains die, the node shuts down
 	criticalChains := set.Of(
 		constants.PlatformChainID,
@@ -1144,6 +1160,11 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		return fmt.Errorf("failed to initialize subnets: %w", err)
 	}
 
+	var consensusEvents event.Publisher
+	if n.consensusEventServer != nil {
+		consensusEvents = n.consensusEventServer
+	}
+
 	n.chainManager, err = chains.New(
 		&chains.ManagerConfig{
 			SybilProtectionEnabled:                  n.Config.SybilProtectionEnabled,
@@ -1181,9 +1202,14 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ChainConfigs:                            n.Config.ChainConfigs,
 			FrontierPollFrequency:                   n.Config.FrontierPollFrequency,
 			ConsensusAppConcurrency:                 n.Config.ConsensusAppConcurrency,
+			ByzantineConfig:                         n.Config.ByzantineConfig,
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 			BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 			BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
+			BootstrapParseWorkers:                   n.Config.BootstrapParseWorkers,
+			BootstrapPeerSelectionStrategy:          n.Config.BootstrapPeerSelectionStrategy,
+			BootstrapCheckpoints:                    n.Config.BootstrapCheckpoints,
+			ConsensusEvents:                         consensusEvents,
 			Upgrades:                                n.Config.UpgradeConfig,
 			ResourceTracker:                         n.resourceTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
@@ -1199,6 +1225,9 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+	if n.consensusEventServer != nil {
+		n.chainManager.AddRegistrant(n.consensusEventServer)
+	}
 	return nil
 }
 
@@ -1362,12 +1391,15 @@ func (n *Node) initAdminAPI() error {
 			Log:          n.Log,
 			DB:           n.DB,
 			ChainManager: n.chainManager,
+			Network:      n.Net,
 			HTTPServer:   n.APIServer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Benchlist:    n.benchlistManager,
+			Timeouts:     n.timeoutManager,
 		},
 	)
 	if err != nil {
@@ -1380,6 +1412,23 @@ func (n *Node) initAdminAPI() error {
 	)
 }
 
+// initConsensusEventsAPI initializes the websocket that streams consensus
+// events. Assumes n.Log, n.APIServer, and n.BlockAcceptorGroup already
+// initialized
+func (n *Node) initConsensusEventsAPI() error {
+	if !n.Config.ConsensusEventsAPIEnabled {
+		n.Log.Info("skipping consensus events API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing consensus events API")
+	n.consensusEventServer = pubsub.NewConsensusEventServer(n.Log, n.BlockAcceptorGroup)
+	return n.APIServer.AddRoute(
+		n.consensusEventServer,
+		"consensus",
+		"/events",
+	)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
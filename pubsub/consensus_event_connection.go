@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
+	"github.com/f01c5700/avalanchego/utils/set"
+)
+
+var ErrUnknownEventType = errors.New("unknown event type")
+
+// ConsensusEventSubscription is sent by a client to select the events it
+// receives. Sending a new subscription replaces the previous one.
+type ConsensusEventSubscription struct {
+	// ChainIDs are the chains to receive events for. If empty, events are
+	// received for all chains.
+	ChainIDs []ids.ID `json:"chainIDs"`
+	// Types are the event types to receive. If empty, all event types are
+	// received.
+	Types []event.Type `json:"types"`
+}
+
+// consensusEventConnection is a websocket connection that receives consensus
+// events.
+type consensusEventConnection struct {
+	s *ConsensusEventServer
+
+	// The websocket connection.
+	conn *websocket.Conn
+
+	// Buffered channel of outbound messages.
+	send chan interface{}
+
+	lock     sync.RWMutex
+	chainIDs set.Set[ids.ID]
+	types    set.Set[event.Type]
+
+	active uint32
+}
+
+// Matches returns true if [e] should be sent over the connection.
+func (c *consensusEventConnection) Matches(e event.Event) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return (c.chainIDs.Len() == 0 || c.chainIDs.Contains(e.ChainID)) &&
+		(c.types.Len() == 0 || c.types.Contains(e.Type))
+}
+
+func (c *consensusEventConnection) isActive() bool {
+	active := atomic.LoadUint32(&c.active)
+	return active != 0
+}
+
+func (c *consensusEventConnection) deactivate() {
+	atomic.StoreUint32(&c.active, 0)
+}
+
+func (c *consensusEventConnection) Send(msg interface{}) bool {
+	if !c.isActive() {
+		return false
+	}
+	select {
+	case c.send <- msg:
+		return true
+	default:
+	}
+	return false
+}
+
+// readPump pumps subscriptions from the websocket connection to the server.
+func (c *consensusEventConnection) readPump() {
+	defer func() {
+		c.deactivate()
+		c.s.removeConnection(c)
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := c.conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		err := c.readMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.s.log.Debug("unexpected close in websockets",
+					zap.Error(err),
+				)
+			}
+			break
+		}
+	}
+}
+
+// writePump pumps events from the server to the websocket connection.
+func (c *consensusEventConnection) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		c.deactivate()
+		ticker.Stop()
+		c.s.removeConnection(c)
+
+		// close is called by both the writePump and the readPump so one of them
+		// will always error
+		_ = c.conn.Close()
+	}()
+	for {
+		select {
+		case message := <-c.send:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				c.s.log.Debug("closing the connection",
+					zap.String("reason", "failed to set the write deadline"),
+					zap.Error(err),
+				)
+				return
+			}
+			if err := c.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				c.s.log.Debug("closing the connection",
+					zap.String("reason", "failed to set the write deadline"),
+					zap.Error(err),
+				)
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *consensusEventConnection) readMessage() error {
+	_, r, err := c.conn.NextReader()
+	if err != nil {
+		return err
+	}
+	subscription := &ConsensusEventSubscription{}
+	if err := json.NewDecoder(r).Decode(subscription); err != nil {
+		return err
+	}
+
+	// An invalid subscription is reported to the client without closing the
+	// connection so that the error can be delivered.
+	if err := c.subscribe(subscription); err != nil {
+		c.Send(&errorMsg{
+			Error: err.Error(),
+		})
+	}
+	return nil
+}
+
+func (c *consensusEventConnection) subscribe(subscription *ConsensusEventSubscription) error {
+	for _, typ := range subscription.Types {
+		if !slices.Contains(event.Types, typ) {
+			return fmt.Errorf("%w: %q", ErrUnknownEventType, typ)
+		}
+	}
+
+	c.lock.Lock()
+	c.chainIDs = set.Of(subscription.ChainIDs...)
+	c.types = set.Of(subscription.Types...)
+	c.lock.Unlock()
+
+	c.s.subscribe(c)
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/cache"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/set"
+)
+
+const (
+	consensusEventAcceptorName = "consensus-events"
+
+	// Maximum number of issued blocks whose issuance is remembered to report
+	// the time blocks spent in consensus.
+	maxTrackedIssuedBlocks = 8192
+)
+
+var (
+	_ event.Publisher = (*ConsensusEventServer)(nil)
+	_ snow.Acceptor   = (*ConsensusEventServer)(nil)
+)
+
+type issuedBlock struct {
+	height uint64
+	time   time.Time
+}
+
+// ConsensusEventServer streams the consensus lifecycle of blocks to websocket
+// clients.
+//
+// Issued, verified, polled, and rejected events are published by the snowman
+// engines. Accepted events are received from the block acceptor group once
+// the chain has been registered with RegisterChain.
+type ConsensusEventServer struct {
+	log           logging.Logger
+	acceptorGroup snow.AcceptorGroup
+
+	// Block ID -> issuance of the block
+	issued cache.Cacher[ids.ID, issuedBlock]
+
+	lock sync.RWMutex
+	// conns are the connections that have subscribed to events
+	conns set.Set[*consensusEventConnection]
+}
+
+func NewConsensusEventServer(log logging.Logger, acceptorGroup snow.AcceptorGroup) *ConsensusEventServer {
+	return &ConsensusEventServer{
+		log:           log,
+		acceptorGroup: acceptorGroup,
+		issued:        &cache.LRU[ids.ID, issuedBlock]{Size: maxTrackedIssuedBlocks},
+	}
+}
+
+// RegisterChain causes accepted blocks of the chain to be streamed.
+func (s *ConsensusEventServer) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	err := s.acceptorGroup.RegisterAcceptor(
+		ctx.ChainID,
+		consensusEventAcceptorName,
+		s,
+		false, // dieOnError
+	)
+	if err != nil {
+		s.log.Error("failed to register consensus event acceptor",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *ConsensusEventServer) Accept(ctx *snow.ConsensusContext, containerID ids.ID, _ []byte) error {
+	s.Publish(event.Event{
+		Type:    event.Accepted,
+		ChainID: ctx.ChainID,
+		Time:    time.Now(),
+		Block: &event.Block{
+			ID: containerID,
+		},
+	})
+	return nil
+}
+
+func (s *ConsensusEventServer) Publish(e event.Event) {
+	if e.Block != nil {
+		blk := *e.Block
+		e.Block = &blk
+
+		switch issued, ok := s.issued.Get(blk.ID); {
+		case e.Type == event.Issued && !ok:
+			s.issued.Put(blk.ID, issuedBlock{
+				height: blk.Height,
+				time:   e.Time,
+			})
+		case ok:
+			// The acceptor group doesn't provide the height of accepted
+			// blocks.
+			e.Block.Height = issued.height
+			e.SinceIssued = e.Time.Sub(issued.time)
+		}
+
+		if e.Type == event.Accepted || e.Type == event.Rejected {
+			s.issued.Evict(blk.ID)
+		}
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for conn := range s.conns {
+		if !conn.Matches(e) {
+			continue
+		}
+		if !conn.Send(e) {
+			s.log.Verbo("dropping consensus event due to too many pending messages")
+		}
+	}
+}
+
+func (s *ConsensusEventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	conn := &consensusEventConnection{
+		s:      s,
+		conn:   wsConn,
+		send:   make(chan interface{}, maxPendingMessages),
+		active: 1,
+	}
+
+	go conn.writePump()
+	go conn.readPump()
+}
+
+func (s *ConsensusEventServer) subscribe(conn *consensusEventConnection) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.conns.Add(conn)
+}
+
+func (s *ConsensusEventServer) removeConnection(conn *consensusEventConnection) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.conns.Remove(conn)
+}
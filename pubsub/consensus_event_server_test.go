@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
+	"github.com/f01c5700/avalanchego/snow/snowtest"
+	"github.com/f01c5700/avalanchego/utils/logging"
+)
+
+func dialConsensusEvents(t *testing.T, s *ConsensusEventServer, subscription *ConsensusEventSubscription) *websocket.Conn {
+	require := require.New(t)
+
+	httpServer := httptest.NewServer(s)
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	require.NoError(conn.WriteJSON(subscription))
+	require.Eventually(
+		func() bool {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+
+			return s.conns.Len() == 1
+		},
+		time.Second,
+		time.Millisecond,
+	)
+	return conn
+}
+
+func TestConsensusEventServerFilters(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID      = ids.GenerateTestID()
+		otherChainID = ids.GenerateTestID()
+		blkID        = ids.GenerateTestID()
+		s            = NewConsensusEventServer(logging.NoLog{}, snow.NewAcceptorGroup(logging.NoLog{}))
+		conn         = dialConsensusEvents(t, s, &ConsensusEventSubscription{
+			ChainIDs: []ids.ID{chainID},
+			Types:    []event.Type{event.Verified},
+		})
+		issuedTime = time.Unix(100, 0)
+	)
+
+	s.Publish(event.Event{
+		Type:    event.Issued,
+		ChainID: chainID,
+		Time:    issuedTime,
+		Block: &event.Block{
+			ID:     blkID,
+			Height: 5,
+		},
+	})
+	s.Publish(event.Event{
+		Type:    event.Verified,
+		ChainID: otherChainID,
+		Time:    issuedTime,
+		Block: &event.Block{
+			ID: ids.GenerateTestID(),
+		},
+	})
+	s.Publish(event.Event{
+		Type:    event.Verified,
+		ChainID: chainID,
+		Time:    issuedTime.Add(time.Second),
+		Block: &event.Block{
+			ID:     blkID,
+			Height: 5,
+		},
+		Duration: time.Millisecond,
+	})
+
+	var received event.Event
+	require.NoError(conn.ReadJSON(&received))
+	require.Equal(event.Verified, received.Type)
+	require.Equal(chainID, received.ChainID)
+	require.Equal(&event.Block{ID: blkID, Height: 5}, received.Block)
+	require.Equal(time.Millisecond, received.Duration)
+	require.Equal(time.Second, received.SinceIssued)
+}
+
+func TestConsensusEventServerAccepted(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		snowCtx       = snowtest.Context(t, snowtest.CChainID)
+		ctx           = snowtest.ConsensusContext(snowCtx)
+		acceptorGroup = snow.NewAcceptorGroup(logging.NoLog{})
+		s             = NewConsensusEventServer(logging.NoLog{}, acceptorGroup)
+		conn          = dialConsensusEvents(t, s, &ConsensusEventSubscription{})
+		blkID         = ids.GenerateTestID()
+	)
+	s.RegisterChain("C", ctx, nil)
+
+	s.Publish(event.Event{
+		Type:    event.Issued,
+		ChainID: ctx.ChainID,
+		Time:    time.Now(),
+		Block: &event.Block{
+			ID:     blkID,
+			Height: 7,
+		},
+	})
+	require.NoError(acceptorGroup.Accept(ctx, blkID, nil))
+
+	var issued event.Event
+	require.NoError(conn.ReadJSON(&issued))
+	require.Equal(event.Issued, issued.Type)
+
+	var accepted event.Event
+	require.NoError(conn.ReadJSON(&accepted))
+	require.Equal(event.Accepted, accepted.Type)
+	require.Equal(ctx.ChainID, accepted.ChainID)
+	require.Equal(&event.Block{ID: blkID, Height: 7}, accepted.Block)
+	require.Positive(accepted.SinceIssued)
+}
+
+func TestConsensusEventServerUnknownType(t *testing.T) {
+	require := require.New(t)
+
+	s := NewConsensusEventServer(logging.NoLog{}, snow.NewAcceptorGroup(logging.NoLog{}))
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	require.NoError(conn.WriteJSON(&ConsensusEventSubscription{
+		Types: []event.Type{"finalized"},
+	}))
+
+	var msg errorMsg
+	require.NoError(conn.ReadJSON(&msg))
+	require.Contains(msg.Error, ErrUnknownEventType.Error())
+}
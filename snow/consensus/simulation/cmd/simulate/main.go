@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// simulate runs a consensus simulation and writes the result to stdout as
+// JSON. The config is read from the file provided by --config. Fields that are
+// omitted from the file keep their default values.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/f01c5700/avalanchego/snow/consensus/simulation"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a JSON simulation config")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "simulation failed: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	config := simulation.DefaultConfig()
+	if configPath != "" {
+		configBytes, err := os.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return err
+		}
+	}
+
+	result, err := simulation.Run(config)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+)
+
+const (
+	// Snowball runs a snowball.Tree on every node.
+	Snowball Protocol = "snowball"
+	// Snowman runs a snowman.Topological on every node, deciding between
+	// conflicting children of the last accepted block.
+	Snowman Protocol = "snowman"
+
+	// Silent byzantine nodes never respond to queries.
+	Silent ByzantineStrategy = "silent"
+	// Oppose byzantine nodes respond with a choice other than the querier's
+	// current preference.
+	Oppose ByzantineStrategy = "oppose"
+	// Balance byzantine nodes respond with the choice that the fewest honest
+	// nodes currently prefer, attempting to keep the network split.
+	Balance ByzantineStrategy = "balance"
+
+	// Uniform gives every node the same stake.
+	Uniform StakeDistribution = "uniform"
+	// Pareto samples the stake of every node from a pareto distribution.
+	Pareto StakeDistribution = "pareto"
+	// Explicit uses the provided weights.
+	Explicit StakeDistribution = "explicit"
+
+	// defaultParetoShape approximates an 80/20 distribution of stake.
+	defaultParetoShape = 1.16
+)
+
+var (
+	errUnknownProtocol         = errors.New("unknown protocol")
+	errUnknownStrategy         = errors.New("unknown byzantine strategy")
+	errUnknownDistribution     = errors.New("unknown stake distribution")
+	errTooFewNodes             = errors.New("too few nodes")
+	errTooFewChoices           = errors.New("too few choices")
+	errInvalidByzantineStake   = errors.New("byzantine stake must be in [0, 1)")
+	errInvalidDropRate         = errors.New("drop rate must be in [0, 1)")
+	errInvalidLatency          = errors.New("invalid latency range")
+	errInvalidParetoShape      = errors.New("pareto shape must not be negative")
+	errWrongNumWeights         = errors.New("wrong number of weights")
+	errZeroWeight              = errors.New("weights must be positive")
+	errNonPositiveQueryTimeout = errors.New("query timeout must be positive")
+	errNonPositiveMaxDuration  = errors.New("max duration must be positive")
+	errNonPositiveNumTrials    = errors.New("number of trials must be positive")
+)
+
+// Protocol is the consensus implementation run by the honest nodes.
+type Protocol string
+
+// ByzantineStrategy is the behavior of the byzantine nodes when queried.
+type ByzantineStrategy string
+
+// StakeDistribution determines how stake is assigned to the nodes.
+type StakeDistribution string
+
+type Config struct {
+	Protocol   Protocol            `json:"protocol"`
+	Parameters snowball.Parameters `json:"parameters"`
+	// NumNodes is the number of nodes, including byzantine nodes.
+	NumNodes int `json:"numNodes"`
+	// NumChoices is the number of conflicting choices. Every honest node
+	// initially prefers one of them, chosen uniformly at random.
+	NumChoices int `json:"numChoices"`
+
+	// ByzantineStake is the fraction of the total stake that is controlled by
+	// byzantine nodes. Byzantine nodes are chosen at random until adding
+	// another one would exceed this fraction.
+	ByzantineStake    float64           `json:"byzantineStake"`
+	ByzantineStrategy ByzantineStrategy `json:"byzantineStrategy"`
+
+	Stake   StakeConfig   `json:"stake"`
+	Latency LatencyConfig `json:"latency"`
+
+	// QueryTimeout is the amount of time a node waits for responses before
+	// finishing a poll with the responses it received.
+	QueryTimeout time.Duration `json:"queryTimeout"`
+	// MaxDuration is the amount of virtual time a trial may run for. Honest
+	// nodes that haven't finalized by then are liveness failures.
+	MaxDuration time.Duration `json:"maxDuration"`
+
+	// NumTrials is the number of independent trials to run. Trial i uses the
+	// seed Seed+i.
+	NumTrials int   `json:"numTrials"`
+	Seed      int64 `json:"seed"`
+}
+
+type StakeConfig struct {
+	Distribution StakeDistribution `json:"distribution"`
+	// ParetoShape is the shape of the pareto distribution. Smaller shapes
+	// concentrate more stake in fewer nodes. Defaults to 1.16.
+	ParetoShape float64 `json:"paretoShape,omitempty"`
+	// Weights is the stake of every node when using the explicit
+	// distribution.
+	Weights []uint64 `json:"weights,omitempty"`
+}
+
+// LatencyConfig determines how long messages take to be delivered. Every
+// message is delayed by an amount chosen uniformly at random from [Min, Max].
+type LatencyConfig struct {
+	Min time.Duration `json:"min"`
+	Max time.Duration `json:"max"`
+	// DropRate is the probability that a message is never delivered.
+	DropRate float64 `json:"dropRate"`
+}
+
+// DefaultConfig returns a config that runs the default snowball parameters on
+// 1000 equally weighted nodes, none of which are byzantine.
+func DefaultConfig() Config {
+	return Config{
+		Protocol:          Snowman,
+		Parameters:        snowball.DefaultParameters,
+		NumNodes:          1000,
+		NumChoices:        2,
+		ByzantineStrategy: Balance,
+		Stake: StakeConfig{
+			Distribution: Uniform,
+		},
+		Latency: LatencyConfig{
+			Min: 10 * time.Millisecond,
+			Max: 200 * time.Millisecond,
+		},
+		QueryTimeout: 2 * time.Second,
+		MaxDuration:  time.Minute,
+		NumTrials:    1,
+	}
+}
+
+func (c *Config) Verify() error {
+	switch c.Protocol {
+	case Snowball, Snowman:
+	default:
+		return fmt.Errorf("%w: %q", errUnknownProtocol, c.Protocol)
+	}
+	if err := c.Parameters.Verify(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.NumNodes < 1:
+		return fmt.Errorf("%w: %d", errTooFewNodes, c.NumNodes)
+	case c.NumChoices < 1:
+		return fmt.Errorf("%w: %d", errTooFewChoices, c.NumChoices)
+	case c.ByzantineStake < 0 || c.ByzantineStake >= 1:
+		return fmt.Errorf("%w: %f", errInvalidByzantineStake, c.ByzantineStake)
+	case c.Latency.Min < 0 || c.Latency.Max < c.Latency.Min:
+		return fmt.Errorf("%w: [%s, %s]", errInvalidLatency, c.Latency.Min, c.Latency.Max)
+	case c.Latency.DropRate < 0 || c.Latency.DropRate >= 1:
+		return fmt.Errorf("%w: %f", errInvalidDropRate, c.Latency.DropRate)
+	case c.QueryTimeout <= 0:
+		return errNonPositiveQueryTimeout
+	case c.MaxDuration <= 0:
+		return errNonPositiveMaxDuration
+	case c.NumTrials < 1:
+		return errNonPositiveNumTrials
+	}
+
+	switch c.ByzantineStrategy {
+	case Silent, Oppose, Balance:
+	default:
+		return fmt.Errorf("%w: %q", errUnknownStrategy, c.ByzantineStrategy)
+	}
+
+	switch c.Stake.Distribution {
+	case Uniform:
+	case Pareto:
+		if c.Stake.ParetoShape < 0 {
+			return fmt.Errorf("%w: %f", errInvalidParetoShape, c.Stake.ParetoShape)
+		}
+	case Explicit:
+		if len(c.Stake.Weights) != c.NumNodes {
+			return fmt.Errorf("%w: expected %d but got %d", errWrongNumWeights, c.NumNodes, len(c.Stake.Weights))
+		}
+		for _, weight := range c.Stake.Weights {
+			if weight == 0 {
+				return errZeroWeight
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %q", errUnknownDistribution, c.Stake.Distribution)
+	}
+	return nil
+}
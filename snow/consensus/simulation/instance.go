@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
+	"github.com/f01c5700/avalanchego/utils/bag"
+	"github.com/f01c5700/avalanchego/utils/logging"
+)
+
+var (
+	_ instance = (*snowballInstance)(nil)
+	_ instance = (*snowmanInstance)(nil)
+
+	_ snowman.Block = (*block)(nil)
+	_ snow.Acceptor = noopAcceptor{}
+
+	genesisID = ids.Empty.Prefix(0)
+)
+
+// instance is the consensus run by an honest node.
+type instance interface {
+	Preference() ids.ID
+	RecordPoll(votes bag.Bag[ids.ID]) error
+	Finalized() bool
+}
+
+// choiceIDs returns the IDs of the choices being decided on.
+func choiceIDs(numChoices int) []ids.ID {
+	choices := make([]ids.ID, numChoices)
+	for i := range choices {
+		choices[i] = ids.Empty.Prefix(uint64(i) + 1)
+	}
+	return choices
+}
+
+// newInstance creates the consensus of an honest node that initially prefers
+// [choices[preference]].
+func newInstance(protocol Protocol, params snowball.Parameters, choices []ids.ID, preference int) (instance, error) {
+	if protocol == Snowball {
+		return newSnowballInstance(params, choices, preference), nil
+	}
+	return newSnowmanInstance(params, choices, preference)
+}
+
+type snowballInstance struct {
+	tree snowball.Consensus
+}
+
+func newSnowballInstance(params snowball.Parameters, choices []ids.ID, preference int) *snowballInstance {
+	tree := snowball.NewTree(snowball.SnowballFactory, params, choices[preference])
+	for i, choice := range choices {
+		if i != preference {
+			tree.Add(choice)
+		}
+	}
+	return &snowballInstance{
+		tree: tree,
+	}
+}
+
+func (s *snowballInstance) Preference() ids.ID {
+	return s.tree.Preference()
+}
+
+func (s *snowballInstance) RecordPoll(votes bag.Bag[ids.ID]) error {
+	if !s.tree.RecordPoll(votes) {
+		s.tree.RecordUnsuccessfulPoll()
+	}
+	return nil
+}
+
+func (s *snowballInstance) Finalized() bool {
+	return s.tree.Finalized()
+}
+
+type snowmanInstance struct {
+	consensus *snowman.Topological
+}
+
+// newSnowmanInstance creates a Topological whose last accepted block is the
+// parent of a block for every choice.
+func newSnowmanInstance(params snowball.Parameters, choices []ids.ID, preference int) (*snowmanInstance, error) {
+	ctx := &snow.ConsensusContext{
+		Context: &snow.Context{
+			Log: logging.NoLog{},
+		},
+		Registerer:    prometheus.NewRegistry(),
+		BlockAcceptor: noopAcceptor{},
+	}
+	consensus := &snowman.Topological{}
+	if err := consensus.Initialize(ctx, params, genesisID, 0, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	// The first block added to the Topological is preferred.
+	if err := consensus.Add(&block{id: choices[preference]}); err != nil {
+		return nil, err
+	}
+	for i, choice := range choices {
+		if i == preference {
+			continue
+		}
+		if err := consensus.Add(&block{id: choice}); err != nil {
+			return nil, err
+		}
+	}
+	return &snowmanInstance{
+		consensus: consensus,
+	}, nil
+}
+
+func (s *snowmanInstance) Preference() ids.ID {
+	return s.consensus.Preference()
+}
+
+func (s *snowmanInstance) RecordPoll(votes bag.Bag[ids.ID]) error {
+	return s.consensus.RecordPoll(context.Background(), votes)
+}
+
+func (s *snowmanInstance) Finalized() bool {
+	return s.consensus.NumProcessing() == 0
+}
+
+// block is a child of the genesis block.
+type block struct {
+	id ids.ID
+}
+
+func (b *block) ID() ids.ID {
+	return b.id
+}
+
+func (*block) Accept(context.Context) error {
+	return nil
+}
+
+func (*block) Reject(context.Context) error {
+	return nil
+}
+
+func (*block) Parent() ids.ID {
+	return genesisID
+}
+
+func (*block) Verify(context.Context) error {
+	return nil
+}
+
+func (b *block) Bytes() []byte {
+	return b.id[:]
+}
+
+func (*block) Height() uint64 {
+	return 1
+}
+
+func (*block) Timestamp() time.Time {
+	return time.Time{}
+}
+
+type noopAcceptor struct{}
+
+func (noopAcceptor) Accept(*snow.ConsensusContext, ids.ID, []byte) error {
+	return nil
+}
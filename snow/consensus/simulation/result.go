@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"slices"
+	"time"
+)
+
+type Result struct {
+	// SafetyViolations is the number of trials in which honest nodes finalized
+	// different choices.
+	SafetyViolations int `json:"safetyViolations"`
+	// LivenessFailures is the number of trials in which at least one honest
+	// node didn't finalize within the max duration.
+	LivenessFailures int `json:"livenessFailures"`
+	// FinalityLatency is the distribution of the virtual time it took honest
+	// nodes to finalize, across all trials.
+	FinalityLatency Distribution[time.Duration] `json:"finalityLatency"`
+	// FinalityPolls is the distribution of the number of polls it took honest
+	// nodes to finalize, across all trials.
+	FinalityPolls Distribution[int] `json:"finalityPolls"`
+	Trials        []TrialResult     `json:"trials"`
+}
+
+type TrialResult struct {
+	Seed              int64 `json:"seed"`
+	NumHonestNodes    int   `json:"numHonestNodes"`
+	NumByzantineNodes int   `json:"numByzantineNodes"`
+	// ByzantineStake is the fraction of the total stake that was controlled by
+	// byzantine nodes.
+	ByzantineStake float64 `json:"byzantineStake"`
+	// Decisions is the number of honest nodes that finalized each choice.
+	Decisions []int `json:"decisions"`
+	// NumUndecided is the number of honest nodes that didn't finalize.
+	NumUndecided    int  `json:"numUndecided"`
+	SafetyViolation bool `json:"safetyViolation"`
+	LivenessFailure bool `json:"livenessFailure"`
+	// Duration is the virtual time at which the trial ended.
+	Duration time.Duration `json:"duration"`
+	// NumPolls is the number of polls issued by honest nodes.
+	NumPolls int `json:"numPolls"`
+}
+
+type number interface {
+	~int | ~int64
+}
+
+// Distribution summarizes a set of samples. Percentiles use the nearest-rank
+// method.
+type Distribution[T number] struct {
+	Count int `json:"count"`
+	Min   T   `json:"min"`
+	Mean  T   `json:"mean"`
+	P50   T   `json:"p50"`
+	P90   T   `json:"p90"`
+	P99   T   `json:"p99"`
+	Max   T   `json:"max"`
+}
+
+func newDistribution[T number](samples []T) Distribution[T] {
+	if len(samples) == 0 {
+		return Distribution[T]{}
+	}
+
+	samples = slices.Clone(samples)
+	slices.Sort(samples)
+
+	var sum float64
+	for _, sample := range samples {
+		sum += float64(sample)
+	}
+	return Distribution[T]{
+		Count: len(samples),
+		Min:   samples[0],
+		Mean:  T(sum / float64(len(samples))),
+		P50:   percentile(samples, 50),
+		P90:   percentile(samples, 90),
+		P99:   percentile(samples, 99),
+		Max:   samples[len(samples)-1],
+	}
+}
+
+// percentile assumes [sorted] is sorted and non-empty.
+func percentile[T number](sorted []T, p int) T {
+	// The nearest rank is ceil(p/100 * n), which is 1-indexed.
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,390 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulation evaluates snowball parameters by running many virtual
+// nodes in a discrete-event simulation.
+//
+// Honest nodes repeatedly poll a stake-weighted sample of the nodes and record
+// the responses in their consensus instance until they finalize. Every query
+// and response is delayed and may be dropped according to the latency config,
+// and byzantine nodes respond according to their strategy. Given the same
+// config, a simulation always produces the same result.
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/bag"
+	"github.com/f01c5700/avalanchego/utils/heap"
+	"github.com/f01c5700/avalanchego/utils/sampler"
+)
+
+var errInsufficientStake = errors.New("total stake is less than k")
+
+type eventType uint8
+
+const (
+	queryArrived eventType = iota
+	responseArrived
+	pollTimedOut
+)
+
+type event struct {
+	at time.Duration
+	// seq breaks ties between events with the same time so that they are
+	// handled in the order they were scheduled.
+	seq       uint64
+	eventType eventType
+	poll      *poll
+	// validator is the index of the queried node.
+	validator int
+	// count is the number of times [validator] was sampled.
+	count int
+	vote  ids.ID
+}
+
+func eventLess(a, b *event) bool {
+	if a.at != b.at {
+		return a.at < b.at
+	}
+	return a.seq < b.seq
+}
+
+type node struct {
+	isByzantine bool
+	// consensus is nil for byzantine nodes.
+	consensus   instance
+	numPolls    int
+	finalized   bool
+	finalizedAt time.Duration
+}
+
+type poll struct {
+	node       *node
+	numPending int
+	votes      bag.Bag[ids.ID]
+	finished   bool
+}
+
+type trial struct {
+	config      Config
+	rng         *rand.Rand
+	sampler     sampler.WeightedWithoutReplacement
+	choices     []ids.ID
+	choiceIndex map[ids.ID]int
+	weights     []uint64
+	nodes       []*node
+	// honestPreferences is the number of honest nodes that prefer each
+	// choice.
+	honestPreferences map[ids.ID]int
+	numUndecided      int
+	numPolls          int
+
+	now     time.Duration
+	events  heap.Queue[*event]
+	nextSeq uint64
+}
+
+// Run runs every trial of the simulation described by [config].
+func Run(config Config) (*Result, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	var (
+		result = &Result{
+			Trials: make([]TrialResult, config.NumTrials),
+		}
+		latencies []time.Duration
+		polls     []int
+	)
+	for i := range result.Trials {
+		t, err := newTrial(config, config.Seed+int64(i))
+		if err != nil {
+			return nil, err
+		}
+		if err := t.run(); err != nil {
+			return nil, fmt.Errorf("trial %d failed: %w", i, err)
+		}
+
+		trialResult := t.result()
+		trialResult.Seed = config.Seed + int64(i)
+		result.Trials[i] = trialResult
+		if trialResult.SafetyViolation {
+			result.SafetyViolations++
+		}
+		if trialResult.LivenessFailure {
+			result.LivenessFailures++
+		}
+		for _, n := range t.nodes {
+			if n.finalized {
+				latencies = append(latencies, n.finalizedAt)
+				polls = append(polls, n.numPolls)
+			}
+		}
+	}
+	result.FinalityLatency = newDistribution(latencies)
+	result.FinalityPolls = newDistribution(polls)
+	return result, nil
+}
+
+func newTrial(config Config, seed int64) (*trial, error) {
+	var (
+		rng         = rand.New(rand.NewSource(seed)) // #nosec G404
+		weights     = weights(config.Stake, config.NumNodes, rng)
+		isByzantine = byzantine(weights, config.ByzantineStake, rng)
+		t           = &trial{
+			config:            config,
+			rng:               rng,
+			sampler:           sampler.NewDeterministicWeightedWithoutReplacement(rng),
+			choices:           choiceIDs(config.NumChoices),
+			choiceIndex:       make(map[ids.ID]int, config.NumChoices),
+			weights:           weights,
+			nodes:             make([]*node, config.NumNodes),
+			honestPreferences: make(map[ids.ID]int, config.NumChoices),
+			events:            heap.NewQueue(eventLess),
+		}
+	)
+	if err := t.sampler.Initialize(weights); err != nil {
+		return nil, err
+	}
+
+	var totalWeight uint64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	if totalWeight < uint64(config.Parameters.K) {
+		return nil, fmt.Errorf("%w: %d < %d", errInsufficientStake, totalWeight, config.Parameters.K)
+	}
+
+	for i, choice := range t.choices {
+		t.choiceIndex[choice] = i
+	}
+	for i := range t.nodes {
+		n := &node{
+			isByzantine: isByzantine[i],
+		}
+		t.nodes[i] = n
+		if n.isByzantine {
+			continue
+		}
+
+		preference := rng.Intn(config.NumChoices)
+		consensus, err := newInstance(config.Protocol, config.Parameters, t.choices, preference)
+		if err != nil {
+			return nil, err
+		}
+		n.consensus = consensus
+		t.honestPreferences[t.choices[preference]]++
+		t.numUndecided++
+	}
+	return t, nil
+}
+
+// run handles events until every honest node has finalized or the max
+// duration has passed.
+func (t *trial) run() error {
+	for _, n := range t.nodes {
+		if !n.isByzantine {
+			t.startPoll(n)
+		}
+	}
+
+	for t.numUndecided > 0 {
+		e, ok := t.events.Pop()
+		if !ok || e.at > t.config.MaxDuration {
+			t.now = t.config.MaxDuration
+			return nil
+		}
+		t.now = e.at
+
+		switch e.eventType {
+		case queryArrived:
+			t.handleQuery(e)
+		case responseArrived:
+			p := e.poll
+			if p.finished {
+				continue
+			}
+			p.votes.AddCount(e.vote, e.count)
+			p.numPending--
+			if p.numPending == 0 {
+				if err := t.finishPoll(p); err != nil {
+					return err
+				}
+			}
+		case pollTimedOut:
+			if !e.poll.finished {
+				if err := t.finishPoll(e.poll); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// startPoll sends a query to a stake-weighted sample of the nodes.
+func (t *trial) startPoll(n *node) {
+	n.numPolls++
+	t.numPolls++
+
+	// Sampling can't fail because the total stake is at least k.
+	indices, _ := t.sampler.Sample(t.config.Parameters.K)
+	// Sort the indices so that queries are sent in a deterministic order and
+	// validators that were sampled multiple times are adjacent.
+	slices.Sort(indices)
+
+	p := &poll{
+		node: n,
+	}
+	for i := 0; i < len(indices); {
+		validator := indices[i]
+		count := 1
+		for i+count < len(indices) && indices[i+count] == validator {
+			count++
+		}
+		i += count
+
+		p.numPending++
+		if t.dropped() {
+			continue
+		}
+		t.schedule(&event{
+			at:        t.now + t.delay(),
+			eventType: queryArrived,
+			poll:      p,
+			validator: validator,
+			count:     count,
+		})
+	}
+	t.schedule(&event{
+		at:        t.now + t.config.QueryTimeout,
+		eventType: pollTimedOut,
+		poll:      p,
+	})
+}
+
+// handleQuery responds to a query once it arrives at the queried node.
+func (t *trial) handleQuery(e *event) {
+	var (
+		responder = t.nodes[e.validator]
+		vote      ids.ID
+	)
+	switch {
+	case !responder.isByzantine:
+		vote = responder.consensus.Preference()
+	case t.config.ByzantineStrategy == Oppose:
+		preference := e.poll.node.consensus.Preference()
+		vote = t.choices[(t.choiceIndex[preference]+1)%len(t.choices)]
+	case t.config.ByzantineStrategy == Balance:
+		vote = t.leastPreferred()
+	default:
+		// Silent byzantine nodes never respond.
+		return
+	}
+
+	if t.dropped() {
+		return
+	}
+	t.schedule(&event{
+		at:        t.now + t.delay(),
+		eventType: responseArrived,
+		poll:      e.poll,
+		count:     e.count,
+		vote:      vote,
+	})
+}
+
+// finishPoll records the votes of [p] and starts the next poll if the node
+// hasn't finalized.
+func (t *trial) finishPoll(p *poll) error {
+	p.finished = true
+
+	n := p.node
+	oldPreference := n.consensus.Preference()
+	if err := n.consensus.RecordPoll(p.votes); err != nil {
+		return err
+	}
+	if newPreference := n.consensus.Preference(); newPreference != oldPreference {
+		t.honestPreferences[oldPreference]--
+		t.honestPreferences[newPreference]++
+	}
+
+	if !n.consensus.Finalized() {
+		t.startPoll(n)
+		return nil
+	}
+	n.finalized = true
+	n.finalizedAt = t.now
+	t.numUndecided--
+	return nil
+}
+
+// leastPreferred returns the choice preferred by the fewest honest nodes.
+func (t *trial) leastPreferred() ids.ID {
+	leastPreferred := t.choices[0]
+	for _, choice := range t.choices[1:] {
+		if t.honestPreferences[choice] < t.honestPreferences[leastPreferred] {
+			leastPreferred = choice
+		}
+	}
+	return leastPreferred
+}
+
+func (t *trial) delay() time.Duration {
+	latency := t.config.Latency
+	return latency.Min + time.Duration(t.rng.Int63n(int64(latency.Max-latency.Min)+1))
+}
+
+func (t *trial) dropped() bool {
+	return t.config.Latency.DropRate > 0 && t.rng.Float64() < t.config.Latency.DropRate
+}
+
+func (t *trial) schedule(e *event) {
+	e.seq = t.nextSeq
+	t.nextSeq++
+	t.events.Push(e)
+}
+
+func (t *trial) result() TrialResult {
+	var (
+		result = TrialResult{
+			Decisions:    make([]int, len(t.choices)),
+			NumUndecided: t.numUndecided,
+			Duration:     t.now,
+			NumPolls:     t.numPolls,
+		}
+		totalStake     uint64
+		byzantineStake uint64
+		numDecisions   int
+	)
+	for i, n := range t.nodes {
+		weight := t.weights[i]
+		totalStake += weight
+		if n.isByzantine {
+			result.NumByzantineNodes++
+			byzantineStake += weight
+			continue
+		}
+
+		result.NumHonestNodes++
+		if n.finalized {
+			result.Decisions[t.choiceIndex[n.consensus.Preference()]]++
+		}
+	}
+	for _, numDecided := range result.Decisions {
+		if numDecided > 0 {
+			numDecisions++
+		}
+	}
+	result.ByzantineStake = float64(byzantineStake) / float64(totalStake)
+	result.SafetyViolation = numDecisions > 1
+	result.LivenessFailure = t.numUndecided > 0
+	return result
+}
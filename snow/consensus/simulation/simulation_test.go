@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+)
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.NumNodes = 200
+	config.NumTrials = 2
+	return config
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "unknown protocol",
+			modify: func(c *Config) {
+				c.Protocol = "avalanche"
+			},
+			expectedErr: errUnknownProtocol,
+		},
+		{
+			name: "invalid parameters",
+			modify: func(c *Config) {
+				c.Parameters.K = 0
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "no nodes",
+			modify: func(c *Config) {
+				c.NumNodes = 0
+			},
+			expectedErr: errTooFewNodes,
+		},
+		{
+			name: "all stake byzantine",
+			modify: func(c *Config) {
+				c.ByzantineStake = 1
+			},
+			expectedErr: errInvalidByzantineStake,
+		},
+		{
+			name: "max latency below min latency",
+			modify: func(c *Config) {
+				c.Latency.Max = c.Latency.Min - 1
+			},
+			expectedErr: errInvalidLatency,
+		},
+		{
+			name: "every message dropped",
+			modify: func(c *Config) {
+				c.Latency.DropRate = 1
+			},
+			expectedErr: errInvalidDropRate,
+		},
+		{
+			name: "unknown strategy",
+			modify: func(c *Config) {
+				c.ByzantineStrategy = "crash"
+			},
+			expectedErr: errUnknownStrategy,
+		},
+		{
+			name: "wrong number of weights",
+			modify: func(c *Config) {
+				c.Stake = StakeConfig{
+					Distribution: Explicit,
+					Weights:      []uint64{1},
+				}
+			},
+			expectedErr: errWrongNumWeights,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			test.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRunHonest(t *testing.T) {
+	for _, protocol := range []Protocol{Snowball, Snowman} {
+		t.Run(string(protocol), func(t *testing.T) {
+			require := require.New(t)
+
+			config := testConfig()
+			config.Protocol = protocol
+			config.NumChoices = 3
+			config.Stake.Distribution = Pareto
+
+			result, err := Run(config)
+			require.NoError(err)
+			require.Zero(result.SafetyViolations)
+			require.Zero(result.LivenessFailures)
+			require.Equal(config.NumNodes*config.NumTrials, result.FinalityLatency.Count)
+			require.GreaterOrEqual(result.FinalityPolls.Min, config.Parameters.Beta)
+			// Every poll takes at least one round trip.
+			require.GreaterOrEqual(result.FinalityLatency.Min, time.Duration(config.Parameters.Beta)*2*config.Latency.Min)
+			require.LessOrEqual(result.FinalityLatency.P50, result.FinalityLatency.P99)
+			for _, trial := range result.Trials {
+				require.Zero(trial.NumByzantineNodes)
+				require.Zero(trial.NumUndecided)
+			}
+		})
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.ByzantineStake = .2
+	config.Latency.DropRate = .05
+
+	result0, err := Run(config)
+	require.NoError(err)
+	result1, err := Run(config)
+	require.NoError(err)
+	require.Equal(result0, result1)
+
+	config.Seed++
+	result2, err := Run(config)
+	require.NoError(err)
+	require.NotEqual(result0, result2)
+}
+
+func TestRunSilentByzantineHaltsProgress(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig()
+	config.NumTrials = 1
+	config.MaxDuration = 10 * time.Second
+	// Polls can't reach AlphaConfidence if more than K-AlphaConfidence of the
+	// sampled stake doesn't respond.
+	config.ByzantineStake = .5
+	config.ByzantineStrategy = Silent
+
+	result, err := Run(config)
+	require.NoError(err)
+	require.Equal(1, result.LivenessFailures)
+
+	trial := result.Trials[0]
+	require.True(trial.LivenessFailure)
+	require.Positive(trial.NumUndecided)
+	require.Equal(config.MaxDuration, trial.Duration)
+	require.InDelta(.5, trial.ByzantineStake, .01)
+}
+
+func TestNewDistribution(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(Distribution[int]{}, newDistribution[int](nil))
+
+	samples := make([]int, 100)
+	for i := range samples {
+		samples[i] = 100 - i
+	}
+	require.Equal(
+		Distribution[int]{
+			Count: 100,
+			Min:   1,
+			Mean:  50,
+			P50:   50,
+			P90:   90,
+			P99:   99,
+			Max:   100,
+		},
+		newDistribution(samples),
+	)
+}
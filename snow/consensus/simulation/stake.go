@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"math"
+	"math/rand"
+)
+
+// paretoScale is the minimum stake assigned by the pareto distribution. It is
+// large enough that truncating the samples to integers has a negligible
+// effect.
+const paretoScale = 1_000_000
+
+// weights returns the stake of every node.
+func weights(config StakeConfig, numNodes int, rng *rand.Rand) []uint64 {
+	weights := make([]uint64, numNodes)
+	switch config.Distribution {
+	case Explicit:
+		copy(weights, config.Weights)
+	case Pareto:
+		shape := config.ParetoShape
+		if shape == 0 {
+			shape = defaultParetoShape
+		}
+		for i := range weights {
+			// Inverse transform sampling. 1-Float64 is in (0, 1].
+			sample := paretoScale / math.Pow(1-rng.Float64(), 1/shape)
+			weights[i] = uint64(min(sample, math.MaxUint32))
+		}
+	default:
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// byzantine randomly marks nodes as byzantine until marking another node would
+// exceed [fraction] of the total stake.
+func byzantine(weights []uint64, fraction float64, rng *rand.Rand) []bool {
+	var totalWeight uint64
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	var (
+		isByzantine    = make([]bool, len(weights))
+		maxByzantine   = uint64(fraction * float64(totalWeight))
+		byzantineStake uint64
+	)
+	for _, i := range rng.Perm(len(weights)) {
+		weight := weights[i]
+		if byzantineStake+weight > maxByzantine {
+			continue
+		}
+		isByzantine[i] = true
+		byzantineStake += weight
+	}
+	return isByzantine
+}
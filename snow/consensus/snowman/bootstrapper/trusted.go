@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrapper
+
+import (
+	"context"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/set"
+)
+
+var _ Poll = (*Trusted)(nil)
+
+// Trusted is a poll that reports a set of potentially accepted blocks that is
+// known ahead of time, such as a checkpoint, without requesting the opinion of
+// any peers. It can be used in place of the Minority poll, in which case the
+// blocks are still verified by the Majority poll.
+type Trusted struct {
+	blkIDs []ids.ID
+}
+
+func NewTrusted(blkIDs ...ids.ID) *Trusted {
+	return &Trusted{
+		blkIDs: blkIDs,
+	}
+}
+
+func (*Trusted) GetPeers(context.Context) set.Set[ids.NodeID] {
+	return nil
+}
+
+func (*Trusted) RecordOpinion(context.Context, ids.NodeID, set.Set[ids.ID]) error {
+	return nil
+}
+
+func (t *Trusted) Result(context.Context) ([]ids.ID, bool) {
+	return t.blkIDs, true
+}
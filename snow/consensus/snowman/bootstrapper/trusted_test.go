@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+)
+
+func TestTrusted(t *testing.T) {
+	require := require.New(t)
+
+	trusted := NewTrusted(blkID0)
+
+	require.Empty(trusted.GetPeers(context.Background()))
+
+	require.NoError(trusted.RecordOpinion(context.Background(), nodeID0, nil))
+
+	blkIDs, finalized := trusted.Result(context.Background())
+	require.Equal([]ids.ID{blkID0}, blkIDs)
+	require.True(finalized)
+}
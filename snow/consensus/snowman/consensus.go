@@ -61,4 +61,8 @@ type Consensus interface {
 	// RecordPoll collects the results of a network poll. Assumes all decisions
 	// have been previously added. Returns if a critical error has occurred.
 	RecordPoll(context.Context, bag.Bag[ids.ID]) error
+
+	// Inspect returns the current state of the last accepted and processing
+	// blocks.
+	Inspect() State
 }
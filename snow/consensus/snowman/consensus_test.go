@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/f01c5700/avalanchego/snow/snowtest"
 	"github.com/f01c5700/avalanchego/utils/bag"
@@ -55,6 +57,7 @@ var (
 		ErrorOnTransitiveRejectionTest,
 		RandomizedConsistencyTest,
 		ErrorOnAddDecidedBlockTest,
+		InspectTest,
 		RecordPollWithDefaultParameters,
 		RecordPollRegressionCalculateInDegreeIndegreeCalculation,
 	}
@@ -1302,8 +1305,6 @@ func ErrorOnTransitiveRejectionTest(t *testing.T, factory Factory) {
 }
 
 func RandomizedConsistencyTest(t *testing.T, factory Factory) {
-	require := require.New(t)
-
 	var (
 		numColors = 50
 		numNodes  = 100
@@ -1317,23 +1318,46 @@ func RandomizedConsistencyTest(t *testing.T, factory Factory) {
 			MaxOutstandingItems:   1,
 			MaxItemProcessingTime: 1,
 		}
-		seed   uint64 = 0
-		source        = prng.NewMT19937()
+		seed uint64 = 0
 	)
 
-	source.Seed(seed)
+	pollStrategies := []poll.StrategyConfig{
+		{
+			Strategy: poll.EarlyTermNoTraversal,
+		},
+		{
+			Strategy:          poll.EarlyTermLatencyAware,
+			ConfidenceTimeout: time.Nanosecond,
+		},
+	}
+	for _, pollStrategy := range pollStrategies {
+		t.Run(string(pollStrategy.Strategy), func(t *testing.T) {
+			require := require.New(t)
 
-	n := NewNetwork(params, numColors, source)
+			pollFactory, err := poll.NewFactory(
+				pollStrategy,
+				params.AlphaPreference,
+				params.AlphaConfidence,
+				prometheus.NewRegistry(),
+			)
+			require.NoError(err)
 
-	for i := 0; i < numNodes; i++ {
-		require.NoError(n.AddNode(t, factory.New()))
-	}
+			source := prng.NewMT19937()
+			source.Seed(seed)
 
-	for !n.Finalized() {
-		require.NoError(n.Round())
-	}
+			n := NewNetwork(params, pollFactory, numColors, source)
+
+			for i := 0; i < numNodes; i++ {
+				require.NoError(n.AddNode(t, factory.New()))
+			}
 
-	require.True(n.Agreement())
+			for !n.Finalized() {
+				require.NoError(n.Round())
+			}
+
+			require.True(n.Agreement())
+		})
+	}
 }
 
 func ErrorOnAddDecidedBlockTest(t *testing.T, factory Factory) {
@@ -1465,3 +1489,66 @@ func RecordPollRegressionCalculateInDegreeIndegreeCalculation(t *testing.T, fact
 	require.Equal(snowtest.Accepted, blk2.Status)
 	require.Equal(snowtest.Accepted, blk3.Status)
 }
+
+func InspectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  3,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(snowmantest.Genesis)
+	block2 := snowmantest.BuildChild(block1)
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+	require.NoError(sm.Add(block2))
+
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block2.ID())))
+
+	state := sm.Inspect()
+	require.Equal(snowmantest.GenesisID, state.LastAcceptedID)
+	require.Equal(snowmantest.GenesisHeight, state.LastAcceptedHeight)
+	require.Equal(block2.ID(), state.Preference)
+	require.Equal(uint64(1), state.NumPolls)
+	require.Len(state.Blocks, 4)
+
+	genesisState := state.Blocks[0]
+	require.Equal(snowmantest.GenesisID, genesisState.ID)
+	require.False(genesisState.Processing)
+	require.True(genesisState.Preferred)
+	require.NotNil(genesisState.Children)
+	require.Equal(block1.ID(), genesisState.Children.Preference)
+	require.False(genesisState.Children.Finalized)
+
+	for _, blockState := range state.Blocks[1:3] {
+		require.True(blockState.Processing)
+		require.Equal(snowmantest.GenesisID, blockState.ParentID)
+		require.Equal(block0.Height(), blockState.Height)
+		require.Equal(blockState.ID == block1.ID(), blockState.Preferred)
+	}
+
+	block2State := state.Blocks[3]
+	require.Equal(block2.ID(), block2State.ID)
+	require.Equal(block1.ID(), block2State.ParentID)
+	require.True(block2State.Preferred)
+	require.Nil(block2State.Children)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/f01c5700/avalanchego/snow/snowtest"
 	"github.com/f01c5700/avalanchego/utils"
@@ -18,14 +19,17 @@ import (
 
 type Network struct {
 	params         snowball.Parameters
+	pollFactory    poll.Factory
 	colors         []*snowmantest.Block
 	rngSource      sampler.Source
+	nodeIDs        []ids.NodeID
 	nodes, running []Consensus
 }
 
-func NewNetwork(params snowball.Parameters, numColors int, rngSource sampler.Source) *Network {
+func NewNetwork(params snowball.Parameters, pollFactory poll.Factory, numColors int, rngSource sampler.Source) *Network {
 	n := &Network{
-		params: params,
+		params:      params,
+		pollFactory: pollFactory,
 		colors: []*snowmantest.Block{{
 			Decidable: snowtest.Decidable{
 				IDV:    ids.Empty.Prefix(rngSource.Uint64()),
@@ -95,6 +99,7 @@ func (n *Network) AddNode(t testing.TB, sm Consensus) error {
 		}
 		deps[myBlock.ID()] = myDep
 	}
+	n.nodeIDs = append(n.nodeIDs, ids.GenerateTestNodeID())
 	n.nodes = append(n.nodes, sm)
 	n.running = append(n.running, sm)
 	return nil
@@ -117,13 +122,22 @@ func (n *Network) Round() error {
 
 	s.Initialize(uint64(len(n.nodes)))
 	indices, _ := s.Sample(n.params.K)
-	sampledColors := bag.Bag[ids.ID]{}
+	sampledNodeIDs := bag.Bag[ids.NodeID]{}
+	for _, index := range indices {
+		sampledNodeIDs.Add(n.nodeIDs[int(index)])
+	}
+
+	// Responses are received in the order the peers were sampled.
+	p := n.pollFactory.New(sampledNodeIDs)
 	for _, index := range indices {
 		peer := n.nodes[int(index)]
-		sampledColors.Add(peer.Preference())
+		p.Vote(n.nodeIDs[int(index)], peer.Preference())
+		if p.Finished() {
+			break
+		}
 	}
 
-	if err := running.RecordPoll(context.Background(), sampledColors); err != nil {
+	if err := running.RecordPoll(context.Background(), p.Result()); err != nil {
 		return err
 	}
 
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/bag"
+)
+
+type earlyTermLatencyAwareFactory struct {
+	alphaPreference   int
+	alphaConfidence   int
+	confidenceTimeout time.Duration
+
+	metrics *earlyTermNoTraversalMetrics
+}
+
+// NewEarlyTermLatencyAwareFactory returns a factory that returns polls with
+// the same early termination as NewEarlyTermNoTraversalFactory. Additionally,
+// once a poll has been outstanding for [confidenceTimeout], it finishes as soon
+// as a single element has achieved an alphaPreference majority rather than
+// waiting on slow validators to possibly achieve an alphaConfidence majority.
+func NewEarlyTermLatencyAwareFactory(
+	alphaPreference int,
+	alphaConfidence int,
+	confidenceTimeout time.Duration,
+	reg prometheus.Registerer,
+) (Factory, error) {
+	metrics, err := newEarlyTermNoTraversalMetrics(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &earlyTermLatencyAwareFactory{
+		alphaPreference:   alphaPreference,
+		alphaConfidence:   alphaConfidence,
+		confidenceTimeout: confidenceTimeout,
+		metrics:           metrics,
+	}, nil
+}
+
+func (f *earlyTermLatencyAwareFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	return &earlyTermLatencyAwarePoll{
+		earlyTermNoTraversalPoll: earlyTermNoTraversalPoll{
+			polled:          vdrs,
+			alphaPreference: f.alphaPreference,
+			alphaConfidence: f.alphaConfidence,
+			metrics:         f.metrics,
+			start:           time.Now(),
+		},
+		confidenceTimeout: f.confidenceTimeout,
+	}
+}
+
+// earlyTermLatencyAwarePoll trades the chance of a successful alphaConfidence
+// poll for lower poll latency.
+//
+// Finished is checked when a response is received or dropped, and periodically
+// by the engine, so a poll that times out finishes even if no more responses
+// arrive.
+type earlyTermLatencyAwarePoll struct {
+	earlyTermNoTraversalPoll
+
+	confidenceTimeout time.Duration
+}
+
+// Finished returns true when the earlyTermNoTraversalPoll would be finished or
+// when the poll has been outstanding for at least confidenceTimeout and a
+// single element has achieved an alphaPreference majority.
+func (p *earlyTermLatencyAwarePoll) Finished() bool {
+	if p.earlyTermNoTraversalPoll.Finished() {
+		return true
+	}
+
+	duration := time.Since(p.start)
+	if duration < p.confidenceTimeout {
+		return false
+	}
+
+	_, freq := p.votes.Mode()
+	if freq < p.alphaPreference {
+		return false
+	}
+
+	p.finished = true
+	p.metrics.observeConfTimeout(duration)
+	return true
+}
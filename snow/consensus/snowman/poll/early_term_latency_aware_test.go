@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/utils/bag"
+)
+
+func TestEarlyTermLatencyAwareWaitsForConfidenceBeforeTimeout(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	alphaPreference := 3
+	alphaConfidence := 5
+
+	factory, err := NewEarlyTermLatencyAwareFactory(alphaPreference, alphaConfidence, time.Hour, prometheus.NewRegistry())
+	require.NoError(err)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr4, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr5, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(5, result.Count(blkID1))
+}
+
+func TestEarlyTermLatencyAwareTerminatesWithAlphaPreferenceAfterTimeout(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	alphaPreference := 3
+	alphaConfidence := 5
+
+	factory, err := NewEarlyTermLatencyAwareFactory(alphaPreference, alphaConfidence, time.Nanosecond, prometheus.NewRegistry())
+	require.NoError(err)
+	poll := factory.New(vdrs)
+	time.Sleep(time.Millisecond)
+
+	poll.Vote(vdr1, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr2, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr4, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(3, result.Count(blkID1))
+}
+
+func TestEarlyTermLatencyAwareTerminatesAfterTimeoutWithoutFurtherVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	alphaPreference := 3
+	alphaConfidence := 5
+	confidenceTimeout := 10 * time.Millisecond
+
+	factory, err := NewEarlyTermLatencyAwareFactory(alphaPreference, alphaConfidence, confidenceTimeout, prometheus.NewRegistry())
+	require.NoError(err)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	time.Sleep(2 * confidenceTimeout)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(3, result.Count(blkID1))
+}
+
+func TestEarlyTermLatencyAwareTerminatesEarlyWithoutAlphaPreference(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+	alpha := 2
+
+	factory, err := NewEarlyTermLatencyAwareFactory(alpha, alpha, time.Hour, prometheus.NewRegistry())
+	require.NoError(err)
+	poll := factory.New(vdrs)
+
+	poll.Drop(vdr1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr2)
+	require.True(poll.Finished())
+}
@@ -23,6 +23,7 @@ var (
 	earlyFailReason      = "early_fail"
 	earlyAlphaPrefReason = "early_alpha_pref"
 	earlyAlphaConfReason = "early_alpha_conf"
+	confTimeoutReason    = "confidence_timeout"
 
 	exhaustedLabel = prometheus.Labels{
 		terminationReason: exhaustedReason,
@@ -36,6 +37,9 @@ var (
 	earlyAlphaConfLabel = prometheus.Labels{
 		terminationReason: earlyAlphaConfReason,
 	}
+	confTimeoutLabel = prometheus.Labels{
+		terminationReason: confTimeoutReason,
+	}
 )
 
 type earlyTermNoTraversalMetrics struct {
@@ -43,11 +47,13 @@ type earlyTermNoTraversalMetrics struct {
 	durEarlyFailPolls      prometheus.Gauge
 	durEarlyAlphaPrefPolls prometheus.Gauge
 	durEarlyAlphaConfPolls prometheus.Gauge
+	durConfTimeoutPolls    prometheus.Gauge
 
 	countExhaustedPolls      prometheus.Counter
 	countEarlyFailPolls      prometheus.Counter
 	countEarlyAlphaPrefPolls prometheus.Counter
 	countEarlyAlphaConfPolls prometheus.Counter
+	countConfTimeoutPolls    prometheus.Counter
 }
 
 func newEarlyTermNoTraversalMetrics(reg prometheus.Registerer) (*earlyTermNoTraversalMetrics, error) {
@@ -71,10 +77,12 @@ func newEarlyTermNoTraversalMetrics(reg prometheus.Registerer) (*earlyTermNoTrav
 		durEarlyFailPolls:        durPollsVec.With(earlyFailLabel),
 		durEarlyAlphaPrefPolls:   durPollsVec.With(earlyAlphaPrefLabel),
 		durEarlyAlphaConfPolls:   durPollsVec.With(earlyAlphaConfLabel),
+		durConfTimeoutPolls:      durPollsVec.With(confTimeoutLabel),
 		countExhaustedPolls:      pollCountVec.With(exhaustedLabel),
 		countEarlyFailPolls:      pollCountVec.With(earlyFailLabel),
 		countEarlyAlphaPrefPolls: pollCountVec.With(earlyAlphaPrefLabel),
 		countEarlyAlphaConfPolls: pollCountVec.With(earlyAlphaConfLabel),
+		countConfTimeoutPolls:    pollCountVec.With(confTimeoutLabel),
 	}, nil
 }
 
@@ -98,6 +106,11 @@ func (m *earlyTermNoTraversalMetrics) observeEarlyAlphaConf(duration time.Durati
 	m.countEarlyAlphaConfPolls.Inc()
 }
 
+func (m *earlyTermNoTraversalMetrics) observeConfTimeout(duration time.Duration) {
+	m.durConfTimeoutPolls.Add(float64(duration.Nanoseconds()))
+	m.countConfTimeoutPolls.Inc()
+}
+
 type earlyTermNoTraversalFactory struct {
 	alphaPreference int
 	alphaConfidence int
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+)
+
+// Info describes the progress of a poll.
+type Info struct {
+	RequestID uint32    `json:"requestID"`
+	StartTime time.Time `json:"startTime"`
+	// Duration is the amount of time the poll took to finish. If the poll is
+	// still outstanding, it is the amount of time since the poll started.
+	Duration time.Duration `json:"duration"`
+	// Finished is true if the poll has its result. An outstanding poll may
+	// be finished if it is waiting for an older poll to finish.
+	Finished bool `json:"finished"`
+	// Responses are the votes received, in the order they were received.
+	Responses []Response `json:"responses"`
+	// Dropped are the validators whose requests failed.
+	Dropped []ids.NodeID `json:"dropped"`
+	// Pending are the validators that haven't responded.
+	Pending []ids.NodeID `json:"pending"`
+	// Votes is the weighted number of votes each block received.
+	Votes map[ids.ID]int `json:"votes"`
+}
+
+// Response is a vote received from a validator.
+type Response struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Vote   ids.ID     `json:"vote"`
+}
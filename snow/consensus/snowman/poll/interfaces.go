@@ -18,7 +18,18 @@ type Set interface {
 	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
 	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
 	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
+	// ProcessFinished returns the results of the polls that have finished
+	// without receiving another response, such as polls that finished after
+	// a timeout.
+	ProcessFinished() []bag.Bag[ids.ID]
 	Len() int
+
+	// Outstanding returns the polls that haven't been removed from the set,
+	// ordered from oldest to newest.
+	Outstanding() []Info
+	// Recent returns up to [limit] of the most recently finished polls,
+	// ordered from newest to oldest.
+	Recent(limit int) []Info
 }
 
 // Poll is an outstanding poll
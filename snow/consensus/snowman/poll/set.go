@@ -6,6 +6,7 @@ package poll
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,7 +14,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils"
 	"github.com/f01c5700/avalanchego/utils/bag"
+	"github.com/f01c5700/avalanchego/utils/buffer"
 	"github.com/f01c5700/avalanchego/utils/linked"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/utils/metric"
@@ -24,22 +27,78 @@ var (
 	errFailedPollDurationMetrics = errors.New("failed to register poll_duration metrics")
 )
 
-type pollHolder interface {
-	GetPoll() Poll
-	StartTime() time.Time
-}
+// MaxRecentPolls is the number of finished polls that a set remembers.
+const MaxRecentPolls = 64
 
 type poll struct {
 	Poll
-	start time.Time
+	requestID uint32
+	start     time.Time
+
+	// pending is tracked separately from the underlying poll, which doesn't
+	// expose which validators it is waiting on.
+	pending   bag.Bag[ids.NodeID]
+	responses []Response
+	dropped   []ids.NodeID
+	// finished caches the result of the underlying poll's Finished, which
+	// records metrics, so that the poll can be inspected without side
+	// effects.
+	finished bool
+}
+
+// checkFinished returns true if the underlying poll has finished. This should
+// only be called when the poll may have progressed.
+func (p *poll) checkFinished() bool {
+	if !p.finished {
+		p.finished = p.Finished()
+	}
+	return p.finished
+}
+
+// isFinished returns true if the poll was last observed to be finished.
+func (p *poll) isFinished() bool {
+	return p.finished
+}
+
+func (p *poll) recordResponse(vdr ids.NodeID, vote ids.ID) {
+	if p.pending.Count(vdr) == 0 {
+		return
+	}
+	p.pending.Remove(vdr)
+	p.responses = append(p.responses, Response{
+		NodeID: vdr,
+		Vote:   vote,
+	})
 }
 
-func (p poll) GetPoll() Poll {
-	return p
+func (p *poll) recordDrop(vdr ids.NodeID) {
+	if p.pending.Count(vdr) == 0 {
+		return
+	}
+	p.pending.Remove(vdr)
+	p.dropped = append(p.dropped, vdr)
 }
 
-func (p poll) StartTime() time.Time {
-	return p.start
+func (p *poll) info(now time.Time) Info {
+	pending := p.pending.List()
+	utils.Sort(pending)
+
+	result := p.Result()
+	votes := make(map[ids.ID]int)
+	for _, blkID := range result.List() {
+		votes[blkID] = result.Count(blkID)
+	}
+
+	return Info{
+		RequestID: p.requestID,
+		StartTime: p.start,
+		Duration:  now.Sub(p.start),
+		Finished:  p.isFinished(),
+		Responses: slices.Clone(p.responses),
+		Dropped:   slices.Clone(p.dropped),
+		Pending:   pending,
+		Votes:     votes,
+	}
 }
 
 type set struct {
@@ -48,7 +107,9 @@ type set struct {
 	durPolls metric.Averager
 	factory  Factory
 	// maps requestID -> poll
-	polls *linked.Hashmap[uint32, pollHolder]
+	polls *linked.Hashmap[uint32, *poll]
+	// recent contains the most recently finished polls
+	recent buffer.Queue[Info]
 }
 
 // NewSet returns a new empty set of polls
@@ -74,12 +135,18 @@ func NewSet(
 		return nil, fmt.Errorf("%w: %w", errFailedPollDurationMetrics, err)
 	}
 
+	recent, err := buffer.NewBoundedQueue[Info](MaxRecentPolls, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	return &set{
 		log:      log,
 		numPolls: numPolls,
 		durPolls: durPolls,
 		factory:  factory,
-		polls:    linked.NewHashmap[uint32, pollHolder](),
+		polls:    linked.NewHashmap[uint32, *poll](),
+		recent:   recent,
 	}, nil
 }
 
@@ -100,9 +167,11 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
-	s.polls.Put(requestID, poll{
-		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+	s.polls.Put(requestID, &poll{
+		Poll:      s.factory.New(vdrs), // create the new poll
+		requestID: requestID,
+		start:     time.Now(),
+		pending:   bag.Of(vdrs.List()...),
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -111,7 +180,7 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 // Vote registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
 func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -121,16 +190,15 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 		return nil
 	}
 
-	p := holder.GetPoll()
-
 	s.log.Verbo("processing vote",
 		zap.Stringer("validator", vdr),
 		zap.Uint32("requestID", requestID),
 		zap.Stringer("vote", vote),
 	)
 
+	p.recordResponse(vdr, vote)
 	p.Vote(vdr, vote)
-	if !p.Finished() {
+	if !p.checkFinished() {
 		return nil
 	}
 
@@ -139,14 +207,16 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 
 // processFinishedPolls checks for other dependent finished polls and returns them all if finished
 func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
-	var results []bag.Bag[ids.ID]
+	var (
+		results []bag.Bag[ids.ID]
+		now     = time.Now()
+	)
 
 	// iterate from oldest to newest
 	iter := s.polls.NewIterator()
 	for iter.Next() {
-		holder := iter.Value()
-		p := holder.GetPoll()
-		if !p.Finished() {
+		p := iter.Value()
+		if !p.checkFinished() {
 			// since we're iterating from oldest to newest, if the next poll has not finished,
 			// we can break and return what we have so far
 			break
@@ -154,10 +224,11 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 
 		s.log.Verbo("poll finished",
 			zap.Uint32("requestID", iter.Key()),
-			zap.Stringer("poll", holder.GetPoll()),
+			zap.Stringer("poll", p),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		s.durPolls.Observe(float64(now.Sub(p.start)))
 		s.numPolls.Dec() // decrease the metrics
+		s.recent.Push(p.info(now))
 
 		results = append(results, p.Result())
 		s.polls.Delete(iter.Key())
@@ -171,7 +242,7 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 // Drop registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
 func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+	poll, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -186,28 +257,54 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 		zap.Uint32("requestID", requestID),
 	)
 
-	poll := holder.GetPoll()
-
+	poll.recordDrop(vdr)
 	poll.Drop(vdr)
-	if !poll.Finished() {
+	if !poll.checkFinished() {
 		return nil
 	}
 
 	return s.processFinishedPolls()
 }
 
+func (s *set) ProcessFinished() []bag.Bag[ids.ID] {
+	return s.processFinishedPolls()
+}
+
 // Len returns the number of outstanding polls
 func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) Outstanding() []Info {
+	var (
+		now   = time.Now()
+		infos = make([]Info, 0, s.polls.Len())
+		iter  = s.polls.NewIterator()
+	)
+	for iter.Next() {
+		infos = append(infos, iter.Value().info(now))
+	}
+	return infos
+}
+
+func (s *set) Recent(limit int) []Info {
+	numRecent := s.recent.Len()
+	limit = min(limit, numRecent)
+	infos := make([]Info, 0, max(limit, 0))
+	for i := 0; i < limit; i++ {
+		info, _ := s.recent.Index(numRecent - 1 - i)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
 	iter := s.polls.NewIterator()
 	for iter.Next() {
 		requestID := iter.Key()
-		poll := iter.Value().(Poll)
+		poll := iter.Value()
 		sb.WriteString(fmt.Sprintf("\n    RequestID %d:\n        %s", requestID, poll.PrefixedString("        ")))
 	}
 	return sb.String()
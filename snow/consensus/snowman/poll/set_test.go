@@ -5,6 +5,7 @@ package poll
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
@@ -236,6 +237,141 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 	require.Empty(results[0].List())
 }
 
+func TestSetOutstandingAndRecent(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := []ids.NodeID{vdr1, vdr2, vdr3} // k = 3
+	alpha := 2
+
+	factory := newEarlyTermNoTraversalTestFactory(require, alpha)
+	log := logging.NoLog{}
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(1, bag.Of(vdrs...)))
+	require.True(s.Add(2, bag.Of(vdrs...)))
+
+	require.Empty(s.Vote(1, vdr2, blkID1))
+	require.Empty(s.Vote(1, vdr2, blkID2)) // duplicate responses are ignored
+	require.Empty(s.Drop(1, vdr3))
+
+	outstanding := s.Outstanding()
+	require.Len(outstanding, 2)
+
+	poll1 := outstanding[0]
+	require.Equal(uint32(1), poll1.RequestID)
+	require.False(poll1.Finished)
+	require.Equal([]Response{{NodeID: vdr2, Vote: blkID1}}, poll1.Responses)
+	require.Equal([]ids.NodeID{vdr3}, poll1.Dropped)
+	require.Equal([]ids.NodeID{vdr1}, poll1.Pending)
+	require.Equal(map[ids.ID]int{blkID1: 1}, poll1.Votes)
+
+	poll2 := outstanding[1]
+	require.Equal(uint32(2), poll2.RequestID)
+	require.Empty(poll2.Responses)
+	require.Equal([]ids.NodeID{vdr1, vdr2, vdr3}, poll2.Pending)
+	require.Empty(s.Recent(MaxRecentPolls))
+
+	require.Len(s.Vote(1, vdr1, blkID1), 1)
+	require.Empty(s.Vote(2, vdr1, blkID2))
+	require.Len(s.Vote(2, vdr2, blkID2), 1)
+	require.Empty(s.Outstanding())
+
+	// Recent polls are returned from newest to oldest.
+	recent := s.Recent(MaxRecentPolls)
+	require.Len(recent, 2)
+	require.Equal(uint32(2), recent[0].RequestID)
+	require.True(recent[0].Finished)
+	require.Equal(map[ids.ID]int{blkID2: 2}, recent[0].Votes)
+	require.Equal([]ids.NodeID{vdr3}, recent[0].Pending)
+	require.Equal(uint32(1), recent[1].RequestID)
+	require.Empty(recent[1].Pending)
+
+	recent = s.Recent(1)
+	require.Len(recent, 1)
+	require.Equal(uint32(2), recent[0].RequestID)
+}
+
+func TestSetProcessFinished(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+	alphaPreference := 2
+	alphaConfidence := 3
+	confidenceTimeout := 10 * time.Millisecond
+
+	factory, err := NewEarlyTermLatencyAwareFactory(alphaPreference, alphaConfidence, confidenceTimeout, prometheus.NewRegistry())
+	require.NoError(err)
+	log := logging.NoLog{}
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr1, blkID1))
+	require.Empty(s.Vote(0, vdr2, blkID1))
+	require.Empty(s.ProcessFinished())
+	require.Equal(1, s.Len())
+
+	time.Sleep(2 * confidenceTimeout)
+	results := s.ProcessFinished()
+	require.Len(results, 1)
+	require.Equal(2, results[0].Count(blkID1))
+	require.Zero(s.Len())
+
+	recent := s.Recent(1)
+	require.Len(recent, 1)
+	require.True(recent[0].Finished)
+	require.Equal([]ids.NodeID{vdr3}, recent[0].Pending)
+}
+
+type finishedCountingPoll struct {
+	Poll
+	numFinished *int
+}
+
+func (p *finishedCountingPoll) Finished() bool {
+	*p.numFinished++
+	return p.Poll.Finished()
+}
+
+type finishedCountingFactory struct {
+	Factory
+	numFinished int
+}
+
+func (f *finishedCountingFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	return &finishedCountingPoll{
+		Poll:        f.Factory.New(vdrs),
+		numFinished: &f.numFinished,
+	}
+}
+
+func TestSetOutstandingDoesNotFinishPolls(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2) // k = 2
+	alpha := 2
+
+	factory := &finishedCountingFactory{
+		Factory: newEarlyTermNoTraversalTestFactory(require, alpha),
+	}
+	log := logging.NoLog{}
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr1, blkID1))
+	numFinished := factory.numFinished
+
+	outstanding := s.Outstanding()
+	require.Len(outstanding, 1)
+	require.False(outstanding[0].Finished)
+	require.Equal(numFinished, factory.numFinished)
+}
+
 func TestSetString(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// EarlyTermNoTraversal polls finish as soon as the remaining validators
+	// can't change the result of the poll. This is the default strategy.
+	EarlyTermNoTraversal Strategy = "earlyTermNoTraversal"
+	// EarlyTermLatencyAware polls additionally stop waiting for an
+	// alphaConfidence majority after ConfidenceTimeout.
+	EarlyTermLatencyAware Strategy = "earlyTermLatencyAware"
+)
+
+var (
+	errUnknownStrategy              = errors.New("unknown poll strategy")
+	errNonPositiveConfidenceTimeout = errors.New("confidence timeout must be positive")
+)
+
+// Strategy determines when polls finish and how their votes are tallied.
+type Strategy string
+
+// StrategyConfig selects the Strategy used to construct polls.
+type StrategyConfig struct {
+	// Strategy defaults to EarlyTermNoTraversal if empty.
+	Strategy Strategy `json:"strategy" yaml:"strategy"`
+	// ConfidenceTimeout is only used by EarlyTermLatencyAware.
+	ConfidenceTimeout time.Duration `json:"confidenceTimeout" yaml:"confidenceTimeout"`
+}
+
+func (c *StrategyConfig) Verify() error {
+	switch c.Strategy {
+	case "", EarlyTermNoTraversal:
+		return nil
+	case EarlyTermLatencyAware:
+		if c.ConfidenceTimeout <= 0 {
+			return errNonPositiveConfidenceTimeout
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownStrategy, c.Strategy)
+	}
+}
+
+// NewFactory returns a factory that returns polls using the configured
+// strategy.
+func NewFactory(
+	config StrategyConfig,
+	alphaPreference int,
+	alphaConfidence int,
+	reg prometheus.Registerer,
+) (Factory, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	switch config.Strategy {
+	case EarlyTermLatencyAware:
+		return NewEarlyTermLatencyAwareFactory(
+			alphaPreference,
+			alphaConfidence,
+			config.ConfidenceTimeout,
+			reg,
+		)
+	default:
+		return NewEarlyTermNoTraversalFactory(
+			alphaPreference,
+			alphaConfidence,
+			reg,
+		)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategyConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      StrategyConfig
+		expectedErr error
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "early term no traversal",
+			config: StrategyConfig{
+				Strategy: EarlyTermNoTraversal,
+			},
+		},
+		{
+			name: "early term latency aware",
+			config: StrategyConfig{
+				Strategy:          EarlyTermLatencyAware,
+				ConfidenceTimeout: time.Second,
+			},
+		},
+		{
+			name: "early term latency aware without timeout",
+			config: StrategyConfig{
+				Strategy: EarlyTermLatencyAware,
+			},
+			expectedErr: errNonPositiveConfidenceTimeout,
+		},
+		{
+			name: "unknown",
+			config: StrategyConfig{
+				Strategy: "weighted",
+			},
+			expectedErr: errUnknownStrategy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewFactory(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       StrategyConfig
+		expectedType Factory
+	}{
+		{
+			name:         "default",
+			expectedType: &earlyTermNoTraversalFactory{},
+		},
+		{
+			name: "early term latency aware",
+			config: StrategyConfig{
+				Strategy:          EarlyTermLatencyAware,
+				ConfidenceTimeout: time.Second,
+			},
+			expectedType: &earlyTermLatencyAwareFactory{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			factory, err := NewFactory(test.config, 1, 1, prometheus.NewRegistry())
+			require.NoError(err)
+			require.IsType(test.expectedType, factory)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import "github.com/f01c5700/avalanchego/ids"
+
+// State describes the blocks that are currently in consensus.
+type State struct {
+	LastAcceptedID     ids.ID `json:"lastAcceptedID"`
+	LastAcceptedHeight uint64 `json:"lastAcceptedHeight"`
+	Preference         ids.ID `json:"preference"`
+	// NumPolls is the number of polls that have been applied.
+	NumPolls uint64 `json:"numPolls"`
+	// Blocks contains the last accepted block followed by the processing
+	// blocks, ordered by height.
+	Blocks []BlockState `json:"blocks"`
+}
+
+// BlockState describes a block that is in consensus.
+type BlockState struct {
+	ID ids.ID `json:"id"`
+	// ParentID is empty for the last accepted block if it was provided during
+	// initialization.
+	ParentID   ids.ID `json:"parentID"`
+	Height     uint64 `json:"height"`
+	Processing bool   `json:"processing"`
+	Preferred  bool   `json:"preferred"`
+	// ShouldFalter is true if the next poll applied to this block's children
+	// should reset their confidence.
+	ShouldFalter bool `json:"shouldFalter"`
+	// Children is the snowball instance deciding between the children of this
+	// block. It is nil if the block has no children.
+	Children *SnowballState `json:"children,omitempty"`
+}
+
+// SnowballState describes a snowball instance.
+type SnowballState struct {
+	Preference ids.ID `json:"preference"`
+	Finalized  bool   `json:"finalized"`
+	// Confidence describes the preference strengths and confidence counters
+	// of the instance.
+	Confidence string `json:"confidence"`
+}
@@ -4,9 +4,11 @@
 package snowman
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -213,6 +215,45 @@ func (ts *Topological) PreferenceAtHeight(height uint64) (ids.ID, bool) {
 	return blkID, ok
 }
 
+func (ts *Topological) Inspect() State {
+	blocks := make([]BlockState, 0, len(ts.blocks))
+	for blkID, n := range ts.blocks {
+		blockState := BlockState{
+			ID:           blkID,
+			Height:       ts.lastAcceptedHeight,
+			Processing:   blkID != ts.lastAcceptedID,
+			Preferred:    ts.IsPreferred(blkID),
+			ShouldFalter: n.shouldFalter,
+		}
+		if n.blk != nil {
+			blockState.ParentID = n.blk.Parent()
+			blockState.Height = n.blk.Height()
+		}
+		if n.sb != nil {
+			blockState.Children = &SnowballState{
+				Preference: n.sb.Preference(),
+				Finalized:  n.sb.Finalized(),
+				Confidence: n.sb.String(),
+			}
+		}
+		blocks = append(blocks, blockState)
+	}
+	slices.SortFunc(blocks, func(a, b BlockState) int {
+		if a.Height != b.Height {
+			return cmp.Compare(a.Height, b.Height)
+		}
+		return a.ID.Compare(b.ID)
+	})
+
+	return State{
+		LastAcceptedID:     ts.lastAcceptedID,
+		LastAcceptedHeight: ts.lastAcceptedHeight,
+		Preference:         ts.preference,
+		NumPolls:           ts.pollNumber,
+		Blocks:             blocks,
+	}
+}
+
 // The votes bag contains at most K votes for blocks in the tree. If there is a
 // vote for a block that isn't in the tree, the vote is dropped.
 //
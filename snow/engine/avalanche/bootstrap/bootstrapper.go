@@ -418,7 +418,7 @@ func (b *bootstrapper) fetch(ctx context.Context, vtxIDs ...ids.ID) error {
 			continue
 		}
 
-		nodeID, ok := b.PeerTracker.SelectPeer()
+		nodeID, ok := b.PeerTracker.SelectPeerWithStrategy(b.PeerSelectionStrategy)
 		if !ok {
 			// If we aren't connected to any peers, we send a request to ourself
 			// which is guaranteed to fail. We send this message to use the
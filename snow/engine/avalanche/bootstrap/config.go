@@ -23,6 +23,8 @@ type Config struct {
 
 	// PeerTracker manages the set of nodes that we fetch the next block from.
 	PeerTracker *p2p.PeerTracker
+	// PeerSelectionStrategy is used to select the peers from PeerTracker.
+	PeerSelectionStrategy p2p.PeerSelectionStrategy
 
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import "context"
+
+// ParallelParsingChainVM defines the interface a ChainVM can optionally
+// implement to allow blocks to be parsed and pre-verified concurrently while
+// bootstrapping.
+type ParallelParsingChainVM interface {
+	// ParallelParsingEnabled returns true if ParseBlock is safe to call
+	// concurrently with itself, with the other methods of the VM, and with the
+	// methods of previously parsed blocks. If the parsed blocks implement
+	// WithPreVerify, PreVerify must be safe to call under the same conditions.
+	//
+	// If enabled, blocks will be parsed and pre-verified ahead of their
+	// execution. Blocks will still be verified and accepted sequentially in
+	// order of increasing height. This allows any verification that doesn't
+	// depend on the block's ancestors, such as recovering signatures, to be
+	// performed in parallel.
+	//
+	// If ParallelParsingChainVM is not implemented, as it may happen with a
+	// wrapper VM, ParallelParsingEnabled should return false, nil
+	ParallelParsingEnabled(context.Context) (bool, error)
+}
+
+// WithPreVerify defines the interface a Block can optionally implement to
+// perform the verification that doesn't depend on its ancestors ahead of
+// Verify.
+//
+// PreVerify is only called while bootstrapping by VMs that enable parallel
+// parsing. It may be called before the block's parent has been verified.
+type WithPreVerify interface {
+	// PreVerify returns an error if the block is invalid regardless of the
+	// state of its ancestors.
+	//
+	// A nil error doesn't imply that the block is valid, and Verify must still
+	// be called. Verify may use the results of PreVerify to avoid repeating
+	// work.
+	PreVerify(context.Context) error
+}
@@ -107,6 +107,15 @@ type Bootstrapper struct {
 	tree            *interval.Tree
 	missingBlockIDs set.Set[ids.ID]
 
+	// checkpoint, if non-nil, is verified with the beacons in place of their
+	// accepted frontier. It is only used by the first bootstrapping attempt.
+	checkpoint *Checkpoint
+	// frontier is the set of blocks that the beacons reported as accepted.
+	// Once fetched, the highest of these blocks is persisted as a checkpoint.
+	frontier set.Set[ids.ID]
+	// checkpointHeight is the height of the persisted checkpoint.
+	checkpointHeight uint64
+
 	// bootstrappedOnce ensures that the [Bootstrapped] callback is only invoked
 	// once, even if bootstrapping is retried.
 	bootstrappedOnce sync.Once
@@ -186,9 +195,43 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 		return fmt.Errorf("failed to initialize missing block IDs: %w", err)
 	}
 
+	b.checkpoint, err = b.selectCheckpoint(lastAcceptedHeight)
+	if err != nil {
+		return fmt.Errorf("failed to select checkpoint: %w", err)
+	}
+
 	return b.tryStartBootstrapping(ctx)
 }
 
+// selectCheckpoint returns the highest of the trusted checkpoint and the
+// checkpoint persisted by a previous run. Checkpoints that aren't above
+// [lastAcceptedHeight] are ignored.
+func (b *Bootstrapper) selectCheckpoint(lastAcceptedHeight uint64) (*Checkpoint, error) {
+	var selected *Checkpoint
+	if b.TrustedCheckpoint != nil && b.TrustedCheckpoint.Height > lastAcceptedHeight {
+		selected = b.TrustedCheckpoint
+	}
+
+	persisted, err := getCheckpoint(b.DB, b.Ctx.Context)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return selected, nil
+	case errors.Is(err, errInvalidCheckpointLength) || errors.Is(err, errInvalidCheckpointSignature):
+		b.Ctx.Log.Warn("ignoring persisted checkpoint",
+			zap.Error(err),
+		)
+		return selected, nil
+	case err != nil:
+		return nil, err
+	}
+
+	b.checkpointHeight = persisted.Height
+	if persisted.Height > lastAcceptedHeight && (selected == nil || persisted.Height > selected.Height) {
+		selected = &persisted
+	}
+	return selected, nil
+}
+
 func (b *Bootstrapper) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
 	if err := b.VM.Connected(ctx, nodeID, nodeVersion); err != nil {
 		return err
@@ -247,6 +290,14 @@ func (b *Bootstrapper) startBootstrapping(ctx context.Context) error {
 		maxOutstandingBroadcastRequests,
 	)
 
+	if b.checkpoint != nil {
+		b.Ctx.Log.Info("verifying checkpoint with beacons",
+			zap.Stringer("blkID", b.checkpoint.BlockID),
+			zap.Uint64("height", b.checkpoint.Height),
+		)
+		b.minority = bootstrapper.NewTrusted(b.checkpoint.BlockID)
+	}
+
 	if accepted, finalized := b.majority.Result(ctx); finalized {
 		b.Ctx.Log.Info("bootstrapping skipped",
 			zap.String("reason", "no provided bootstraps"),
@@ -284,6 +335,15 @@ func (b *Bootstrapper) sendBootstrappingMessagesOrFinish(ctx context.Context) er
 	}
 
 	numAccepted := len(accepted)
+	if b.checkpoint != nil {
+		if numAccepted == 0 {
+			b.Ctx.Log.Warn("checkpoint wasn't accepted by a majority of the beacons",
+				zap.Stringer("blkID", b.checkpoint.BlockID),
+				zap.Uint64("height", b.checkpoint.Height),
+			)
+		}
+		b.checkpoint = nil
+	}
 	if numAccepted == 0 {
 		b.Ctx.Log.Debug("restarting bootstrap",
 			zap.String("reason", "no blocks accepted"),
@@ -366,6 +426,7 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedBlockIDs []ids.
 	knownBlockIDs := genesis.GetCheckpoints(b.Ctx.NetworkID, b.Ctx.ChainID)
 	b.missingBlockIDs.Union(knownBlockIDs)
 	b.missingBlockIDs.Add(acceptedBlockIDs...)
+	b.frontier = set.Of(acceptedBlockIDs...)
 	numMissingBlockIDs := b.missingBlockIDs.Len()
 
 	log := b.Ctx.Log.Info
@@ -412,7 +473,7 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 		return nil
 	}
 
-	nodeID, ok := b.PeerTracker.SelectPeer()
+	nodeID, ok := b.PeerTracker.SelectPeerWithStrategy(b.PeerSelectionStrategy)
 	if !ok {
 		// If we aren't connected to any peers, we send a request to ourself
 		// which is guaranteed to fail. We send this message to use the message
@@ -615,6 +676,10 @@ func (b *Bootstrapper) process(
 		}
 	}
 
+	if err := b.tryCheckpoint(batch, blk, lastAccepted.Height()); err != nil {
+		return err
+	}
+
 	if err := batch.Write(); err != nil || !foundNewMissingID {
 		return err
 	}
@@ -624,6 +689,50 @@ func (b *Bootstrapper) process(
 	return b.fetch(ctx, missingBlockID)
 }
 
+// tryCheckpoint persists [blk] as the checkpoint if the beacons reported it as
+// accepted and it is higher than both the last accepted block and the current
+// checkpoint.
+func (b *Bootstrapper) tryCheckpoint(db database.KeyValueWriter, blk snowman.Block, lastAcceptedHeight uint64) error {
+	// Checkpoints can only be persisted if this node is able to sign them.
+	if b.Ctx.WarpSigner == nil {
+		return nil
+	}
+
+	height := blk.Height()
+	if !b.frontier.Contains(blk.ID()) || height <= lastAcceptedHeight || height <= b.checkpointHeight {
+		return nil
+	}
+
+	err := putCheckpoint(db, b.Ctx.Context, Checkpoint{
+		Height:  height,
+		BlockID: blk.ID(),
+	})
+	if err != nil {
+		return err
+	}
+	b.checkpointHeight = height
+	return nil
+}
+
+// numParsers returns the number of goroutines that should parse and pre-verify
+// blocks while executing.
+func (b *Bootstrapper) numParsers(ctx context.Context) (int, error) {
+	if b.ParseWorkers <= 1 {
+		return 1, nil
+	}
+
+	vm, ok := b.VM.(block.ParallelParsingChainVM)
+	if !ok {
+		return 1, nil
+	}
+
+	enabled, err := vm.ParallelParsingEnabled(ctx)
+	if err != nil || !enabled {
+		return 1, err
+	}
+	return b.ParseWorkers, nil
+}
+
 // tryStartExecuting executes all pending blocks if there are no more blocks
 // being fetched. After executing all pending blocks it will either restart
 // bootstrapping, or transition into normal operations.
@@ -646,6 +755,11 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 		log = b.Ctx.Log.Debug
 	}
 
+	numParsers, err := b.numParsers(ctx)
+	if err != nil {
+		return err
+	}
+
 	numToExecute := b.tree.Len()
 	err = execute(
 		ctx,
@@ -657,6 +771,7 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 			ctx:         b.Ctx,
 			numAccepted: b.numAccepted,
 		},
+		numParsers,
 		b.tree,
 		lastAccepted.Height(),
 	)
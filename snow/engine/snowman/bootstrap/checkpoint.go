@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/bootstrap/interval"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/wrappers"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+const checkpointPayloadLen = wrappers.LongLen + ids.IDLen
+
+var (
+	errInvalidCheckpointLength    = errors.New("invalid checkpoint length")
+	errInvalidCheckpointSignature = errors.New("invalid checkpoint signature")
+)
+
+// Checkpoint is a block that is expected to be accepted by the network.
+// Bootstrapping can sync to a checkpoint without first polling peers for their
+// accepted frontier, but still requires a majority of the beacons to report the
+// checkpoint as accepted.
+type Checkpoint struct {
+	Height  uint64 `json:"height"`
+	BlockID ids.ID `json:"blockID"`
+}
+
+func (c Checkpoint) payload() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, checkpointPayloadLen),
+	}
+	p.PackLong(c.Height)
+	p.PackFixedBytes(c.BlockID[:])
+	return p.Bytes
+}
+
+// checkpointMessage returns the message that is signed to authenticate [c].
+func checkpointMessage(ctx *snow.Context, c Checkpoint) (*warp.UnsignedMessage, error) {
+	return warp.NewUnsignedMessage(ctx.NetworkID, ctx.ChainID, c.payload())
+}
+
+// putCheckpoint writes [c] along with this node's signature over it.
+func putCheckpoint(db database.KeyValueWriter, ctx *snow.Context, c Checkpoint) error {
+	msg, err := checkpointMessage(ctx, c)
+	if err != nil {
+		return err
+	}
+	sig, err := ctx.WarpSigner.Sign(msg)
+	if err != nil {
+		return fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+	return interval.PutCheckpoint(db, append(c.payload(), sig...))
+}
+
+// getCheckpoint returns the checkpoint previously written by putCheckpoint.
+// Returns database.ErrNotFound if there isn't a checkpoint and
+// errInvalidCheckpointSignature if the checkpoint wasn't signed by this node.
+func getCheckpoint(db database.KeyValueReader, ctx *snow.Context) (Checkpoint, error) {
+	checkpointBytes, err := interval.GetCheckpoint(db)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if len(checkpointBytes) != checkpointPayloadLen+bls.SignatureLen {
+		return Checkpoint{}, fmt.Errorf("%w: %d", errInvalidCheckpointLength, len(checkpointBytes))
+	}
+
+	p := wrappers.Packer{
+		Bytes: checkpointBytes,
+	}
+	c := Checkpoint{
+		Height: p.UnpackLong(),
+	}
+	copy(c.BlockID[:], p.UnpackFixedBytes(ids.IDLen))
+	if p.Err != nil {
+		return Checkpoint{}, p.Err
+	}
+
+	sig, err := bls.SignatureFromBytes(checkpointBytes[checkpointPayloadLen:])
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("%w: %w", errInvalidCheckpointSignature, err)
+	}
+	msg, err := checkpointMessage(ctx, c)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if ctx.PublicKey == nil || !bls.Verify(ctx.PublicKey, sig, msg.Bytes()) {
+		return Checkpoint{}, errInvalidCheckpointSignature
+	}
+	return c, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/database/memdb"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/snowmantest"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/bootstrap/interval"
+	"github.com/f01c5700/avalanchego/snow/snowtest"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+func newSigningContext(t *testing.T) *snow.Context {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	ctx.PublicKey = bls.PublicFromSecretKey(sk)
+	ctx.WarpSigner = warp.NewSigner(sk, ctx.NetworkID, ctx.ChainID)
+	return ctx
+}
+
+func TestCheckpointPersistence(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db         = memdb.New()
+		ctx        = newSigningContext(t)
+		checkpoint = Checkpoint{
+			Height:  10,
+			BlockID: ids.GenerateTestID(),
+		}
+	)
+
+	_, err := getCheckpoint(db, ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(putCheckpoint(db, ctx, checkpoint))
+
+	persisted, err := getCheckpoint(db, ctx)
+	require.NoError(err)
+	require.Equal(checkpoint, persisted)
+
+	// A checkpoint signed by another node must not be trusted.
+	_, err = getCheckpoint(db, newSigningContext(t))
+	require.ErrorIs(err, errInvalidCheckpointSignature)
+
+	require.NoError(interval.PutCheckpoint(db, checkpoint.payload()))
+	_, err = getCheckpoint(db, ctx)
+	require.ErrorIs(err, errInvalidCheckpointLength)
+}
+
+func TestBootstrapperTrustedCheckpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted bool
+	}{
+		{
+			name:     "accepted by beacons",
+			accepted: true,
+		},
+		{
+			name:     "rejected by beacons",
+			accepted: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config, peerID, sender, vm := newConfig(t)
+
+			blks := snowmantest.BuildChain(4)
+			initializeVMWithBlockchain(vm, blks)
+
+			config.TrustedCheckpoint = &Checkpoint{
+				Height:  blks[3].Height(),
+				BlockID: blks[3].ID(),
+			}
+
+			bs, err := New(config, nil)
+			require.NoError(err)
+
+			// The accepted frontier shouldn't be requested when there is a
+			// checkpoint.
+			sender.CantSendGetAcceptedFrontier = true
+			var requestID uint32
+			sender.SendGetAcceptedF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], reqID uint32, blkIDs []ids.ID) {
+				require.Equal(set.Of(peerID), nodeIDs)
+				require.Equal([]ids.ID{blks[3].ID()}, blkIDs)
+				requestID = reqID
+			}
+			require.NoError(bs.Start(context.Background(), 0))
+			require.NotZero(requestID)
+
+			if !test.accepted {
+				var frontierRequested bool
+				sender.SendGetAcceptedFrontierF = func(context.Context, set.Set[ids.NodeID], uint32) {
+					frontierRequested = true
+				}
+				require.NoError(bs.Accepted(context.Background(), peerID, requestID, nil))
+				require.True(frontierRequested)
+				require.Nil(bs.checkpoint)
+				return
+			}
+
+			var requested ids.ID
+			sender.SendGetAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, blkID ids.ID) {
+				requested = blkID
+			}
+			require.NoError(bs.Accepted(context.Background(), peerID, requestID, set.Of(blks[3].ID())))
+			require.Equal(blks[3].ID(), requested)
+			require.Nil(bs.checkpoint)
+		})
+	}
+}
+
+func TestBootstrapperResumesFromPersistedCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+	config.Ctx.Context = newSigningContext(t)
+
+	blks := snowmantest.BuildChain(4)
+	initializeVMWithBlockchain(vm, blks)
+
+	bs, err := New(config, nil)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var requestID uint32
+	sender.SendGetAncestorsF = func(_ context.Context, _ ids.NodeID, reqID uint32, _ ids.ID) {
+		requestID = reqID
+	}
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[3:4])))
+
+	// Fetching the accepted frontier persists it as the checkpoint.
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[2:4])))
+	checkpoint, err := getCheckpoint(config.DB, config.Ctx.Context)
+	require.NoError(err)
+	require.Equal(
+		Checkpoint{
+			Height:  blks[3].Height(),
+			BlockID: blks[3].ID(),
+		},
+		checkpoint,
+	)
+
+	// Restarting bootstrapping before the remaining blocks are fetched should
+	// skip polling for the accepted frontier.
+	config.Ctx.Registerer = prometheus.NewRegistry()
+	bs, err = New(config, nil)
+	require.NoError(err)
+
+	sender.CantSendGetAcceptedFrontier = true
+	var verified []ids.ID
+	sender.SendGetAcceptedF = func(_ context.Context, _ set.Set[ids.NodeID], _ uint32, blkIDs []ids.ID) {
+		verified = blkIDs
+	}
+	require.NoError(bs.Start(context.Background(), 0))
+	require.Equal([]ids.ID{blks[3].ID()}, verified)
+}
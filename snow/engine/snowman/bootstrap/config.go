@@ -27,6 +27,8 @@ type Config struct {
 
 	// PeerTracker manages the set of nodes that we fetch the next block from.
 	PeerTracker *p2p.PeerTracker
+	// PeerSelectionStrategy is used to select the peers from PeerTracker.
+	PeerSelectionStrategy p2p.PeerSelectionStrategy
 
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
@@ -41,5 +43,16 @@ type Config struct {
 	// NonVerifyingParse parses blocks without verifying them.
 	NonVerifyingParse block.ParseFunc
 
+	// ParseWorkers is the number of goroutines that parse and pre-verify
+	// blocks ahead of their execution. Blocks are only parsed and pre-verified
+	// in parallel if ParseWorkers is greater than 1 and VM enables parallel
+	// parsing.
+	ParseWorkers int
+
+	// TrustedCheckpoint, if non-nil and above the last accepted block, is
+	// synced to without polling peers for their accepted frontier. It must
+	// still be reported as accepted by a majority of the beacons.
+	TrustedCheckpoint *Checkpoint
+
 	Bootstrapped func()
 }
@@ -12,6 +12,7 @@ import (
 const (
 	intervalPrefixByte byte = iota
 	blockPrefixByte
+	checkpointPrefixByte
 
 	prefixLen = 1
 )
@@ -19,6 +20,7 @@ const (
 var (
 	intervalPrefix = []byte{intervalPrefixByte}
 	blockPrefix    = []byte{blockPrefixByte}
+	checkpointKey  = []byte{checkpointPrefixByte}
 
 	errInvalidKeyLength = errors.New("invalid key length")
 )
@@ -107,3 +109,13 @@ func makeBlockKey(height uint64) []byte {
 	blockKey := database.PackUInt64(height)
 	return append(blockPrefix, blockKey...)
 }
+
+// GetCheckpoint returns the checkpoint of bootstrapping progress, or
+// database.ErrNotFound if one hasn't been written.
+func GetCheckpoint(db database.KeyValueReader) ([]byte, error) {
+	return db.Get(checkpointKey)
+}
+
+func PutCheckpoint(db database.KeyValueWriter, checkpoint []byte) error {
+	return db.Put(checkpointKey, checkpoint)
+}
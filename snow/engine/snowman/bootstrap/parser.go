@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/block"
+	"github.com/f01c5700/avalanchego/utils/buffer"
+)
+
+// parseAheadPerWorker is the number of blocks that may be queued for each
+// worker.
+const parseAheadPerWorker = 4
+
+type parseJob struct {
+	bytes []byte
+	done  chan struct{}
+
+	blk snowman.Block
+	err error
+}
+
+// orderedParser parses and pre-verifies blocks ahead of their execution. Blocks
+// are returned by Pop in the order they were pushed.
+//
+// If there is only a single worker, blocks are parsed when they are popped and
+// aren't pre-verified.
+type orderedParser struct {
+	ctx        context.Context
+	parser     block.Parser
+	numWorkers int
+
+	pending buffer.Deque[*parseJob]
+	jobs    chan *parseJob
+	workers sync.WaitGroup
+}
+
+func newOrderedParser(ctx context.Context, parser block.Parser, numWorkers int) *orderedParser {
+	p := &orderedParser{
+		ctx:        ctx,
+		parser:     parser,
+		numWorkers: max(numWorkers, 1),
+	}
+	p.pending = buffer.NewUnboundedDeque[*parseJob](p.Capacity())
+	if p.numWorkers == 1 {
+		return p
+	}
+
+	p.jobs = make(chan *parseJob, p.Capacity())
+	p.workers.Add(p.numWorkers)
+	for i := 0; i < p.numWorkers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Capacity returns the maximum number of blocks that should be pending at
+// once.
+func (p *orderedParser) Capacity() int {
+	if p.numWorkers == 1 {
+		return 1
+	}
+	return p.numWorkers * parseAheadPerWorker
+}
+
+// Len returns the number of blocks that have been pushed but not popped.
+func (p *orderedParser) Len() int {
+	return p.pending.Len()
+}
+
+// Push queues [bytes] to be parsed. [bytes] must not be modified after being
+// pushed. Push must not be called when there are already Capacity blocks
+// pending.
+func (p *orderedParser) Push(bytes []byte) {
+	job := &parseJob{
+		bytes: bytes,
+		done:  make(chan struct{}),
+	}
+	p.pending.PushRight(job)
+	if p.jobs != nil {
+		p.jobs <- job
+	}
+}
+
+// Pop returns the result of parsing the oldest pending block. Pop must only be
+// called when Len is non-zero.
+func (p *orderedParser) Pop() (snowman.Block, error) {
+	job, _ := p.pending.PopLeft()
+	if p.jobs == nil {
+		return p.parser.ParseBlock(p.ctx, job.bytes)
+	}
+
+	<-job.done
+	return job.blk, job.err
+}
+
+// Close stops the workers after they have finished parsing the pending
+// blocks.
+func (p *orderedParser) Close() {
+	if p.jobs == nil {
+		return
+	}
+
+	close(p.jobs)
+	p.workers.Wait()
+}
+
+func (p *orderedParser) work() {
+	defer p.workers.Done()
+
+	for job := range p.jobs {
+		job.blk, job.err = p.parse(job.bytes)
+		close(job.done)
+	}
+}
+
+// parse parses [bytes] and pre-verifies the resulting block.
+func (p *orderedParser) parse(bytes []byte) (snowman.Block, error) {
+	blk, err := p.parser.ParseBlock(p.ctx, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	preVerifiable, ok := blk.(block.WithPreVerify)
+	if !ok {
+		return blk, nil
+	}
+	if err := preVerifiable.PreVerify(p.ctx); err != nil {
+		return nil, fmt.Errorf("failed to pre-verify block %s (height=%d, parentID=%s) in bootstrapping: %w",
+			blk.ID(),
+			blk.Height(),
+			blk.Parent(),
+			err,
+		)
+	}
+	return blk, nil
+}
@@ -6,6 +6,7 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -124,6 +125,11 @@ func process(
 //
 // execute assumes that getMissingBlockIDs would return an empty set.
 //
+// Blocks are parsed by [numParsers] workers ahead of their execution. If
+// [numParsers] is greater than 1, the parsed blocks are also pre-verified, and
+// both parsing and pre-verification must be safe to perform concurrently with
+// the verification and acceptance of previously parsed blocks.
+//
 // TODO: Replace usage of haltable with context cancellation.
 func execute(
 	ctx context.Context,
@@ -131,6 +137,7 @@ func execute(
 	log logging.Func,
 	db database.Database,
 	nonVerifyingParser block.Parser,
+	numParsers int,
 	tree *interval.Tree,
 	lastAcceptedHeight uint64,
 ) error {
@@ -161,6 +168,7 @@ func execute(
 			return nil
 		}
 
+		parser                        = newOrderedParser(ctx, nonVerifyingParser, numParsers)
 		iterator                      = interval.GetBlockIterator(db)
 		processedSinceIteratorRelease uint
 
@@ -168,6 +176,7 @@ func execute(
 		timeOfNextLog = startTime.Add(logPeriod)
 	)
 	defer func() {
+		parser.Close()
 		iterator.Release()
 
 		var (
@@ -196,9 +205,21 @@ func execute(
 		zap.Uint64("numToExecute", totalNumberToProcess),
 	)
 
-	for !haltable.Halted() && iterator.Next() {
-		blkBytes := iterator.Value()
-		blk, err := nonVerifyingParser.ParseBlock(ctx, blkBytes)
+	for !haltable.Halted() {
+		// Read ahead to keep the parsers busy. Blocks aren't read past the
+		// next iterator release so that the iterator can be restarted after
+		// the last read block.
+		for parser.Len() < parser.Capacity() &&
+			processedSinceIteratorRelease+uint(parser.Len()) < iteratorReleasePeriod &&
+			iterator.Next() {
+			// The iterator may reuse the value's memory after Next is called.
+			parser.Push(slices.Clone(iterator.Value()))
+		}
+		if parser.Len() == 0 {
+			break
+		}
+
+		blk, err := parser.Pop()
 		if err != nil {
 			return err
 		}
@@ -6,6 +6,10 @@ package bootstrap
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,14 +17,23 @@ import (
 	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/database/memdb"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/f01c5700/avalanchego/snow/engine/common"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/block"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/bootstrap/interval"
 	"github.com/f01c5700/avalanchego/snow/snowtest"
+	"github.com/f01c5700/avalanchego/utils/crypto/secp256k1"
+	"github.com/f01c5700/avalanchego/utils/hashing"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/chain"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/genesis"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/tx"
+
+	xsblock "github.com/f01c5700/avalanchego/vms/example/xsvm/block"
+	xsexecute "github.com/f01c5700/avalanchego/vms/example/xsvm/execute"
 )
 
 var _ block.Parser = testParser(nil)
@@ -226,6 +239,7 @@ func TestExecute(t *testing.T) {
 	tests := []struct {
 		name                      string
 		haltable                  common.Haltable
+		numParsers                int
 		lastAcceptedHeight        uint64
 		expectedProcessingHeights []uint64
 		expectedAcceptedHeights   []uint64
@@ -233,6 +247,7 @@ func TestExecute(t *testing.T) {
 		{
 			name:                      "execute everything",
 			haltable:                  unhalted,
+			numParsers:                1,
 			lastAcceptedHeight:        0,
 			expectedProcessingHeights: nil,
 			expectedAcceptedHeights:   []uint64{0, 1, 2, 3, 4, 5, 6},
@@ -240,6 +255,7 @@ func TestExecute(t *testing.T) {
 		{
 			name:                      "do not execute blocks accepted by height",
 			haltable:                  unhalted,
+			numParsers:                1,
 			lastAcceptedHeight:        3,
 			expectedProcessingHeights: []uint64{1, 2, 3},
 			expectedAcceptedHeights:   []uint64{0, 4, 5, 6},
@@ -247,10 +263,27 @@ func TestExecute(t *testing.T) {
 		{
 			name:                      "do not execute blocks when halted",
 			haltable:                  halted,
+			numParsers:                1,
 			lastAcceptedHeight:        0,
 			expectedProcessingHeights: []uint64{1, 2, 3, 4, 5, 6},
 			expectedAcceptedHeights:   []uint64{0},
 		},
+		{
+			name:                      "execute everything with parallel parsing",
+			haltable:                  unhalted,
+			numParsers:                4,
+			lastAcceptedHeight:        0,
+			expectedProcessingHeights: nil,
+			expectedAcceptedHeights:   []uint64{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:                      "do not execute blocks accepted by height with parallel parsing",
+			haltable:                  unhalted,
+			numParsers:                4,
+			lastAcceptedHeight:        3,
+			expectedProcessingHeights: []uint64{1, 2, 3},
+			expectedAcceptedHeights:   []uint64{0, 4, 5, 6},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -273,6 +306,7 @@ func TestExecute(t *testing.T) {
 				logging.NoLog{}.Info,
 				db,
 				parser,
+				test.numParsers,
 				tree,
 				test.lastAcceptedHeight,
 			))
@@ -294,6 +328,290 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteParallelAcrossIteratorReleases(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	tree, err := interval.NewTree(db)
+	require.NoError(err)
+
+	blocks := snowmantest.BuildChain(2*iteratorReleasePeriod + 3)
+	for _, blk := range blocks {
+		_, err := interval.Add(db, tree, 0, blk.Height(), blk.Bytes())
+		require.NoError(err)
+	}
+
+	require.NoError(execute(
+		context.Background(),
+		&common.Halter{},
+		logging.NoLog{}.Info,
+		db,
+		makeIndexedParser(blocks),
+		8,
+		tree,
+		0,
+	))
+	for _, blk := range blocks {
+		require.Equal(snowtest.Accepted, blk.Status)
+	}
+	require.Zero(tree.Len())
+}
+
+func TestExecutePreVerify(t *testing.T) {
+	const numBlocks = 7
+
+	errInvalid := errors.New("invalid")
+	tests := []struct {
+		name                string
+		numParsers          int
+		invalidHeight       uint64
+		expectedErr         error
+		expectedPreVerified bool
+	}{
+		{
+			name:                "pre-verified with parallel parsing",
+			numParsers:          4,
+			expectedPreVerified: true,
+		},
+		{
+			name:                "not pre-verified without parallel parsing",
+			numParsers:          1,
+			expectedPreVerified: false,
+		},
+		{
+			name:          "invalid block",
+			numParsers:    4,
+			invalidHeight: 4,
+			expectedErr:   errInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			tree, err := interval.NewTree(db)
+			require.NoError(err)
+
+			blocks := snowmantest.BuildChain(numBlocks)
+			var (
+				lock        sync.Mutex
+				preVerified = set.Set[uint64]{}
+				parser      = makeParser(blocks)
+			)
+			preVerifyingParser := testParser(func(ctx context.Context, b []byte) (snowman.Block, error) {
+				blk, err := parser.ParseBlock(ctx, b)
+				if err != nil {
+					return nil, err
+				}
+				return &preVerifyBlock{
+					Block: blk,
+					preVerify: func(context.Context) error {
+						height := blk.Height()
+						if test.invalidHeight != 0 && height == test.invalidHeight {
+							return errInvalid
+						}
+
+						lock.Lock()
+						defer lock.Unlock()
+
+						preVerified.Add(height)
+						return nil
+					},
+				}, nil
+			})
+			for _, blk := range blocks {
+				_, err := interval.Add(db, tree, 0, blk.Height(), blk.Bytes())
+				require.NoError(err)
+			}
+
+			err = execute(
+				context.Background(),
+				&common.Halter{},
+				logging.NoLog{}.Info,
+				db,
+				preVerifyingParser,
+				test.numParsers,
+				tree,
+				0,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				require.Equal(snowtest.Accepted, blocks[test.invalidHeight-1].Status)
+				require.Equal(snowtest.Undecided, blocks[test.invalidHeight].Status)
+				return
+			}
+
+			if test.expectedPreVerified {
+				// The genesis block is already accepted, so it isn't
+				// executed.
+				require.Equal(numBlocks-1, preVerified.Len())
+			} else {
+				require.Zero(preVerified.Len())
+			}
+			for _, blk := range blocks {
+				require.Equal(snowtest.Accepted, blk.Status)
+			}
+		})
+	}
+}
+
+// BenchmarkExecute measures executing blocks whose parsing is expensive, as is
+// the case when parsing recovers signatures.
+func BenchmarkExecute(b *testing.B) {
+	const (
+		numBlocks      = 1024
+		hashesPerBlock = 512
+	)
+
+	blocks := snowmantest.BuildChain(numBlocks)
+	indexedParser := makeIndexedParser(blocks)
+	parser := testParser(func(ctx context.Context, b []byte) (snowman.Block, error) {
+		digest := b
+		for i := 0; i < hashesPerBlock; i++ {
+			digest = hashing.ComputeHash256(digest)
+		}
+		return indexedParser.ParseBlock(ctx, b)
+	})
+
+	for _, numParsers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("parsers=%d", numParsers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				db := memdb.New()
+				tree, err := interval.NewTree(db)
+				require.NoError(b, err)
+				for _, blk := range blocks {
+					blk.Status = snowtest.Undecided
+					_, err := interval.Add(db, tree, 0, blk.Height(), blk.Bytes())
+					require.NoError(b, err)
+				}
+				b.StartTimer()
+
+				require.NoError(b, execute(
+					context.Background(),
+					&common.Halter{},
+					logging.NoLog{}.Info,
+					db,
+					parser,
+					numParsers,
+					tree,
+					0,
+				))
+			}
+		})
+	}
+}
+
+// BenchmarkExecuteXSVM measures executing a range of xsvm transfer blocks
+// sequentially and with the blocks parsed and pre-verified in parallel. The
+// parallel case uses GOMAXPROCS parsers, so it should be run with -cpu set to
+// more than 1 to measure the speedup.
+func BenchmarkExecuteXSVM(b *testing.B) {
+	// The number of transactions exceeds the size of the signature cache, so
+	// the senders recovered by previous iterations are evicted.
+	const (
+		numBlocks   = 128
+		txsPerBlock = 32
+	)
+
+	var (
+		chainID = ids.GenerateTestID()
+		keys    = make([]*secp256k1.PrivateKey, txsPerBlock)
+		g       = &genesis.Genesis{
+			Allocations: make([]genesis.Allocation, txsPerBlock),
+		}
+	)
+	for i := range keys {
+		key, err := secp256k1.NewPrivateKey()
+		require.NoError(b, err)
+
+		keys[i] = key
+		g.Allocations[i] = genesis.Allocation{
+			Address: key.Address(),
+			Balance: numBlocks,
+		}
+	}
+
+	genesisBlk, err := genesis.Block(g)
+	require.NoError(b, err)
+	parentID, err := genesisBlk.ID()
+	require.NoError(b, err)
+
+	blkBytes := make([][]byte, numBlocks)
+	for i := range blkBytes {
+		txs := make([]*tx.Tx, txsPerBlock)
+		for j, key := range keys {
+			txs[j], err = tx.Sign(
+				&tx.Transfer{
+					ChainID: chainID,
+					Nonce:   uint64(i),
+					AssetID: chainID,
+					Amount:  1,
+				},
+				key,
+			)
+			require.NoError(b, err)
+		}
+
+		blk := &xsblock.Stateless{
+			ParentID: parentID,
+			Height:   uint64(i + 1),
+			Txs:      txs,
+		}
+		blkBytes[i], err = xsblock.Codec.Marshal(xsblock.CodecVersion, blk)
+		require.NoError(b, err)
+		parentID, err = blk.ID()
+		require.NoError(b, err)
+	}
+
+	for _, parallel := range []bool{false, true} {
+		numParsers := 1
+		if parallel {
+			numParsers = runtime.GOMAXPROCS(0)
+		}
+		b.Run(fmt.Sprintf("parallel=%t", parallel), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				// The chain is re-created to avoid reusing the state of a
+				// previous execution.
+				chainDB := memdb.New()
+				require.NoError(b, xsexecute.Genesis(chainDB, chainID, g))
+				c, err := chain.New(snowtest.Context(b, chainID), chainDB)
+				require.NoError(b, err)
+				c.SetChainState(snow.Bootstrapping)
+				parser := testParser(func(_ context.Context, bytes []byte) (snowman.Block, error) {
+					blk, err := xsblock.Parse(bytes)
+					if err != nil {
+						return nil, err
+					}
+					return c.NewBlock(blk)
+				})
+
+				db := memdb.New()
+				tree, err := interval.NewTree(db)
+				require.NoError(b, err)
+				for j, bytes := range blkBytes {
+					_, err := interval.Add(db, tree, 0, uint64(j+1), bytes)
+					require.NoError(b, err)
+				}
+				b.StartTimer()
+
+				require.NoError(b, execute(
+					context.Background(),
+					&common.Halter{},
+					logging.NoLog{}.Info,
+					db,
+					parser,
+					numParsers,
+					tree,
+					0,
+				))
+			}
+		})
+	}
+}
+
 type testParser func(context.Context, []byte) (snowman.Block, error)
 
 func (f testParser) ParseBlock(ctx context.Context, bytes []byte) (snowman.Block, error) {
@@ -310,3 +628,27 @@ func makeParser(blocks []*snowmantest.Block) block.Parser {
 		return nil, database.ErrNotFound
 	})
 }
+
+func makeIndexedParser(blocks []*snowmantest.Block) block.Parser {
+	blocksByBytes := make(map[string]*snowmantest.Block, len(blocks))
+	for _, blk := range blocks {
+		blocksByBytes[string(blk.Bytes())] = blk
+	}
+	return testParser(func(_ context.Context, b []byte) (snowman.Block, error) {
+		blk, ok := blocksByBytes[string(b)]
+		if !ok {
+			return nil, database.ErrNotFound
+		}
+		return blk, nil
+	})
+}
+
+type preVerifyBlock struct {
+	snowman.Block
+
+	preVerify func(context.Context) error
+}
+
+func (b *preVerifyBlock) PreVerify(ctx context.Context) error {
+	return b.preVerify(ctx)
+}
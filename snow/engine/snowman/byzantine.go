@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import "github.com/f01c5700/avalanchego/ids"
+
+const (
+	// HonestVotes votes for the preferred blocks.
+	HonestVotes VoteStrategy = iota
+	// ConflictingVotes votes for a different processing block in every Chits
+	// message, so that the peers polling this node receive conflicting votes.
+	ConflictingVotes
+	// WrongPreferenceVotes votes for a processing block that isn't preferred.
+	WrongPreferenceVotes
+)
+
+// VoteStrategy chooses the blocks that the engine votes for. Strategies other
+// than HonestVotes deviate from the protocol and must only be used to test how
+// honest nodes react to byzantine peers.
+type VoteStrategy uint8
+
+// byzantineVote returns the block to vote for in place of the preference when
+// using a byzantine vote strategy. If there is no processing block to vote for
+// instead, false is returned.
+//
+// Assumes the context lock is held.
+func (e *Engine) byzantineVote() (ids.ID, bool) {
+	var candidates []ids.ID
+	for _, blk := range e.Consensus.Inspect().Blocks {
+		if !blk.Processing {
+			continue
+		}
+		if e.Config.VoteStrategy == WrongPreferenceVotes && blk.Preferred {
+			continue
+		}
+		candidates = append(candidates, blk.ID)
+	}
+	if len(candidates) == 0 {
+		return ids.Empty, false
+	}
+
+	switch e.Config.VoteStrategy {
+	case ConflictingVotes:
+		vote := candidates[e.numByzantineVotes%len(candidates)]
+		e.numByzantineVotes++
+		return vote, true
+	default:
+		// Vote for the highest block that isn't preferred.
+		return candidates[len(candidates)-1], true
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/f01c5700/avalanchego/snow"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/snow/engine/common"
 	"github.com/f01c5700/avalanchego/snow/engine/common/tracker"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/block"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/validators"
 )
 
@@ -23,6 +25,13 @@ type Config struct {
 	Validators          validators.Manager
 	ConnectedValidators tracker.Peers
 	Params              snowball.Parameters
+	PollStrategy        poll.StrategyConfig
 	Consensus           snowman.Consensus
 	PartialSync         bool
+	// VoteStrategy chooses the blocks voted for in Chits messages. It must
+	// only be changed from HonestVotes in tests.
+	VoteStrategy VoteStrategy
+	// Events is notified of the consensus lifecycle of blocks. If nil, events
+	// are not published.
+	Events event.Publisher
 }
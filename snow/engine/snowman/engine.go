@@ -6,6 +6,7 @@ package snowman
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -20,6 +21,7 @@ import (
 	"github.com/f01c5700/avalanchego/snow/engine/common"
 	"github.com/f01c5700/avalanchego/snow/engine/common/tracker"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/ancestor"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/job"
 	"github.com/f01c5700/avalanchego/snow/validators"
 	"github.com/f01c5700/avalanchego/utils/bag"
@@ -90,6 +92,9 @@ type Engine struct {
 	// number of times build block needs to be called once the number of
 	// processing blocks has gone below the optimal number.
 	pendingBuildBlocks int
+
+	// number of Chits messages sent using ConflictingVotes.
+	numByzantineVotes int
 }
 
 func New(config Config) (*Engine, error) {
@@ -110,7 +115,8 @@ func New(config Config) (*Engine, error) {
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterSetCallbackListener(config.Ctx.SubnetID, acceptedFrontiers)
 
-	factory, err := poll.NewEarlyTermNoTraversalFactory(
+	factory, err := poll.NewFactory(
+		config.PollStrategy,
 		config.Params.AlphaPreference,
 		config.Params.AlphaConfidence,
 		config.Ctx.Registerer,
@@ -132,6 +138,10 @@ func New(config Config) (*Engine, error) {
 		return nil, err
 	}
 
+	if config.Events == nil {
+		config.Events = event.NoOpPublisher{}
+	}
+
 	return &Engine{
 		Config:                      config,
 		metrics:                     metrics,
@@ -154,6 +164,12 @@ func New(config Config) (*Engine, error) {
 }
 
 func (e *Engine) Gossip(ctx context.Context) error {
+	// Polls may finish without receiving another response, such as when they
+	// time out, so they are checked periodically.
+	if err := e.recordPollResults(ctx, e.polls.ProcessFinished()); err != nil {
+		return err
+	}
+
 	lastAcceptedID, lastAcceptedHeight := e.Consensus.LastAccepted()
 	if numProcessing := e.Consensus.NumProcessing(); numProcessing != 0 {
 		e.Ctx.Log.Debug("skipping block gossip",
@@ -644,6 +660,12 @@ func (e *Engine) sendChits(ctx context.Context, nodeID ids.NodeID, requestID uin
 			preferenceAtHeight = preference
 		}
 	}
+	if e.Config.VoteStrategy != HonestVotes {
+		if vote, ok := e.byzantineVote(); ok {
+			preference = vote
+			preferenceAtHeight = vote
+		}
+	}
 	e.Sender.SendChits(ctx, nodeID, requestID, preference, preferenceAtHeight, lastAcceptedID)
 }
 
@@ -808,6 +830,15 @@ func (e *Engine) issue(
 
 	// mark that the block is queued to be added to consensus once its ancestors have been
 	e.pending[blkID] = blk
+	e.Events.Publish(event.Event{
+		Type:    event.Issued,
+		ChainID: e.Ctx.ChainID,
+		Time:    time.Now(),
+		Block: &event.Block{
+			ID:     blkID,
+			Height: blk.Height(),
+		},
+	})
 
 	// Remove any outstanding requests for this block
 	if req, ok := e.blkReqs.DeleteValue(blkID); ok {
@@ -1080,6 +1111,7 @@ func (e *Engine) addUnverifiedBlockToConsensus(
 	blkHeight := blk.Height()
 
 	// make sure this block is valid
+	verifyStart := time.Now()
 	if err := blk.Verify(ctx); err != nil {
 		e.Ctx.Log.Debug("block verification failed",
 			zap.Stringer("nodeID", nodeID),
@@ -1093,6 +1125,18 @@ func (e *Engine) addUnverifiedBlockToConsensus(
 		return false, nil
 	}
 
+	now := time.Now()
+	e.Events.Publish(event.Event{
+		Type:    event.Verified,
+		ChainID: e.Ctx.ChainID,
+		Time:    now,
+		Block: &event.Block{
+			ID:     blkID,
+			Height: blkHeight,
+		},
+		Duration: now.Sub(verifyStart),
+	})
+
 	issuedMetric.Inc()
 	e.unverifiedIDToAncestor.Remove(blkID)
 	e.unverifiedBlockCache.Evict(blkID)
@@ -1106,6 +1150,8 @@ func (e *Engine) addUnverifiedBlockToConsensus(
 		Block:   blk,
 		metrics: e.metrics,
 		tree:    e.unverifiedIDToAncestor,
+		chainID: e.Ctx.ChainID,
+		events:  e.Events,
 	})
 }
 
@@ -20,12 +20,14 @@ import (
 	"github.com/f01c5700/avalanchego/snow"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman/snowmantest"
 	"github.com/f01c5700/avalanchego/snow/engine/common"
 	"github.com/f01c5700/avalanchego/snow/engine/common/tracker"
 	"github.com/f01c5700/avalanchego/snow/engine/enginetest"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/ancestor"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/block/blocktest"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/getter"
 	"github.com/f01c5700/avalanchego/snow/snowtest"
 	"github.com/f01c5700/avalanchego/snow/validators"
@@ -3221,3 +3223,259 @@ func TestEngineAbortQueryWhenInPartition(t *testing.T) {
 
 	require.Contains(buff.String(), errInsufficientStake)
 }
+
+func TestEngineConsensusState(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te := setup(t, DefaultConfig(t))
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var queryRequestID uint32
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestID = requestID
+	}
+	require.NoError(te.issue(
+		context.Background(),
+		te.Ctx.NodeID,
+		blk,
+		false,
+		te.metrics.issued.WithLabelValues(unknownSource),
+	))
+
+	state := te.ConsensusState(1)
+	require.Len(state.Consensus.Blocks, 2)
+	require.Equal(blk.ID(), state.Consensus.Preference)
+	require.Len(state.OutstandingPolls, 1)
+	require.Equal(queryRequestID, state.OutstandingPolls[0].RequestID)
+	require.Equal([]ids.NodeID{vdr}, state.OutstandingPolls[0].Pending)
+	require.Empty(state.RecentPolls)
+
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, blk.ID(), blk.ID(), blk.ID()))
+
+	state = te.ConsensusState(1)
+	require.Empty(state.OutstandingPolls)
+	require.Len(state.RecentPolls, 1)
+
+	recentPoll := state.RecentPolls[0]
+	require.Equal(queryRequestID, recentPoll.RequestID)
+	require.True(recentPoll.Finished)
+	require.Empty(recentPoll.Pending)
+	require.Equal([]poll.Response{{NodeID: vdr, Vote: blk.ID()}}, recentPoll.Responses)
+}
+
+func TestEngineGossipFinishesTimedOutPolls(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig(t)
+	config.Params.K = 3
+	config.Params.AlphaPreference = 2
+	config.Params.AlphaConfidence = 3
+	config.PollStrategy = poll.StrategyConfig{
+		Strategy:          poll.EarlyTermLatencyAware,
+		ConfidenceTimeout: 50 * time.Millisecond,
+	}
+	var (
+		fastVdr = ids.GenerateTestNodeID()
+		slowVdr = ids.GenerateTestNodeID()
+	)
+	for _, nodeID := range []ids.NodeID{fastVdr, slowVdr} {
+		require.NoError(config.Validators.AddStaker(config.Ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+		require.NoError(config.ConnectedValidators.Connected(context.Background(), nodeID, version.CurrentApp))
+	}
+	vdr, _, sender, vm, te := setup(t, config)
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+	vm.GetBlockF = MakeGetBlockF([]*snowmantest.Block{
+		snowmantest.Genesis,
+		blk,
+	})
+
+	var queryRequestIDs []uint32
+	sender.SendPullQueryF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		require.Equal(set.Of(vdr, fastVdr, slowVdr), nodeIDs)
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+	require.NoError(te.issue(
+		context.Background(),
+		te.Ctx.NodeID,
+		blk,
+		false,
+		te.metrics.issued.WithLabelValues(unknownSource),
+	))
+	require.Len(queryRequestIDs, 1)
+
+	for _, nodeID := range []ids.NodeID{vdr, fastVdr} {
+		require.NoError(te.Chits(context.Background(), nodeID, queryRequestIDs[0], blk.ID(), blk.ID(), snowmantest.GenesisID))
+	}
+	require.Len(te.ConsensusState(0).OutstandingPolls, 1)
+
+	// The slow validator never responds, so the poll must be finished once
+	// it has timed out.
+	time.Sleep(2 * config.PollStrategy.ConfidenceTimeout)
+	require.NoError(te.Gossip(context.Background()))
+
+	state := te.ConsensusState(1)
+	require.Len(state.RecentPolls, 1)
+	recentPoll := state.RecentPolls[0]
+	require.Equal(queryRequestIDs[0], recentPoll.RequestID)
+	require.True(recentPoll.Finished)
+	require.Equal([]ids.NodeID{slowVdr}, recentPoll.Pending)
+	require.Equal(uint64(1), state.Consensus.NumPolls)
+
+	// The block is still processing, so it is polled again.
+	require.Len(queryRequestIDs, 2)
+}
+
+func TestEngineByzantineVotes(t *testing.T) {
+	preferredBlk := snowmantest.BuildChild(snowmantest.Genesis)
+	conflictingBlk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	tests := []struct {
+		name          string
+		voteStrategy  VoteStrategy
+		expectedVotes []ids.ID
+	}{
+		{
+			name:          "honest",
+			voteStrategy:  HonestVotes,
+			expectedVotes: []ids.ID{preferredBlk.ID(), preferredBlk.ID()},
+		},
+		{
+			name:          "conflicting",
+			voteStrategy:  ConflictingVotes,
+			expectedVotes: []ids.ID{preferredBlk.ID(), conflictingBlk.ID()},
+		},
+		{
+			name:          "wrong preference",
+			voteStrategy:  WrongPreferenceVotes,
+			expectedVotes: []ids.ID{conflictingBlk.ID(), conflictingBlk.ID()},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := DefaultConfig(t)
+			config.VoteStrategy = test.voteStrategy
+			vdr, _, sender, vm, te := setup(t, config)
+
+			vm.GetBlockF = MakeGetBlockF([]*snowmantest.Block{
+				snowmantest.Genesis,
+				preferredBlk,
+				conflictingBlk,
+			})
+			sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID, uint64) {}
+			for _, blk := range []*snowmantest.Block{preferredBlk, conflictingBlk} {
+				require.NoError(te.issue(
+					context.Background(),
+					te.Ctx.NodeID,
+					blk,
+					false,
+					te.metrics.issued.WithLabelValues(unknownSource),
+				))
+			}
+			require.Equal(preferredBlk.ID(), te.Consensus.Preference())
+
+			var votes []ids.ID
+			sender.SendChitsF = func(_ context.Context, _ ids.NodeID, _ uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) {
+				require.Equal(preferredID, preferredIDAtHeight)
+				require.Equal(snowmantest.GenesisID, acceptedID)
+				votes = append(votes, preferredID)
+			}
+			for i := range test.expectedVotes {
+				require.NoError(te.PullQuery(context.Background(), vdr, uint32(i), preferredBlk.ID(), preferredBlk.Height()))
+			}
+			require.ElementsMatch(test.expectedVotes, votes)
+		})
+	}
+}
+
+type eventRecorder []event.Event
+
+func (r *eventRecorder) Publish(e event.Event) {
+	*r = append(*r, e)
+}
+
+func TestEnginePublishesEvents(t *testing.T) {
+	require := require.New(t)
+
+	var events eventRecorder
+	config := DefaultConfig(t)
+	config.Events = &events
+	nodeID, _, sender, vm, te := setup(t, config)
+
+	// Ignore outbound chits
+	sender.SendChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID, ids.ID) {}
+
+	acceptedBlk := snowmantest.BuildChild(snowmantest.Genesis)
+	rejectedBlk := snowmantest.BuildChild(snowmantest.Genesis)
+	vm.ParseBlockF = MakeParseBlockF([]*snowmantest.Block{
+		snowmantest.Genesis,
+		acceptedBlk,
+		rejectedBlk,
+	})
+	vm.GetBlockF = MakeGetBlockF([]*snowmantest.Block{
+		snowmantest.Genesis,
+		acceptedBlk,
+		rejectedBlk,
+	})
+
+	var queryRequestIDs []uint32
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestIDs = append(queryRequestIDs, requestID)
+	}
+
+	require.NoError(te.PushQuery(context.Background(), nodeID, 0, acceptedBlk.Bytes(), acceptedBlk.Height()))
+	require.NoError(te.PushQuery(context.Background(), nodeID, 0, rejectedBlk.Bytes(), rejectedBlk.Height()))
+	require.Len(queryRequestIDs, 1)
+
+	require.NoError(te.Chits(context.Background(), nodeID, queryRequestIDs[0], acceptedBlk.ID(), acceptedBlk.ID(), acceptedBlk.ID()))
+	require.Equal(snowtest.Accepted, acceptedBlk.Status)
+	require.Equal(snowtest.Rejected, rejectedBlk.Status)
+
+	type summary struct {
+		typ   event.Type
+		blkID ids.ID
+	}
+	var summaries []summary
+	for _, e := range events {
+		require.Equal(config.Ctx.ChainID, e.ChainID)
+
+		var blkID ids.ID
+		if e.Block != nil {
+			blkID = e.Block.ID
+			require.Equal(uint64(1), e.Block.Height)
+		}
+		summaries = append(summaries, summary{
+			typ:   e.Type,
+			blkID: blkID,
+		})
+	}
+	require.Equal(
+		[]summary{
+			{typ: event.Issued, blkID: acceptedBlk.ID()},
+			{typ: event.Verified, blkID: acceptedBlk.ID()},
+			{typ: event.Issued, blkID: rejectedBlk.ID()},
+			{typ: event.Verified, blkID: rejectedBlk.ID()},
+			{typ: event.Rejected, blkID: rejectedBlk.ID()},
+			{typ: event.Polled},
+		},
+		summaries,
+	)
+
+	polled := events[len(events)-1].Poll
+	require.NotNil(polled)
+	require.Equal(queryRequestIDs[0], polled.RequestID)
+	require.Equal(map[ids.ID]int{acceptedBlk.ID(): 1}, polled.Votes)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package event
+
+import (
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
+)
+
+const (
+	// Issued is emitted when a block is issued to the engine, before its
+	// ancestry is known to be available.
+	Issued Type = "issued"
+	// Verified is emitted when a block is verified and added to consensus.
+	Verified Type = "verified"
+	// Polled is emitted when a poll finishes and its result is applied to
+	// consensus.
+	Polled Type = "polled"
+	// Accepted is emitted when a block is accepted.
+	Accepted Type = "accepted"
+	// Rejected is emitted when a block that was added to consensus is
+	// rejected.
+	Rejected Type = "rejected"
+)
+
+var (
+	// Types are all the event types, in the order they may occur in a block's
+	// lifecycle.
+	Types = []Type{
+		Issued,
+		Verified,
+		Polled,
+		Accepted,
+		Rejected,
+	}
+
+	_ Publisher = NoOpPublisher{}
+)
+
+type Type string
+
+// Event is a step of the consensus lifecycle of a block on a chain.
+type Event struct {
+	Type    Type      `json:"type"`
+	ChainID ids.ID    `json:"chainID"`
+	Time    time.Time `json:"time"`
+	// Block is the block this event refers to. It is nil for Polled events.
+	Block *Block `json:"block,omitempty"`
+	// Poll is the finished poll. It is only populated for Polled events.
+	Poll *poll.Info `json:"poll,omitempty"`
+	// Duration is the time spent verifying the block. It is only populated
+	// for Verified events.
+	Duration time.Duration `json:"duration,omitempty"`
+	// SinceIssued is the time since the block was issued, if it is known.
+	SinceIssued time.Duration `json:"sinceIssued,omitempty"`
+}
+
+type Block struct {
+	ID ids.ID `json:"id"`
+	// Height may be zero for accepted blocks that were never issued to the
+	// engine, such as blocks accepted during bootstrapping.
+	Height uint64 `json:"height"`
+}
+
+// Publisher is notified of consensus events.
+//
+// Publish is called synchronously by the engine, so it must not block.
+type Publisher interface {
+	Publish(Event)
+}
+
+type NoOpPublisher struct{}
+
+func (NoOpPublisher) Publish(Event) {}
@@ -5,9 +5,12 @@ package snowman
 
 import (
 	"context"
+	"time"
 
+	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/ancestor"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 )
 
 var _ snowman.Block = (*memoryBlock)(nil)
@@ -18,6 +21,8 @@ type memoryBlock struct {
 
 	tree    ancestor.Tree
 	metrics *metrics
+	chainID ids.ID
+	events  event.Publisher
 }
 
 // Accept accepts the underlying block & removes sibling subtrees
@@ -31,5 +36,18 @@ func (mb *memoryBlock) Accept(ctx context.Context) error {
 func (mb *memoryBlock) Reject(ctx context.Context) error {
 	mb.tree.RemoveDescendants(mb.ID())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
-	return mb.Block.Reject(ctx)
+	if err := mb.Block.Reject(ctx); err != nil {
+		return err
+	}
+
+	mb.events.Publish(event.Event{
+		Type:    event.Rejected,
+		ChainID: mb.chainID,
+		Time:    time.Now(),
+		Block: &event.Block{
+			ID:     mb.ID(),
+			Height: mb.Height(),
+		},
+	})
+	return nil
 }
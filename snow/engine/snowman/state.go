@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
+)
+
+// ConsensusState describes the progress the engine is making towards deciding
+// blocks.
+type ConsensusState struct {
+	Consensus snowman.State `json:"consensus"`
+	// NumPendingBlocks is the number of blocks that are waiting for their
+	// ancestors before they can be added to consensus.
+	NumPendingBlocks int `json:"numPendingBlocks"`
+	// OutstandingPolls are ordered from oldest to newest.
+	OutstandingPolls []poll.Info `json:"outstandingPolls"`
+	// RecentPolls are the most recently finished polls, ordered from newest
+	// to oldest.
+	RecentPolls []poll.Info `json:"recentPolls"`
+}
+
+// ConsensusState returns the current state of consensus along with up to
+// [numRecentPolls] of the most recently finished polls.
+//
+// Assumes the context lock is held.
+func (e *Engine) ConsensusState(numRecentPolls int) ConsensusState {
+	return ConsensusState{
+		Consensus:        e.Consensus.Inspect(),
+		NumPendingBlocks: len(e.pending),
+		OutstandingPolls: e.polls.Outstanding(),
+		RecentPolls:      e.polls.Recent(numRecentPolls),
+	}
+}
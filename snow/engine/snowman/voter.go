@@ -5,10 +5,12 @@ package snowman
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/engine/snowman/event"
 	"github.com/f01c5700/avalanchego/snow/engine/snowman/job"
 	"github.com/f01c5700/avalanchego/utils/bag"
 )
@@ -51,30 +53,49 @@ func (v *voter) Execute(ctx context.Context, _ []ids.ID, _ []ids.ID) error {
 		results = v.e.polls.Drop(v.requestID, v.nodeID)
 	}
 
+	return v.e.recordPollResults(ctx, results)
+}
+
+// recordPollResults applies the results of finished polls, ordered from oldest
+// to newest, to consensus.
+func (e *Engine) recordPollResults(ctx context.Context, results []bag.Bag[ids.ID]) error {
 	if len(results) == 0 {
 		return nil
 	}
 
-	for _, result := range results {
+	// Recent returns the finished polls from newest to oldest, whereas the
+	// results are ordered from oldest to newest.
+	infos := e.polls.Recent(len(results))
+	for i, result := range results {
 		result := result
-		v.e.Ctx.Log.Debug("finishing poll",
+		e.Ctx.Log.Debug("finishing poll",
 			zap.Stringer("result", &result),
 		)
-		if err := v.e.Consensus.RecordPoll(ctx, result); err != nil {
+		if err := e.Consensus.RecordPoll(ctx, result); err != nil {
 			return err
 		}
+
+		if infoIndex := len(results) - 1 - i; infoIndex < len(infos) {
+			info := infos[infoIndex]
+			e.Events.Publish(event.Event{
+				Type:    event.Polled,
+				ChainID: e.Ctx.ChainID,
+				Time:    time.Now(),
+				Poll:    &info,
+			})
+		}
 	}
 
-	if err := v.e.VM.SetPreference(ctx, v.e.Consensus.Preference()); err != nil {
+	if err := e.VM.SetPreference(ctx, e.Consensus.Preference()); err != nil {
 		return err
 	}
 
-	if v.e.Consensus.NumProcessing() == 0 {
-		v.e.Ctx.Log.Debug("Snowman engine can quiesce")
+	if e.Consensus.NumProcessing() == 0 {
+		e.Ctx.Log.Debug("Snowman engine can quiesce")
 		return nil
 	}
 
-	v.e.Ctx.Log.Debug("Snowman engine can't quiesce")
-	v.e.repoll(ctx)
+	e.Ctx.Log.Debug("Snowman engine can't quiesce")
+	e.repoll(ctx)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
@@ -23,6 +24,30 @@ import (
 	safemath "github.com/f01c5700/avalanchego/utils/math"
 )
 
+const (
+	// ReasonConsecutiveFailures is the reason a node is benched after too many
+	// queries to it failed in a row.
+	ReasonConsecutiveFailures Reason = "consecutiveFailures"
+	// ReasonManual is the reason a node is benched by an operator.
+	ReasonManual Reason = "manual"
+)
+
+var errNonPositiveBenchDuration = errors.New("bench duration must be positive")
+
+// Reason describes why a node was benched.
+type Reason string
+
+// BenchedNode describes a node that is currently benched.
+type BenchedNode struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Reason Reason     `json:"reason"`
+	// Failures is the number of consecutive failed queries that caused the
+	// node to be benched. It is zero for manually benched nodes.
+	Failures     int       `json:"failures"`
+	BenchedAt    time.Time `json:"benchedAt"`
+	BenchedUntil time.Time `json:"benchedUntil"`
+}
+
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
 // If we cannot terminate the poll early, then the poll will wait
@@ -40,6 +65,22 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Benched returns the currently benched nodes, ordered by when they will
+	// be unbenched.
+	Benched() []BenchedNode
+	// Bench benches [nodeID] for [duration], regardless of its failures and
+	// of the maximum portion of stake that may be benched. If [nodeID] is
+	// already benched, it will remain benched for [duration].
+	Bench(nodeID ids.NodeID, duration time.Duration) error
+	// Unbench removes [nodeID] from the benchlist. Returns false if [nodeID]
+	// wasn't benched.
+	Unbench(nodeID ids.NodeID) bool
+}
+
+type benchInfo struct {
+	reason    Reason
+	failures  int
+	benchedAt time.Time
 }
 
 type failureStreak struct {
@@ -54,7 +95,8 @@ type benchlist struct {
 	// Context of the chain this is the benchlist for
 	ctx *snow.ConsensusContext
 
-	numBenched, weightBenched prometheus.Gauge
+	numBenched, weightBenched            prometheus.Gauge
+	numManualBenches, numManualUnbenches prometheus.Counter
 
 	// Used to notify the timer that it should recalculate when it should fire
 	resetTimer chan struct{}
@@ -76,6 +118,9 @@ type benchlist struct {
 	// IDs of validators that are currently benched
 	benchlistSet set.Set[ids.NodeID]
 
+	// Validator ID --> Why the validator is benched
+	benchInfos map[ids.NodeID]benchInfo
+
 	// Min heap of benched validators ordered by when they can be unbenched
 	benchedHeap heap.Map[ids.NodeID, time.Time]
 
@@ -118,9 +163,18 @@ func NewBenchlist(
 			Name: "benched_weight",
 			Help: "Weight of currently benched validators",
 		}),
+		numManualBenches: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "manual_benches",
+			Help: "Number of times a validator was benched by an operator",
+		}),
+		numManualUnbenches: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "manual_unbenches",
+			Help: "Number of times a validator was unbenched by an operator",
+		}),
 		resetTimer:             make(chan struct{}, 1),
 		failureStreaks:         make(map[ids.NodeID]failureStreak),
 		benchlistSet:           set.Set[ids.NodeID]{},
+		benchInfos:             make(map[ids.NodeID]benchInfo),
 		benchable:              benchable,
 		benchedHeap:            heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
 		vdrs:                   validators,
@@ -133,6 +187,8 @@ func NewBenchlist(
 	err := errors.Join(
 		reg.Register(benchlist.numBenched),
 		reg.Register(benchlist.weightBenched),
+		reg.Register(benchlist.numManualBenches),
+		reg.Register(benchlist.numManualUnbenches),
 	)
 	if err != nil {
 		return nil, err
@@ -202,9 +258,15 @@ func (b *benchlist) removedExpiredNodes() {
 			zap.Stringer("nodeID", nodeID),
 		)
 		b.benchlistSet.Remove(nodeID)
+		delete(b.benchInfos, nodeID)
 		b.benchable.Unbenched(b.ctx.ChainID, nodeID)
 	}
 
+	b.updateMetrics()
+}
+
+// Assumes [b.lock] is held
+func (b *benchlist) updateMetrics() {
 	b.numBenched.Set(float64(b.benchedHeap.Len()))
 	benchedStake, err := b.vdrs.SubsetWeight(b.ctx.SubnetID, b.benchlistSet)
 	if err != nil {
@@ -239,6 +301,88 @@ func (b *benchlist) IsBenched(nodeID ids.NodeID) bool {
 	return b.benchlistSet.Contains(nodeID)
 }
 
+func (b *benchlist) Benched() []BenchedNode {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make([]BenchedNode, 0, len(b.benchInfos))
+	for nodeID, info := range b.benchInfos {
+		benchedUntil, _ := b.benchedHeap.Get(nodeID)
+		benched = append(benched, BenchedNode{
+			NodeID:       nodeID,
+			Reason:       info.reason,
+			Failures:     info.failures,
+			BenchedAt:    info.benchedAt,
+			BenchedUntil: benchedUntil,
+		})
+	}
+	slices.SortFunc(benched, func(a, b BenchedNode) int {
+		return a.BenchedUntil.Compare(b.BenchedUntil)
+	})
+	return benched
+}
+
+func (b *benchlist) Bench(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("%w: %s", errNonPositiveBenchDuration, duration)
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	benchedUntil := now.Add(duration)
+	b.ctx.Log.Info("manually benching node",
+		zap.Stringer("nodeID", nodeID),
+		zap.Duration("benchDuration", duration),
+	)
+
+	if !b.benchlistSet.Contains(nodeID) {
+		b.benchlistSet.Add(nodeID)
+		b.benchable.Benched(b.ctx.ChainID, nodeID)
+
+		b.streaklock.Lock()
+		delete(b.failureStreaks, nodeID)
+		b.streaklock.Unlock()
+	}
+	b.benchInfos[nodeID] = benchInfo{
+		reason:    ReasonManual,
+		benchedAt: now,
+	}
+	b.benchedHeap.Push(nodeID, benchedUntil)
+
+	// Update the timer to account for the newly benched node.
+	select {
+	case b.resetTimer <- struct{}{}:
+	default:
+	}
+
+	b.numManualBenches.Inc()
+	b.updateMetrics()
+	return nil
+}
+
+func (b *benchlist) Unbench(nodeID ids.NodeID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.benchlistSet.Contains(nodeID) {
+		return false
+	}
+
+	b.ctx.Log.Info("manually unbenching node",
+		zap.Stringer("nodeID", nodeID),
+	)
+	b.benchedHeap.Remove(nodeID)
+	b.benchlistSet.Remove(nodeID)
+	delete(b.benchInfos, nodeID)
+	b.benchable.Unbenched(b.ctx.ChainID, nodeID)
+
+	b.numManualUnbenches.Inc()
+	b.updateMetrics()
+	return true
+}
+
 // RegisterResponse notes that we received a response from [nodeID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
@@ -343,9 +487,16 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 	b.benchable.Benched(b.ctx.ChainID, nodeID)
 
 	b.streaklock.Lock()
+	failures := b.failureStreaks[nodeID].consecutive
 	delete(b.failureStreaks, nodeID)
 	b.streaklock.Unlock()
 
+	b.benchInfos[nodeID] = benchInfo{
+		reason:    ReasonConsecutiveFailures,
+		failures:  failures,
+		benchedAt: now,
+	}
+
 	b.benchedHeap.Push(nodeID, benchedUntil)
 
 	// Update the timer to account for the newly benched node.
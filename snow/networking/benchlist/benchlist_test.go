@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/f01c5700/avalanchego/ids"
@@ -117,6 +118,17 @@ func TestBenchlistAdd(t *testing.T) {
 	benchable.BenchedF = nil
 	b.lock.Unlock()
 
+	require.Equal(
+		[]BenchedNode{{
+			NodeID:       vdrID0,
+			Reason:       ReasonConsecutiveFailures,
+			Failures:     threshold + 1,
+			BenchedAt:    now,
+			BenchedUntil: benchedUntil,
+		}},
+		b.Benched(),
+	)
+
 	// Give another validator [threshold-1] failures
 	for i := 0; i < threshold-1; i++ {
 		b.RegisterFailure(vdrID1)
@@ -365,3 +377,87 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(3, count)
 }
+
+// Test that validators can be manually benched and unbenched
+func TestBenchlistManual(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+
+	var benchedIDs, unbenchedIDs []ids.NodeID
+	benchable := &TestBenchable{
+		T: t,
+		BenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benchedIDs = append(benchedIDs, nodeID)
+		},
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			unbenchedIDs = append(unbenchedIDs, nodeID)
+		},
+	}
+
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		3,
+		minimumFailingDuration,
+		time.Minute,
+		0.1, // Manual benches ignore the maximum benched stake
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	now := time.Now()
+	b.clock.Set(now)
+
+	err = b.Bench(vdrID0, 0)
+	require.ErrorIs(err, errNonPositiveBenchDuration)
+
+	require.NoError(b.Bench(vdrID0, time.Hour))
+	require.NoError(b.Bench(vdrID1, time.Minute))
+	require.True(b.IsBenched(vdrID0))
+	require.True(b.IsBenched(vdrID1))
+	require.Equal([]ids.NodeID{vdrID0, vdrID1}, benchedIDs)
+	require.Equal(
+		[]BenchedNode{
+			{
+				NodeID:       vdrID1,
+				Reason:       ReasonManual,
+				BenchedAt:    now,
+				BenchedUntil: now.Add(time.Minute),
+			},
+			{
+				NodeID:       vdrID0,
+				Reason:       ReasonManual,
+				BenchedAt:    now,
+				BenchedUntil: now.Add(time.Hour),
+			},
+		},
+		b.Benched(),
+	)
+
+	// Re-benching a node should only update when it is unbenched
+	require.NoError(b.Bench(vdrID1, 2*time.Hour))
+	require.Len(benchedIDs, 2)
+	require.Equal(vdrID0, b.Benched()[0].NodeID)
+
+	require.True(b.Unbench(vdrID0))
+	require.False(b.Unbench(vdrID0))
+	require.False(b.IsBenched(vdrID0))
+	require.Equal([]ids.NodeID{vdrID0}, unbenchedIDs)
+
+	b.lock.Lock()
+	require.Equal(1, b.benchedHeap.Len())
+	require.Equal(float64(3), testutil.ToFloat64(b.numManualBenches))
+	require.Equal(float64(1), testutil.ToFloat64(b.numManualUnbenches))
+	require.Equal(float64(1), testutil.ToFloat64(b.numBenched))
+	require.Equal(float64(50), testutil.ToFloat64(b.weightBenched))
+	b.lock.Unlock()
+}
@@ -4,6 +4,8 @@
 package benchlist
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -13,7 +15,12 @@ import (
 	"github.com/f01c5700/avalanchego/snow/validators"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	errUnknownChain      = errors.New("unknown chain")
+	errBenchlistDisabled = errors.New("benchlist is disabled")
+
+	_ Manager = (*manager)(nil)
+)
 
 // Manager provides an interface for a benchlist to register whether
 // queries have been successful or unsuccessful and place validators with
@@ -36,6 +43,13 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// Benchlists returns the benched nodes of every registered chain.
+	Benchlists() map[ids.ID][]BenchedNode
+	// Bench benches [nodeID] on chain [chainID] for [duration].
+	Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error
+	// Unbench removes [nodeID] from the benchlist of chain [chainID]. Returns
+	// false if [nodeID] wasn't benched.
+	Unbench(chainID ids.ID, nodeID ids.NodeID) (bool, error)
 }
 
 // Config defines the configuration for a benchlist
@@ -102,6 +116,39 @@ func (m *manager) GetBenched(nodeID ids.NodeID) []ids.ID {
 	return benched
 }
 
+func (m *manager) Benchlists() map[ids.ID][]BenchedNode {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benchlists := make(map[ids.ID][]BenchedNode, len(m.chainBenchlists))
+	for chainID, benchlist := range m.chainBenchlists {
+		benchlists[chainID] = benchlist.Benched()
+	}
+	return benchlists
+}
+
+func (m *manager) Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist.Bench(nodeID, duration)
+}
+
+func (m *manager) Unbench(chainID ids.ID, nodeID ids.NodeID) (bool, error) {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return false, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist.Unbench(nodeID), nil
+}
+
 func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -180,3 +227,15 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) Benchlists() map[ids.ID][]BenchedNode {
+	return map[ids.ID][]BenchedNode{}
+}
+
+func (noBenchlist) Bench(ids.ID, ids.NodeID, time.Duration) error {
+	return errBenchlistDisabled
+}
+
+func (noBenchlist) Unbench(ids.ID, ids.NodeID) (bool, error) {
+	return false, nil
+}
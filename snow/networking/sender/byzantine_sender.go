@@ -0,0 +1,264 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/utils/set"
+)
+
+const (
+	// RandomVotes replaces the blocks in every Chits message with blocks
+	// chosen at random from the blocks the engine previously referenced.
+	RandomVotes ByzantineStrategy = "random-votes"
+	// WithholdChits drops every Chits message.
+	WithholdChits ByzantineStrategy = "withhold-chits"
+	// StaleAncestors replies to every GetAncestors request with the first
+	// non-empty Ancestors response that was sent.
+	StaleAncestors ByzantineStrategy = "stale-ancestors"
+	// DelayResponses sends every consensus response after ResponseDelay.
+	DelayResponses ByzantineStrategy = "delay-responses"
+	// ConflictingVotes makes the engine vote for a different processing block
+	// in every Chits message. It is applied by the engine rather than the
+	// sender.
+	ConflictingVotes ByzantineStrategy = "conflicting-votes"
+	// WrongPreference makes the engine vote for a processing block that it
+	// doesn't prefer. It is applied by the engine rather than the sender.
+	WrongPreference ByzantineStrategy = "wrong-preference"
+
+	// maxRecentBlocks is the number of recently referenced blocks that
+	// RandomVotes chooses from.
+	maxRecentBlocks = 256
+)
+
+var (
+	_ common.Sender = (*byzantineSender)(nil)
+
+	errUnknownByzantineStrategy = errors.New("unknown byzantine strategy")
+	errNonPositiveResponseDelay = errors.New("response delay must be positive")
+	errMultipleVoteStrategies   = errors.New("multiple vote strategies")
+)
+
+// ByzantineStrategy is a way in which a byzantine sender deviates from the
+// protocol.
+type ByzantineStrategy string
+
+// ByzantineConfig configures a sender that misbehaves on purpose. It must only
+// be used to test how honest nodes react to byzantine peers.
+type ByzantineConfig struct {
+	Strategies []ByzantineStrategy `json:"strategies"`
+	// ResponseDelay is the amount of time responses are delayed by when using
+	// DelayResponses.
+	ResponseDelay time.Duration `json:"responseDelay"`
+	// Seed is used to choose the blocks voted for by RandomVotes.
+	Seed int64 `json:"seed"`
+}
+
+// Enabled returns true if any strategy is configured.
+func (c *ByzantineConfig) Enabled() bool {
+	return len(c.Strategies) > 0
+}
+
+// Contains returns true if [strategy] is configured.
+func (c *ByzantineConfig) Contains(strategy ByzantineStrategy) bool {
+	return slices.Contains(c.Strategies, strategy)
+}
+
+func (c *ByzantineConfig) Verify() error {
+	numVoteStrategies := 0
+	for _, strategy := range c.Strategies {
+		switch strategy {
+		case RandomVotes, ConflictingVotes, WrongPreference:
+			numVoteStrategies++
+			if numVoteStrategies > 1 {
+				return errMultipleVoteStrategies
+			}
+		case WithholdChits, StaleAncestors:
+		case DelayResponses:
+			if c.ResponseDelay <= 0 {
+				return errNonPositiveResponseDelay
+			}
+		default:
+			return fmt.Errorf("%w: %q", errUnknownByzantineStrategy, strategy)
+		}
+	}
+	return nil
+}
+
+// byzantineSender deviates from the protocol according to its config and
+// otherwise forwards messages to the wrapped sender.
+type byzantineSender struct {
+	common.Sender
+
+	strategies    set.Set[ByzantineStrategy]
+	responseDelay time.Duration
+
+	lock sync.Mutex
+	rng  *rand.Rand
+	// recentBlocks is a ring buffer of the distinct blocks most recently
+	// referenced by the engine.
+	recentBlocks    []ids.ID
+	nextRecentBlock int
+	staleAncestors  [][]byte
+}
+
+// Byzantine returns a sender that misbehaves according to [config]. If
+// [config] isn't enabled, [sender] is returned.
+func Byzantine(sender common.Sender, config ByzantineConfig) (common.Sender, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return sender, nil
+	}
+	return &byzantineSender{
+		Sender:        sender,
+		strategies:    set.Of(config.Strategies...),
+		responseDelay: config.ResponseDelay,
+		rng:           rand.New(rand.NewSource(config.Seed)), // #nosec G404
+	}, nil
+}
+
+func (s *byzantineSender) SendStateSummaryFrontier(ctx context.Context, nodeID ids.NodeID, requestID uint32, summary []byte) {
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendStateSummaryFrontier(ctx, nodeID, requestID, summary)
+	})
+}
+
+func (s *byzantineSender) SendAcceptedStateSummary(ctx context.Context, nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) {
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendAcceptedStateSummary(ctx, nodeID, requestID, summaryIDs)
+	})
+}
+
+func (s *byzantineSender) SendAcceptedFrontier(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.observe(containerID)
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendAcceptedFrontier(ctx, nodeID, requestID, containerID)
+	})
+}
+
+func (s *byzantineSender) SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
+	s.observe(containerIDs...)
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendAccepted(ctx, nodeID, requestID, containerIDs)
+	})
+}
+
+func (s *byzantineSender) SendGet(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.observe(containerID)
+	s.Sender.SendGet(ctx, nodeID, requestID, containerID)
+}
+
+func (s *byzantineSender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.observe(containerID)
+	s.Sender.SendGetAncestors(ctx, nodeID, requestID, containerID)
+}
+
+func (s *byzantineSender) SendPut(ctx context.Context, nodeID ids.NodeID, requestID uint32, container []byte) {
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendPut(ctx, nodeID, requestID, container)
+	})
+}
+
+func (s *byzantineSender) SendAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) {
+	if s.strategies.Contains(StaleAncestors) {
+		s.lock.Lock()
+		if s.staleAncestors == nil && len(containers) > 0 {
+			s.staleAncestors = containers
+		}
+		if s.staleAncestors != nil {
+			containers = s.staleAncestors
+		}
+		s.lock.Unlock()
+	}
+
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendAncestors(ctx, nodeID, requestID, containers)
+	})
+}
+
+func (s *byzantineSender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, containerID ids.ID, requestedHeight uint64) {
+	s.observe(containerID)
+	s.Sender.SendPullQuery(ctx, nodeIDs, requestID, containerID, requestedHeight)
+}
+
+func (s *byzantineSender) SendChits(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	preferredID ids.ID,
+	preferredIDAtHeight ids.ID,
+	acceptedID ids.ID,
+) {
+	if s.strategies.Contains(WithholdChits) {
+		return
+	}
+
+	s.observe(preferredID, preferredIDAtHeight, acceptedID)
+	if s.strategies.Contains(RandomVotes) {
+		preferredID = s.randomBlock()
+		preferredIDAtHeight = s.randomBlock()
+		acceptedID = s.randomBlock()
+	}
+
+	s.respond(ctx, func(ctx context.Context) {
+		s.Sender.SendChits(ctx, nodeID, requestID, preferredID, preferredIDAtHeight, acceptedID)
+	})
+}
+
+// respond calls [send] immediately, or after the response delay when using
+// DelayResponses.
+func (s *byzantineSender) respond(ctx context.Context, send func(context.Context)) {
+	if !s.strategies.Contains(DelayResponses) {
+		send(ctx)
+		return
+	}
+
+	// The response is sent after the caller has returned, so it must not be
+	// cancelled along with the caller's context.
+	ctx = context.WithoutCancel(ctx)
+	time.AfterFunc(s.responseDelay, func() {
+		send(ctx)
+	})
+}
+
+// observe records that the engine referenced [blkIDs].
+func (s *byzantineSender) observe(blkIDs ...ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, blkID := range blkIDs {
+		if blkID == ids.Empty || slices.Contains(s.recentBlocks, blkID) {
+			continue
+		}
+		if len(s.recentBlocks) < maxRecentBlocks {
+			s.recentBlocks = append(s.recentBlocks, blkID)
+			continue
+		}
+		s.recentBlocks[s.nextRecentBlock] = blkID
+		s.nextRecentBlock = (s.nextRecentBlock + 1) % maxRecentBlocks
+	}
+}
+
+// randomBlock returns a block chosen uniformly at random from the recently
+// referenced blocks.
+func (s *byzantineSender) randomBlock() ids.ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.recentBlocks) == 0 {
+		return ids.Empty
+	}
+	return s.recentBlocks[s.rng.Intn(len(s.recentBlocks))]
+}
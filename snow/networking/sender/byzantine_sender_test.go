@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/engine/enginetest"
+	"github.com/f01c5700/avalanchego/utils/set"
+)
+
+func TestByzantineConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ByzantineConfig
+		expectedErr error
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "valid",
+			config: ByzantineConfig{
+				Strategies:    []ByzantineStrategy{RandomVotes, WithholdChits, StaleAncestors, DelayResponses},
+				ResponseDelay: time.Second,
+			},
+		},
+		{
+			name: "unknown strategy",
+			config: ByzantineConfig{
+				Strategies: []ByzantineStrategy{"equivocate"},
+			},
+			expectedErr: errUnknownByzantineStrategy,
+		},
+		{
+			name: "delay without response delay",
+			config: ByzantineConfig{
+				Strategies: []ByzantineStrategy{DelayResponses},
+			},
+			expectedErr: errNonPositiveResponseDelay,
+		},
+		{
+			name: "engine vote strategy",
+			config: ByzantineConfig{
+				Strategies: []ByzantineStrategy{ConflictingVotes, WithholdChits},
+			},
+		},
+		{
+			name: "multiple vote strategies",
+			config: ByzantineConfig{
+				Strategies: []ByzantineStrategy{RandomVotes, WrongPreference},
+			},
+			expectedErr: errMultipleVoteStrategies,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestByzantineDisabled(t *testing.T) {
+	require := require.New(t)
+
+	honest := &enginetest.Sender{}
+	s, err := Byzantine(honest, ByzantineConfig{})
+	require.NoError(err)
+	require.Equal(honest, s)
+}
+
+func TestByzantineWithholdChits(t *testing.T) {
+	require := require.New(t)
+
+	honest := &enginetest.Sender{
+		T:             t,
+		CantSendChits: true,
+	}
+	s, err := Byzantine(honest, ByzantineConfig{
+		Strategies: []ByzantineStrategy{WithholdChits},
+	})
+	require.NoError(err)
+
+	s.SendChits(context.Background(), ids.GenerateTestNodeID(), 1, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+}
+
+func TestByzantineRandomVotes(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		blkID0 = ids.GenerateTestID()
+		blkID1 = ids.GenerateTestID()
+		blkID2 = ids.GenerateTestID()
+		known  = set.Of(blkID0, blkID1, blkID2)
+		votes  []ids.ID
+	)
+	honest := &enginetest.Sender{
+		SendChitsF: func(_ context.Context, _ ids.NodeID, _ uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) {
+			votes = append(votes, preferredID, preferredIDAtHeight, acceptedID)
+		},
+	}
+	s, err := Byzantine(honest, ByzantineConfig{
+		Strategies: []ByzantineStrategy{RandomVotes},
+	})
+	require.NoError(err)
+
+	s.SendPullQuery(context.Background(), nil, 1, blkID1, 1)
+	s.SendGet(context.Background(), ids.EmptyNodeID, 2, blkID2)
+	for i := 0; i < 100; i++ {
+		s.SendChits(context.Background(), ids.EmptyNodeID, uint32(i), blkID0, blkID0, blkID0)
+	}
+
+	votedFor := set.Of(votes...)
+	require.Equal(known, votedFor)
+}
+
+func TestByzantineStaleAncestors(t *testing.T) {
+	require := require.New(t)
+
+	var sent [][][]byte
+	honest := &enginetest.Sender{
+		SendAncestorsF: func(_ context.Context, _ ids.NodeID, _ uint32, containers [][]byte) {
+			sent = append(sent, containers)
+		},
+	}
+	s, err := Byzantine(honest, ByzantineConfig{
+		Strategies: []ByzantineStrategy{StaleAncestors},
+	})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	s.SendAncestors(context.Background(), nodeID, 1, nil)
+	s.SendAncestors(context.Background(), nodeID, 2, [][]byte{{1}})
+	s.SendAncestors(context.Background(), nodeID, 3, [][]byte{{2}, {1}})
+	require.Equal(
+		[][][]byte{
+			nil,
+			{{1}},
+			{{1}},
+		},
+		sent,
+	)
+}
+
+func TestByzantineDelayResponses(t *testing.T) {
+	require := require.New(t)
+
+	const delay = 50 * time.Millisecond
+	sent := make(chan time.Time, 1)
+	honest := &enginetest.Sender{
+		SendPutF: func(context.Context, ids.NodeID, uint32, []byte) {
+			sent <- time.Now()
+		},
+	}
+	s, err := Byzantine(honest, ByzantineConfig{
+		Strategies:    []ByzantineStrategy{DelayResponses},
+		ResponseDelay: delay,
+	})
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	s.SendPut(ctx, ids.GenerateTestNodeID(), 1, []byte{1})
+	// Cancelling the caller's context must not prevent the delayed response.
+	cancel()
+
+	require.GreaterOrEqual((<-sent).Sub(start), delay)
+}
@@ -19,6 +19,15 @@ import (
 
 var _ Manager = (*manager)(nil)
 
+// PeerTimeout is the estimated timeout of the requests with [Op] sent to
+// [NodeID].
+type PeerTimeout struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Op     string     `json:"op"`
+	// Timeout is in nanoseconds.
+	Timeout time.Duration `json:"timeout"`
+}
+
 // Manages timeouts for requests sent to peers.
 type Manager interface {
 	// Start the manager. Must be called before any other method.
@@ -26,6 +35,9 @@ type Manager interface {
 	Dispatch()
 	// TimeoutDuration returns the current timeout duration.
 	TimeoutDuration() time.Duration
+	// PeerTimeouts returns the timeouts that are estimated for specific peers
+	// and ops. Requests to other peers use TimeoutDuration.
+	PeerTimeouts() []PeerTimeout
 	// IsBenched returns true if messages to [nodeID] regarding [chainID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID, chainID ids.ID) bool
@@ -74,8 +86,12 @@ func NewManager(
 	requestReg prometheus.Registerer,
 	responseReg prometheus.Registerer,
 ) (Manager, error) {
+	config := *timeoutConfig
+	config.FormatOp = func(op byte) string {
+		return message.Op(op).String()
+	}
 	tm, err := timer.NewAdaptiveTimeoutManager(
-		timeoutConfig,
+		&config,
 		requestReg,
 	)
 	if err != nil {
@@ -109,6 +125,19 @@ func (m *manager) TimeoutDuration() time.Duration {
 	return m.tm.TimeoutDuration()
 }
 
+func (m *manager) PeerTimeouts() []PeerTimeout {
+	estimates := m.tm.PeerTimeouts()
+	timeouts := make([]PeerTimeout, len(estimates))
+	for i, estimate := range estimates {
+		timeouts[i] = PeerTimeout{
+			NodeID:  estimate.NodeID,
+			Op:      message.Op(estimate.Op).String(),
+			Timeout: estimate.Timeout,
+		}
+	}
+	return timeouts
+}
+
 // IsBenched returns true if messages to [nodeID] regarding [chainID]
 // should not be sent over the network and should immediately fail.
 func (m *manager) IsBenched(nodeID ids.NodeID, chainID ids.ID) bool {
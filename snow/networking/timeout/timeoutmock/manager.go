@@ -16,6 +16,7 @@ import (
 	ids "github.com/f01c5700/avalanchego/ids"
 	message "github.com/f01c5700/avalanchego/message"
 	snow "github.com/f01c5700/avalanchego/snow"
+	timeout "github.com/f01c5700/avalanchego/snow/networking/timeout"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -68,6 +69,20 @@ func (mr *ManagerMockRecorder) IsBenched(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBenched", reflect.TypeOf((*Manager)(nil).IsBenched), arg0, arg1)
 }
 
+// PeerTimeouts mocks base method.
+func (m *Manager) PeerTimeouts() []timeout.PeerTimeout {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerTimeouts")
+	ret0, _ := ret[0].([]timeout.PeerTimeout)
+	return ret0
+}
+
+// PeerTimeouts indicates an expected call of PeerTimeouts.
+func (mr *ManagerMockRecorder) PeerTimeouts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerTimeouts", reflect.TypeOf((*Manager)(nil).PeerTimeouts))
+}
+
 // RegisterChain mocks base method.
 func (m *Manager) RegisterChain(arg0 *snow.ConsensusContext) error {
 	m.ctrl.T.Helper()
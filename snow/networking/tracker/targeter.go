@@ -4,6 +4,10 @@
 package tracker
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+
 	"go.uber.org/zap"
 
 	"github.com/f01c5700/avalanchego/ids"
@@ -12,13 +16,30 @@ import (
 	"github.com/f01c5700/avalanchego/utils/logging"
 )
 
-var _ Targeter = (*targeter)(nil)
+var (
+	_ DynamicTargeter = (*targeter)(nil)
+
+	errNegativeTarget = errors.New("target must be non-negative")
+)
 
 type Targeter interface {
 	// Returns the target usage of the given node.
 	TargetUsage(nodeID ids.NodeID) float64
 }
 
+// DynamicTargeter is a Targeter whose allocations can be updated while it is
+// in use.
+type DynamicTargeter interface {
+	Targeter
+
+	// Config returns the current allocations of this targeter.
+	Config() TargeterConfig
+
+	// SetConfig updates the allocations of this targeter. [config] must have
+	// been verified.
+	SetConfig(config TargeterConfig)
+}
+
 type TargeterConfig struct {
 	// VdrAlloc is the amount of the resource to split over validators, weighted
 	// by stake.
@@ -33,36 +54,63 @@ type TargeterConfig struct {
 	MaxNonVdrNodeUsage float64 `json:"maxNonVdrNodeUsage"`
 }
 
+func (c *TargeterConfig) Verify() error {
+	switch {
+	case c.VdrAlloc < 0:
+		return fmt.Errorf("%w: vdrAlloc (%f)", errNegativeTarget, c.VdrAlloc)
+	case c.MaxNonVdrUsage < 0:
+		return fmt.Errorf("%w: maxNonVdrUsage (%f)", errNegativeTarget, c.MaxNonVdrUsage)
+	case c.MaxNonVdrNodeUsage < 0:
+		return fmt.Errorf("%w: maxNonVdrNodeUsage (%f)", errNegativeTarget, c.MaxNonVdrNodeUsage)
+	default:
+		return nil
+	}
+}
+
 func NewTargeter(
 	logger logging.Logger,
 	config *TargeterConfig,
 	vdrs validators.Manager,
 	tracker Tracker,
-) Targeter {
+) DynamicTargeter {
 	return &targeter{
-		log:                logger,
-		vdrs:               vdrs,
-		tracker:            tracker,
-		vdrAlloc:           config.VdrAlloc,
-		maxNonVdrUsage:     config.MaxNonVdrUsage,
-		maxNonVdrNodeUsage: config.MaxNonVdrNodeUsage,
+		log:     logger,
+		vdrs:    vdrs,
+		tracker: tracker,
+		config:  *config,
 	}
 }
 
 type targeter struct {
-	vdrs               validators.Manager
-	log                logging.Logger
-	tracker            Tracker
-	vdrAlloc           float64
-	maxNonVdrUsage     float64
-	maxNonVdrNodeUsage float64
+	vdrs    validators.Manager
+	log     logging.Logger
+	tracker Tracker
+
+	lock   sync.RWMutex
+	config TargeterConfig
+}
+
+func (t *targeter) Config() TargeterConfig {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.config
+}
+
+func (t *targeter) SetConfig(config TargeterConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.config = config
 }
 
 func (t *targeter) TargetUsage(nodeID ids.NodeID) float64 {
+	config := t.Config()
+
 	// This node's at-large allocation is min([remaining at large], [max at large for a given peer])
 	usage := t.tracker.TotalUsage()
-	baseAlloc := max(0, t.maxNonVdrUsage-usage)
-	baseAlloc = min(baseAlloc, t.maxNonVdrNodeUsage)
+	baseAlloc := max(0, config.MaxNonVdrUsage-usage)
+	baseAlloc = min(baseAlloc, config.MaxNonVdrNodeUsage)
 
 	// This node gets a stake-weighted portion of the validator allocation.
 	weight := t.vdrs.GetWeight(constants.PrimaryNetworkID, nodeID)
@@ -78,6 +126,6 @@ func (t *targeter) TargetUsage(nodeID ids.NodeID) float64 {
 		return baseAlloc
 	}
 
-	vdrAlloc := t.vdrAlloc * float64(weight) / float64(totalWeight)
+	vdrAlloc := config.VdrAlloc * float64(weight) / float64(totalWeight)
 	return vdrAlloc + baseAlloc
 }
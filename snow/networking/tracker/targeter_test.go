@@ -39,8 +39,81 @@ func TestNewTargeter(t *testing.T) {
 	targeter := targeterIntf.(*targeter)
 	require.Equal(vdrs, targeter.vdrs)
 	require.Equal(tracker, targeter.tracker)
-	require.Equal(config.MaxNonVdrUsage, targeter.maxNonVdrUsage)
-	require.Equal(config.MaxNonVdrNodeUsage, targeter.maxNonVdrNodeUsage)
+	require.Equal(*config, targeter.Config())
+}
+
+func TestTargeterSetConfig(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	tracker := trackermock.NewTracker(ctrl)
+	targeter := NewTargeter(
+		logging.NoLog{},
+		&TargeterConfig{
+			VdrAlloc:           10,
+			MaxNonVdrUsage:     10,
+			MaxNonVdrNodeUsage: 10,
+		},
+		validators.NewManager(),
+		tracker,
+	)
+
+	newConfig := TargeterConfig{
+		VdrAlloc:           20,
+		MaxNonVdrUsage:     4,
+		MaxNonVdrNodeUsage: 2,
+	}
+	targeter.SetConfig(newConfig)
+	require.Equal(newConfig, targeter.Config())
+
+	// The new per-node limit should be applied.
+	tracker.EXPECT().TotalUsage().Return(float64(0)).Times(1)
+	require.Equal(float64(2), targeter.TargetUsage(ids.GenerateTestNodeID()))
+}
+
+func TestTargeterConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      TargeterConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: TargeterConfig{
+				VdrAlloc:           1,
+				MaxNonVdrUsage:     1,
+				MaxNonVdrNodeUsage: 1,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative vdr alloc",
+			config: TargeterConfig{
+				VdrAlloc: -1,
+			},
+			expectedErr: errNegativeTarget,
+		},
+		{
+			name: "negative max non-vdr usage",
+			config: TargeterConfig{
+				MaxNonVdrUsage: -1,
+			},
+			expectedErr: errNegativeTarget,
+		},
+		{
+			name: "negative max non-vdr node usage",
+			config: TargeterConfig{
+				MaxNonVdrNodeUsage: -1,
+			},
+			expectedErr: errNegativeTarget,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
 }
 
 func TestTarget(t *testing.T) {
@@ -17,6 +17,10 @@ var _ Manager = (*manager)(nil)
 type Manager interface {
 	Tracker
 	Calculator
+
+	// CalculateUptimeHistory returns the uptime of [nodeID] on [subnetID]
+	// during each window that overlaps the period from [start] to [end].
+	CalculateUptimeHistory(nodeID ids.NodeID, subnetID ids.ID, start, end time.Time) ([]Sample, error)
 }
 
 type Tracker interface {
@@ -68,7 +72,7 @@ func (m *manager) StartTracking(nodeIDs []ids.NodeID, subnetID ids.ID) error {
 
 		durationOffline := now.Sub(lastUpdated)
 		newUpDuration := upDuration + durationOffline
-		if err := m.state.SetUptime(nodeID, subnetID, newUpDuration, now); err != nil {
+		if err := m.setUptime(nodeID, subnetID, upDuration, lastUpdated, newUpDuration, now); err != nil {
 			return err
 		}
 	}
@@ -105,7 +109,7 @@ func (m *manager) StopTracking(nodeIDs []ids.NodeID, subnetID ids.ID) error {
 			continue
 		}
 
-		if err := m.state.SetUptime(nodeID, subnetID, upDuration, now); err != nil {
+		if err := m.setUptime(nodeID, subnetID, upDuration, lastUpdated, upDuration, now); err != nil {
 			return err
 		}
 	}
@@ -202,6 +206,38 @@ func (m *manager) CalculateUptimePercentFrom(nodeID ids.NodeID, subnetID ids.ID,
 	return uptime, nil
 }
 
+func (m *manager) CalculateUptimeHistory(nodeID ids.NodeID, subnetID ids.ID, start, end time.Time) ([]Sample, error) {
+	upDuration, lastUpdated, err := m.state.GetUptime(nodeID, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	newUpDuration, now, err := m.CalculateUptime(nodeID, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the uptime that hasn't been written to the state yet.
+	unwritten := make(map[int64]Sample)
+	for _, period := range splitPeriod(lastUpdated, now, newUpDuration-upDuration) {
+		unwritten[period.Start.Unix()] = period
+	}
+
+	var samples []Sample
+	for windowStart := WindowStart(start); windowStart.Before(end); windowStart = windowStart.Add(Window) {
+		sample, err := m.state.GetUptimeSample(nodeID, subnetID, windowStart)
+		if err != nil {
+			return nil, err
+		}
+		sample.Start = windowStart
+		if period, ok := unwritten[windowStart.Unix()]; ok {
+			sample.UpDuration += period.UpDuration
+			sample.ObservedDuration += period.ObservedDuration
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
 // updateSubnetUptime updates the subnet uptime of the node on the state by the amount
 // of time that the node has been connected to the subnet.
 func (m *manager) updateSubnetUptime(nodeID ids.NodeID, subnetID ids.ID) error {
@@ -210,7 +246,7 @@ func (m *manager) updateSubnetUptime(nodeID ids.NodeID, subnetID ids.ID) error {
 		return nil
 	}
 
-	newDuration, newLastUpdated, err := m.CalculateUptime(nodeID, subnetID)
+	upDuration, lastUpdated, err := m.state.GetUptime(nodeID, subnetID)
 	if err == database.ErrNotFound {
 		// If a non-validator disconnects, we don't care
 		return nil
@@ -219,5 +255,43 @@ func (m *manager) updateSubnetUptime(nodeID ids.NodeID, subnetID ids.ID) error {
 		return err
 	}
 
-	return m.state.SetUptime(nodeID, subnetID, newDuration, newLastUpdated)
+	newDuration, newLastUpdated, err := m.CalculateUptime(nodeID, subnetID)
+	if err != nil {
+		return err
+	}
+
+	return m.setUptime(nodeID, subnetID, upDuration, lastUpdated, newDuration, newLastUpdated)
+}
+
+// setUptime updates the uptime of the node on the state from [upDuration] at
+// [lastUpdated] to [newUpDuration] at [newLastUpdated], and adds the change to
+// the uptime samples of the windows it overlaps.
+//
+// Because a node is only credited for the time since it most recently
+// connected, the change in the up duration is always the end of the period.
+func (m *manager) setUptime(
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	upDuration time.Duration,
+	lastUpdated time.Time,
+	newUpDuration time.Duration,
+	newLastUpdated time.Time,
+) error {
+	if err := m.state.SetUptime(nodeID, subnetID, newUpDuration, newLastUpdated); err != nil {
+		return err
+	}
+
+	for _, period := range splitPeriod(lastUpdated, newLastUpdated, newUpDuration-upDuration) {
+		sample, err := m.state.GetUptimeSample(nodeID, subnetID, period.Start)
+		if err != nil {
+			return err
+		}
+		sample.Start = period.Start
+		sample.UpDuration += period.UpDuration
+		sample.ObservedDuration += period.ObservedDuration
+		if err := m.state.SetUptimeSample(nodeID, subnetID, sample); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -630,3 +630,68 @@ func TestStopTrackingUnixTimeRegression(t *testing.T) {
 	require.NoError(err)
 	require.GreaterOrEqual(float64(1), perc)
 }
+
+func TestCalculateUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	day0 := WindowStart(time.Now())
+	day1 := day0.Add(Window)
+	day2 := day1.Add(Window)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, subnetID, day0)
+
+	clk := mockable.Clock{}
+	up := NewManager(s, &clk)
+
+	// The time before tracking started is considered online.
+	clk.Set(day0.Add(12 * time.Hour))
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}, subnetID))
+
+	clk.Set(day0.Add(18 * time.Hour))
+	require.NoError(up.Connect(nodeID0, subnetID))
+
+	clk.Set(day1.Add(6 * time.Hour))
+	require.NoError(up.Disconnect(nodeID0))
+
+	clk.Set(day1.Add(12 * time.Hour))
+	samples, err := up.CalculateUptimeHistory(nodeID0, subnetID, day0, day2)
+	require.NoError(err)
+	require.Equal(
+		[]Sample{
+			{
+				Start:            day0,
+				UpDuration:       18 * time.Hour,
+				ObservedDuration: 24 * time.Hour,
+			},
+			{
+				Start:            day1,
+				UpDuration:       6 * time.Hour,
+				ObservedDuration: 12 * time.Hour,
+			},
+		},
+		samples,
+	)
+	require.InDelta(0.75, samples[0].Uptime(), 0)
+	require.InDelta(0.5, samples[1].Uptime(), 0)
+
+	// The uptime that was written to the state matches the uptime of the
+	// samples.
+	duration, _, err := up.CalculateUptime(nodeID0, subnetID)
+	require.NoError(err)
+	require.Equal(24*time.Hour, duration)
+}
+
+func TestCalculateUptimeHistoryNonValidator(t *testing.T) {
+	require := require.New(t)
+
+	s := NewTestState()
+	clk := mockable.Clock{}
+	up := NewManager(s, &clk)
+
+	start := WindowStart(time.Now())
+	_, err := up.CalculateUptimeHistory(ids.GenerateTestNodeID(), ids.GenerateTestID(), start, start.Add(Window))
+	require.ErrorIs(err, database.ErrNotFound)
+}
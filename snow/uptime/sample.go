@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import "time"
+
+// Window is the duration of the windows that uptime samples are bucketed
+// into.
+const Window = 24 * time.Hour
+
+// Sample is the uptime of a node during a window.
+type Sample struct {
+	// Start of the window
+	Start time.Time
+	// UpDuration is the amount of time during the window that the node was
+	// considered online.
+	UpDuration time.Duration
+	// ObservedDuration is the amount of time during the window that the uptime
+	// of the node was measured.
+	ObservedDuration time.Duration
+}
+
+// Uptime returns the fraction of the observed duration that the node was
+// considered online. If nothing was observed, 1 is returned.
+func (s Sample) Uptime() float64 {
+	if s.ObservedDuration == 0 {
+		return 1
+	}
+	return float64(s.UpDuration) / float64(s.ObservedDuration)
+}
+
+// WindowStart returns the start of the window that contains [t].
+func WindowStart(t time.Time) time.Time {
+	return t.Truncate(Window)
+}
+
+// splitPeriod returns the samples of each window overlapping the period from
+// [start] to [end], where the node was considered online for the final
+// [upDuration] of the period.
+func splitPeriod(start, end time.Time, upDuration time.Duration) []Sample {
+	upStart := end.Add(-upDuration)
+
+	var samples []Sample
+	for windowStart := WindowStart(start); windowStart.Before(end); windowStart = windowStart.Add(Window) {
+		windowEnd := windowStart.Add(Window)
+		samples = append(samples, Sample{
+			Start:            windowStart,
+			UpDuration:       overlap(upStart, end, windowStart, windowEnd),
+			ObservedDuration: overlap(start, end, windowStart, windowEnd),
+		})
+	}
+	return samples
+}
+
+// overlap returns the duration that [aStart, aEnd) and [bStart, bEnd) have in
+// common.
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	start := aStart
+	if bStart.After(start) {
+		start = bStart
+	}
+	end := aEnd
+	if bEnd.Before(end) {
+		end = bEnd
+	}
+	if !start.Before(end) {
+		return 0
+	}
+	return end.Sub(start)
+}
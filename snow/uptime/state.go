@@ -40,4 +40,23 @@ type State interface {
 		nodeID ids.NodeID,
 		subnetID ids.ID,
 	) (startTime time.Time, err error)
+
+	// GetUptimeSample returns the uptime of [nodeID] on [subnetID] that was
+	// recorded during the window starting at [windowStart]. If no uptime was
+	// recorded during the window, an empty sample is returned.
+	GetUptimeSample(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		windowStart time.Time,
+	) (Sample, error)
+
+	// SetUptimeSample updates the uptime of [nodeID] on [subnetID] that was
+	// recorded during the window starting at [sample.Start].
+	// Returns [database.ErrNotFound] if [nodeID] isn't currently a validator of
+	// the subnet.
+	SetUptimeSample(
+		nodeID ids.NodeID,
+		subnetID ids.ID,
+		sample Sample,
+	) error
 }
@@ -16,6 +16,7 @@ type uptime struct {
 	upDuration  time.Duration
 	lastUpdated time.Time
 	startTime   time.Time
+	samples     map[int64]Sample // window start -> sample
 }
 
 type TestState struct {
@@ -40,6 +41,7 @@ func (s *TestState) AddNode(nodeID ids.NodeID, subnetID ids.ID, startTime time.T
 	subnetUptimes[subnetID] = &uptime{
 		lastUpdated: st,
 		startTime:   st,
+		samples:     make(map[int64]Sample),
 	}
 }
 
@@ -68,3 +70,24 @@ func (s *TestState) GetStartTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time,
 	}
 	return up.startTime, s.dbReadError
 }
+
+func (s *TestState) GetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, windowStart time.Time) (Sample, error) {
+	up, exists := s.nodes[nodeID][subnetID]
+	if !exists {
+		return Sample{Start: windowStart}, s.dbReadError
+	}
+	sample, exists := up.samples[windowStart.Unix()]
+	if !exists {
+		sample.Start = windowStart
+	}
+	return sample, s.dbReadError
+}
+
+func (s *TestState) SetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, sample Sample) error {
+	up, exists := s.nodes[nodeID][subnetID]
+	if !exists {
+		return database.ErrNotFound
+	}
+	up.samples[sample.Start.Unix()] = sample
+	return s.dbWriteError
+}
@@ -10,10 +10,14 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/utils/set"
 )
 
-var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+var (
+	errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+	errInvalidPollStrategy              = errors.New("invalid poll strategy")
+)
 
 type Config struct {
 	// ValidatorOnly indicates that this Subnet's Chains are available to only subnet validators.
@@ -25,6 +29,9 @@ type Config struct {
 	// ValidatorOnly is enabled.
 	AllowedNodes        set.Set[ids.NodeID] `json:"allowedNodes"        yaml:"allowedNodes"`
 	ConsensusParameters snowball.Parameters `json:"consensusParameters" yaml:"consensusParameters"`
+	// PollStrategy determines when the polls of this Subnet's snowman chains
+	// finish.
+	PollStrategy poll.StrategyConfig `json:"pollStrategy" yaml:"pollStrategy"`
 
 	// ProposerMinBlockDelay is the minimum delay this node will enforce when
 	// building a snowman++ block.
@@ -55,6 +62,9 @@ func (c *Config) Valid() error {
 	if err := c.ConsensusParameters.Verify(); err != nil {
 		return fmt.Errorf("consensus %w", err)
 	}
+	if err := c.PollStrategy.Verify(); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPollStrategy, err)
+	}
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
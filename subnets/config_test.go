@@ -10,6 +10,7 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowball"
+	"github.com/f01c5700/avalanchego/snow/consensus/snowman/poll"
 	"github.com/f01c5700/avalanchego/utils/set"
 )
 
@@ -49,6 +50,16 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "invalid poll strategy",
+			s: Config{
+				ConsensusParameters: validParameters,
+				PollStrategy: poll.StrategyConfig{
+					Strategy: poll.EarlyTermLatencyAware,
+				},
+			},
+			expectedErr: errInvalidPollStrategy,
+		},
 		{
 			name: "valid",
 			s: Config{
@@ -6,6 +6,7 @@ package tmpnet
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/f01c5700/avalanchego/config"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/networking/sender"
 	"github.com/f01c5700/avalanchego/staking"
 	"github.com/f01c5700/avalanchego/utils/crypto/bls"
 	"github.com/f01c5700/avalanchego/vms/platformvm/signer"
@@ -240,6 +242,21 @@ func (n *Node) SetNetworkingConfig(bootstrapIDs []string, bootstrapIPs []string)
 	n.Flags[config.BootstrapIPsKey] = strings.Join(bootstrapIPs, ",")
 }
 
+// Configures the node's snowman engines to deviate from the protocol so that
+// tests can observe how honest nodes react to a byzantine peer. Takes effect
+// the next time the node is started.
+func (n *Node) SetByzantineConfig(byzantineConfig sender.ByzantineConfig) error {
+	if err := byzantineConfig.Verify(); err != nil {
+		return err
+	}
+	configBytes, err := json.Marshal(byzantineConfig)
+	if err != nil {
+		return err
+	}
+	n.Flags[config.ConsensusByzantineConfigKey] = string(configBytes)
+	return nil
+}
+
 // Ensures staking and signing keys are generated if not already present and
 // that the node ID (derived from the staking keypair) is set.
 func (n *Node) EnsureKeys() error {
@@ -44,6 +44,7 @@ const (
 	DefaultNetworkMaximumInboundTimeout = 10 * time.Second
 	DefaultNetworkTimeoutHalflife       = 5 * time.Minute
 	DefaultNetworkTimeoutCoefficient    = 2
+	DefaultNetworkMaxPeerTimeouts       = 4096
 	DefaultNetworkReadHandshakeTimeout  = 15 * time.Second
 
 	DefaultNetworkCompressionType           = compression.TypeZstd
@@ -52,6 +53,18 @@ const (
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
 
+	// Outbound Queue Prioritization
+	DefaultNetworkOutboundQueuePrioritized        = false
+	DefaultNetworkOutboundQueueQuantum            = 64 * units.KiB
+	DefaultNetworkOutboundQueueConsensusWeight    = 8
+	DefaultNetworkOutboundQueueBootstrapWeight    = 2
+	DefaultNetworkOutboundQueueAppRequestWeight   = 4
+	DefaultNetworkOutboundQueueAppGossipWeight    = 1
+	DefaultNetworkOutboundQueueConsensusMaxBytes  = 0
+	DefaultNetworkOutboundQueueBootstrapMaxBytes  = 16 * units.MiB
+	DefaultNetworkOutboundQueueAppRequestMaxBytes = 8 * units.MiB
+	DefaultNetworkOutboundQueueAppGossipMaxBytes  = 4 * units.MiB
+
 	DefaultNetworkTCPProxyEnabled = false
 
 	// The PROXY protocol specification recommends setting this value to be at
@@ -6,6 +6,7 @@ package timer
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,15 +14,21 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/utils/heap"
+	"github.com/f01c5700/avalanchego/utils/linked"
 	"github.com/f01c5700/avalanchego/utils/math"
 	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 )
 
+const numTimeoutBuckets = 10
+
 var (
 	errNonPositiveHalflife        = errors.New("timeout halflife must be positive")
 	errInitialTimeoutAboveMaximum = errors.New("initial timeout cannot be greater than maximum timeout")
 	errInitialTimeoutBelowMinimum = errors.New("initial timeout cannot be less than minimum timeout")
 	errTooSmallTimeoutCoefficient = errors.New("timeout coefficient must be >= 1")
+	errNegativeMaxPeerTimeouts    = errors.New("max peer timeouts must be non-negative")
+
+	opLabels = []string{"op"}
 
 	_ AdaptiveTimeoutManager = (*adaptiveTimeoutManager)(nil)
 )
@@ -32,6 +39,27 @@ type adaptiveTimeout struct {
 	duration       time.Duration // How long this timeout was set for
 	deadline       time.Time     // When this timeout should be fired
 	measureLatency bool          // Whether this request should impact latency
+	networkTimeout time.Duration // The network timeout when this timeout was set
+}
+
+// peerOp identifies the requests whose timeouts are estimated together.
+type peerOp struct {
+	nodeID ids.NodeID
+	op     byte
+}
+
+// PeerTimeout is the estimated timeout of the requests with [Op] sent to
+// [NodeID].
+type PeerTimeout struct {
+	NodeID  ids.NodeID
+	Op      byte
+	Timeout time.Duration
+}
+
+type peerTimeout struct {
+	// Averages the response time of the requests to this peer with this op
+	averager math.Averager
+	timeout  time.Duration
 }
 
 // AdaptiveTimeoutConfig contains the parameters provided to the
@@ -46,6 +74,14 @@ type AdaptiveTimeoutConfig struct {
 	// Larger halflife --> less volatile timeout
 	// [timeoutHalfLife] must be positive
 	TimeoutHalflife time.Duration `json:"timeoutHalflife"`
+	// MaxPeerTimeouts is the maximum number of (peer, op) pairs whose timeouts
+	// are estimated from their own response times. Requests to other pairs
+	// use the timeout estimated from the response times of all peers. If 0,
+	// the same timeout is used for all requests.
+	MaxPeerTimeouts int `json:"maxPeerTimeouts"`
+	// FormatOp formats the op of a request in metrics. If nil, the op is
+	// formatted as a number.
+	FormatOp func(op byte) string `json:"-"`
 }
 
 type AdaptiveTimeoutManager interface {
@@ -58,6 +94,12 @@ type AdaptiveTimeoutManager interface {
 	Stop()
 	// Returns the current network timeout duration.
 	TimeoutDuration() time.Duration
+	// Returns the timeout duration of requests to [nodeID] with [op]. If
+	// there is no estimate for the pair, the network timeout is returned.
+	PeerTimeoutDuration(nodeID ids.NodeID, op byte) time.Duration
+	// Returns the (peer, op) pairs whose timeouts are estimated from their own
+	// response times, ordered from least to most recently observed.
+	PeerTimeouts() []PeerTimeout
 	// Registers a timeout for the item with the given [id].
 	// If the timeout occurs before the item is Removed, [timeoutHandler] is called.
 	Put(id ids.RequestID, measureLatency bool, timeoutHandler func())
@@ -85,8 +127,15 @@ type adaptiveTimeoutManager struct {
 	minimumTimeout     time.Duration
 	maximumTimeout     time.Duration
 	currentTimeout     time.Duration // Amount of time before a timeout
+	timeoutHalflife    time.Duration
 	timeoutHeap        heap.Map[ids.RequestID, *adaptiveTimeout]
 	timer              *Timer // Timer that will fire to clear the timeouts
+
+	maxPeerTimeouts int
+	// Per (peer, op) estimates, ordered from least to most recently observed
+	peerTimeouts         *linked.Hashmap[peerOp, *peerTimeout]
+	requestTimeoutMetric *prometheus.HistogramVec
+	formatOp             func(byte) string
 }
 
 func NewAdaptiveTimeoutManager(
@@ -102,6 +151,15 @@ func NewAdaptiveTimeoutManager(
 		return nil, fmt.Errorf("%w: %f", errTooSmallTimeoutCoefficient, config.TimeoutCoefficient)
 	case config.TimeoutHalflife <= 0:
 		return nil, errNonPositiveHalflife
+	case config.MaxPeerTimeouts < 0:
+		return nil, fmt.Errorf("%w: %d", errNegativeMaxPeerTimeouts, config.MaxPeerTimeouts)
+	}
+
+	formatOp := config.FormatOp
+	if formatOp == nil {
+		formatOp = func(op byte) string {
+			return strconv.Itoa(int(op))
+		}
 	}
 
 	tm := &adaptiveTimeoutManager{
@@ -121,10 +179,22 @@ func NewAdaptiveTimeoutManager(
 			Name: "pending_timeouts",
 			Help: "Number of pending timeouts",
 		}),
+		requestTimeoutMetric: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "request_timeout",
+				Help:    "Duration of the timeouts of requests in nanoseconds",
+				Buckets: timeoutBuckets(config.MinimumTimeout, config.MaximumTimeout),
+			},
+			opLabels,
+		),
 		minimumTimeout:     config.MinimumTimeout,
 		maximumTimeout:     config.MaximumTimeout,
 		currentTimeout:     config.InitialTimeout,
 		timeoutCoefficient: config.TimeoutCoefficient,
+		timeoutHalflife:    config.TimeoutHalflife,
+		maxPeerTimeouts:    config.MaxPeerTimeouts,
+		peerTimeouts:       linked.NewHashmap[peerOp, *peerTimeout](),
+		formatOp:           formatOp,
 		timeoutHeap: heap.NewMap[ids.RequestID, *adaptiveTimeout](func(a, b *adaptiveTimeout) bool {
 			return a.deadline.Before(b.deadline)
 		}),
@@ -137,6 +207,7 @@ func NewAdaptiveTimeoutManager(
 		reg.Register(tm.avgLatency),
 		reg.Register(tm.numTimeouts),
 		reg.Register(tm.numPendingTimeouts),
+		reg.Register(tm.requestTimeoutMetric),
 	)
 	return tm, err
 }
@@ -148,6 +219,43 @@ func (tm *adaptiveTimeoutManager) TimeoutDuration() time.Duration {
 	return tm.currentTimeout
 }
 
+func (tm *adaptiveTimeoutManager) PeerTimeoutDuration(nodeID ids.NodeID, op byte) time.Duration {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	return tm.timeoutDuration(peerOp{
+		nodeID: nodeID,
+		op:     op,
+	})
+}
+
+func (tm *adaptiveTimeoutManager) PeerTimeouts() []PeerTimeout {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	var (
+		timeouts = make([]PeerTimeout, 0, tm.peerTimeouts.Len())
+		iter     = tm.peerTimeouts.NewIterator()
+	)
+	for iter.Next() {
+		key := iter.Key()
+		timeouts = append(timeouts, PeerTimeout{
+			NodeID:  key.nodeID,
+			Op:      key.op,
+			Timeout: iter.Value().timeout,
+		})
+	}
+	return timeouts
+}
+
+// Assumes [tm.lock] is held
+func (tm *adaptiveTimeoutManager) timeoutDuration(key peerOp) time.Duration {
+	if estimate, ok := tm.peerTimeouts.Get(key); ok {
+		return estimate.timeout
+	}
+	return tm.currentTimeout
+}
+
 func (tm *adaptiveTimeoutManager) Dispatch() {
 	tm.timer.Dispatch()
 }
@@ -166,16 +274,22 @@ func (tm *adaptiveTimeoutManager) Put(id ids.RequestID, measureLatency bool, tim
 // Assumes [tm.lock] is held
 func (tm *adaptiveTimeoutManager) put(id ids.RequestID, measureLatency bool, handler func()) {
 	now := tm.clock.Time()
-	tm.remove(id, now)
+	tm.remove(id, now, false /*=timedOut*/)
 
+	duration := tm.timeoutDuration(peerOp{
+		nodeID: id.NodeID,
+		op:     id.Op,
+	})
 	timeout := &adaptiveTimeout{
 		id:             id,
 		handler:        handler,
-		duration:       tm.currentTimeout,
-		deadline:       now.Add(tm.currentTimeout),
+		duration:       duration,
+		deadline:       now.Add(duration),
 		measureLatency: measureLatency,
+		networkTimeout: tm.currentTimeout,
 	}
 	tm.timeoutHeap.Push(id, timeout)
+	tm.requestTimeoutMetric.WithLabelValues(tm.formatOp(id.Op)).Observe(float64(duration))
 	tm.numPendingTimeouts.Set(float64(tm.timeoutHeap.Len()))
 
 	tm.setNextTimeoutTime()
@@ -185,11 +299,14 @@ func (tm *adaptiveTimeoutManager) Remove(id ids.RequestID) {
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
-	tm.remove(id, tm.clock.Time())
+	tm.remove(id, tm.clock.Time(), false /*=timedOut*/)
 }
 
+// remove the timeout associated with [id]. If [timedOut] is true, the request
+// timed out rather than received a response.
+//
 // Assumes [tm.lock] is held
-func (tm *adaptiveTimeoutManager) remove(id ids.RequestID, now time.Time) {
+func (tm *adaptiveTimeoutManager) remove(id ids.RequestID, now time.Time, timedOut bool) {
 	// Observe the response time to update average network response time.
 	timeout, exists := tm.timeoutHeap.Remove(id)
 	if !exists {
@@ -199,7 +316,24 @@ func (tm *adaptiveTimeoutManager) remove(id ids.RequestID, now time.Time) {
 	if timeout.measureLatency {
 		timeoutRegisteredAt := timeout.deadline.Add(-1 * timeout.duration)
 		latency := now.Sub(timeoutRegisteredAt)
-		tm.observeLatencyAndUpdateTimeout(latency, now)
+		// A timed out request only tells us that the peer took longer than
+		// its timeout. Observing the timeout as its latency would ratchet the
+		// peer's timeout up to the maximum.
+		if !timedOut {
+			tm.observePeerLatency(
+				peerOp{
+					nodeID: id.NodeID,
+					op:     id.Op,
+				},
+				latency,
+				now,
+			)
+		}
+		// Without per-peer estimates, a request slower than the network
+		// timeout would have timed out. Capping the latency keeps peers with
+		// longer estimated timeouts from increasing the network timeout any
+		// more than they otherwise would.
+		tm.observeLatencyAndUpdateTimeout(min(latency, timeout.networkTimeout), now)
 	}
 	tm.numPendingTimeouts.Set(float64(tm.timeoutHeap.Len()))
 }
@@ -237,17 +371,58 @@ func (tm *adaptiveTimeoutManager) ObserveLatency(latency time.Duration) {
 func (tm *adaptiveTimeoutManager) observeLatencyAndUpdateTimeout(latency time.Duration, now time.Time) {
 	tm.averager.Observe(float64(latency), now)
 	avgLatency := tm.averager.Read()
-	tm.currentTimeout = time.Duration(tm.timeoutCoefficient * avgLatency)
-	if tm.currentTimeout > tm.maximumTimeout {
-		tm.currentTimeout = tm.maximumTimeout
-	} else if tm.currentTimeout < tm.minimumTimeout {
-		tm.currentTimeout = tm.minimumTimeout
-	}
+	tm.currentTimeout = tm.timeoutFromLatency(avgLatency)
 	// Update the metrics
 	tm.networkTimeoutMetric.Set(float64(tm.currentTimeout))
 	tm.avgLatency.Set(avgLatency)
 }
 
+// observePeerLatency updates the estimated timeout of [key]. If [key] doesn't
+// have an estimate yet, its estimate starts from the average latency of all
+// peers.
+//
+// Assumes [tm.lock] is held
+func (tm *adaptiveTimeoutManager) observePeerLatency(key peerOp, latency time.Duration, now time.Time) {
+	if tm.maxPeerTimeouts == 0 {
+		return
+	}
+
+	estimate, ok := tm.peerTimeouts.Get(key)
+	if !ok {
+		if tm.peerTimeouts.Len() >= tm.maxPeerTimeouts {
+			evicted, _, _ := tm.peerTimeouts.Oldest()
+			tm.peerTimeouts.Delete(evicted)
+		}
+		estimate = &peerTimeout{
+			averager: math.NewAverager(tm.averager.Read(), tm.timeoutHalflife, now),
+		}
+	}
+
+	estimate.averager.Observe(float64(latency), now)
+	estimate.timeout = tm.timeoutFromLatency(estimate.averager.Read())
+	tm.peerTimeouts.Put(key, estimate)
+}
+
+// timeoutFromLatency returns the timeout to use for requests with an average
+// latency of [avgLatency].
+func (tm *adaptiveTimeoutManager) timeoutFromLatency(avgLatency float64) time.Duration {
+	timeout := time.Duration(tm.timeoutCoefficient * avgLatency)
+	return min(max(timeout, tm.minimumTimeout), tm.maximumTimeout)
+}
+
+// timeoutBuckets returns the histogram buckets of request timeouts between
+// [minimumTimeout] and [maximumTimeout].
+func timeoutBuckets(minimumTimeout, maximumTimeout time.Duration) []float64 {
+	if minimumTimeout <= 0 || minimumTimeout >= maximumTimeout {
+		return []float64{float64(maximumTimeout)}
+	}
+	return prometheus.ExponentialBucketsRange(
+		float64(minimumTimeout),
+		float64(maximumTimeout),
+		numTimeoutBuckets,
+	)
+}
+
 // Returns the handler function associated with the next timeout.
 // If there are no timeouts, or if the next timeout is after [now],
 // returns nil.
@@ -260,7 +435,7 @@ func (tm *adaptiveTimeoutManager) getNextTimeoutHandler(now time.Time) func() {
 	if nextTimeout.deadline.After(now) {
 		return nil
 	}
-	tm.remove(nextTimeout.id, now)
+	tm.remove(nextTimeout.id, now, true /*=timedOut*/)
 	return nextTimeout.handler
 }
 
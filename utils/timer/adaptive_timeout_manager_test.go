@@ -71,6 +71,17 @@ func TestAdaptiveTimeoutManagerInit(t *testing.T) {
 			},
 			expectedErr: errNonPositiveHalflife,
 		},
+		{
+			config: AdaptiveTimeoutConfig{
+				InitialTimeout:     2 * time.Second,
+				MinimumTimeout:     2 * time.Second,
+				MaximumTimeout:     3 * time.Second,
+				TimeoutCoefficient: 1,
+				TimeoutHalflife:    5 * time.Minute,
+				MaxPeerTimeouts:    -1,
+			},
+			expectedErr: errNegativeMaxPeerTimeouts,
+		},
 		{
 			config: AdaptiveTimeoutConfig{
 				InitialTimeout:     2 * time.Second,
@@ -131,3 +142,172 @@ func TestAdaptiveTimeoutManager(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAdaptiveTimeoutManagerPeerTimeouts(t *testing.T) {
+	require := require.New(t)
+
+	tmIntf, err := NewAdaptiveTimeoutManager(
+		&AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     time.Hour,
+			TimeoutHalflife:    5 * time.Minute,
+			TimeoutCoefficient: 2,
+			MaxPeerTimeouts:    2,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tm := tmIntf.(*adaptiveTimeoutManager)
+
+	now := time.Now()
+	tm.clock.Set(now)
+
+	var (
+		slowPeer   = ids.GenerateTestNodeID()
+		fastPeer   = ids.GenerateTestNodeID()
+		unseenPeer = ids.GenerateTestNodeID()
+		op         = byte(1)
+	)
+
+	// respond is used to record a response from [nodeID] after [latency].
+	respond := func(nodeID ids.NodeID, latency time.Duration) {
+		requestID := ids.RequestID{
+			NodeID: nodeID,
+			Op:     op,
+		}
+		tm.Put(requestID, true, func() {})
+		now = now.Add(latency)
+		tm.clock.Set(now)
+		tm.Remove(requestID)
+	}
+
+	for i := 0; i < 10; i++ {
+		respond(slowPeer, 10*time.Second)
+		respond(fastPeer, 10*time.Millisecond)
+	}
+
+	slowTimeout := tm.PeerTimeoutDuration(slowPeer, op)
+	fastTimeout := tm.PeerTimeoutDuration(fastPeer, op)
+	globalTimeout := tm.TimeoutDuration()
+	require.Greater(slowTimeout, globalTimeout)
+	require.Less(fastTimeout, globalTimeout)
+
+	// Peers and ops without any observations use the global estimate
+	require.Equal(globalTimeout, tm.PeerTimeoutDuration(unseenPeer, op))
+	require.Equal(globalTimeout, tm.PeerTimeoutDuration(slowPeer, op+1))
+
+	require.Equal(
+		[]PeerTimeout{
+			{NodeID: slowPeer, Op: op, Timeout: slowTimeout},
+			{NodeID: fastPeer, Op: op, Timeout: fastTimeout},
+		},
+		tm.PeerTimeouts(),
+	)
+
+	// Observing a third (peer, op) evicts the least recently observed one
+	respond(unseenPeer, time.Second)
+	require.Equal(2, tm.peerTimeouts.Len())
+	require.Equal(tm.TimeoutDuration(), tm.PeerTimeoutDuration(slowPeer, op))
+	require.NotEqual(tm.TimeoutDuration(), tm.PeerTimeoutDuration(fastPeer, op))
+
+	peerTimeouts := tm.PeerTimeouts()
+	require.Len(peerTimeouts, 2)
+	require.Equal(fastPeer, peerTimeouts[0].NodeID)
+	require.Equal(unseenPeer, peerTimeouts[1].NodeID)
+}
+
+func TestAdaptiveTimeoutManagerTimedOutPeerRequest(t *testing.T) {
+	require := require.New(t)
+
+	tmIntf, err := NewAdaptiveTimeoutManager(
+		&AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     time.Hour,
+			TimeoutHalflife:    5 * time.Minute,
+			TimeoutCoefficient: 2,
+			MaxPeerTimeouts:    1,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tm := tmIntf.(*adaptiveTimeoutManager)
+
+	now := time.Now()
+	tm.clock.Set(now)
+
+	requestID := ids.RequestID{
+		NodeID: ids.GenerateTestNodeID(),
+		Op:     1,
+	}
+	tm.Put(requestID, true, func() {})
+	now = now.Add(100 * time.Millisecond)
+	tm.clock.Set(now)
+	tm.Remove(requestID)
+
+	peerTimeout := tm.PeerTimeoutDuration(requestID.NodeID, requestID.Op)
+
+	var timedOut bool
+	tm.Put(requestID, true, func() {
+		timedOut = true
+	})
+	now = now.Add(peerTimeout)
+	tm.clock.Set(now)
+	tm.timeout()
+	require.True(timedOut)
+
+	// The timed out request must not increase the peer's timeout
+	require.Equal(peerTimeout, tm.PeerTimeoutDuration(requestID.NodeID, requestID.Op))
+}
+
+func TestAdaptiveTimeoutManagerSlowPeerNetworkTimeout(t *testing.T) {
+	require := require.New(t)
+
+	newManager := func(maxPeerTimeouts int) *adaptiveTimeoutManager {
+		tm, err := NewAdaptiveTimeoutManager(
+			&AdaptiveTimeoutConfig{
+				InitialTimeout:     time.Second,
+				MinimumTimeout:     time.Millisecond,
+				MaximumTimeout:     time.Hour,
+				TimeoutHalflife:    5 * time.Minute,
+				TimeoutCoefficient: 2,
+				MaxPeerTimeouts:    maxPeerTimeouts,
+			},
+			prometheus.NewRegistry(),
+		)
+		require.NoError(err)
+		return tm.(*adaptiveTimeoutManager)
+	}
+
+	var (
+		withPeerTimeouts    = newManager(1)
+		withoutPeerTimeouts = newManager(0)
+		slowPeer            = ids.GenerateTestNodeID()
+		now                 = time.Now()
+	)
+	for i := 0; i < 10; i++ {
+		requestID := ids.RequestID{
+			NodeID:    slowPeer,
+			RequestID: uint32(i),
+		}
+		for _, tm := range []*adaptiveTimeoutManager{withPeerTimeouts, withoutPeerTimeouts} {
+			tm.clock.Set(now)
+			tm.Put(requestID, true, func() {})
+			tm.clock.Set(now.Add(10 * time.Second))
+			tm.Remove(requestID)
+		}
+		now = now.Add(10 * time.Second)
+	}
+
+	// The slow peer's estimate allows its requests to take longer than the
+	// network timeout, but its responses must not increase the network
+	// timeout more than they would without per-peer estimates.
+	require.Greater(withPeerTimeouts.PeerTimeoutDuration(slowPeer, 0), withPeerTimeouts.TimeoutDuration())
+	// The averagers are created at slightly different times.
+	require.InDelta(
+		float64(withoutPeerTimeouts.TimeoutDuration()),
+		float64(withPeerTimeouts.TimeoutDuration()),
+		float64(time.Millisecond),
+	)
+}
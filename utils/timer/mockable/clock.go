@@ -12,8 +12,15 @@ var MaxTime = time.Unix(1<<63-62135596801, 0) // 0 is used because we drop the n
 type Clock struct {
 	faked bool
 	time  time.Time
+	// source, if non-nil, provides the time and the timers of this clock.
+	source Source
 }
 
+// SetSource makes this clock, and every copy of it made afterwards, report the
+// time of [source] and create timers with [source]. While a source is set, Set
+// and Sync have no effect.
+func (c *Clock) SetSource(source Source) { c.source = source }
+
 // Set the time on the clock
 func (c *Clock) Set(time time.Time) { c.faked = true; c.time = time }
 
@@ -22,6 +29,9 @@ func (c *Clock) Sync() { c.faked = false }
 
 // Time returns the time on this clock
 func (c *Clock) Time() time.Time {
+	if c.source != nil {
+		return c.source.Now()
+	}
 	if c.faked {
 		return c.time
 	}
@@ -42,3 +52,21 @@ func (c *Clock) Unix() uint64 {
 	}
 	return uint64(unix)
 }
+
+// NewTicker returns a ticker that ticks every [d]. Unless a source is set, the
+// ticker runs on the wall clock, even if the time of this clock is faked.
+func (c *Clock) NewTicker(d time.Duration) Ticker {
+	if c.source != nil {
+		return c.source.NewTicker(d)
+	}
+	return &wallTicker{ticker: time.NewTicker(d)}
+}
+
+// NewTimer returns a timer that fires after [d]. Unless a source is set, the
+// timer runs on the wall clock, even if the time of this clock is faked.
+func (c *Clock) NewTimer(d time.Duration) Timer {
+	if c.source != nil {
+		return c.source.NewTimer(d)
+	}
+	return &wallTimer{timer: time.NewTimer(d)}
+}
@@ -23,7 +23,7 @@ func TestClockSet(t *testing.T) {
 func TestClockSync(t *testing.T) {
 	require := require.New(t)
 
-	clock := Clock{true, time.Unix(0, 0)}
+	clock := Clock{faked: true, time: time.Unix(0, 0)}
 	clock.Sync()
 	require.False(clock.faked)
 	require.NotEqual(time.Unix(0, 0), clock.Time())
@@ -32,13 +32,62 @@ func TestClockSync(t *testing.T) {
 func TestClockUnixTime(t *testing.T) {
 	require := require.New(t)
 
-	clock := Clock{true, time.Unix(123, 123)}
+	clock := Clock{faked: true, time: time.Unix(123, 123)}
 	require.Zero(clock.UnixTime().Nanosecond())
 	require.Equal(123, clock.Time().Nanosecond())
 }
 
 func TestClockUnix(t *testing.T) {
-	clock := Clock{true, time.Unix(-14159040, 0)}
+	clock := Clock{faked: true, time: time.Unix(-14159040, 0)}
 	actual := clock.Unix()
 	require.Zero(t, actual) // time prior to Unix epoch should be clamped to 0
 }
+
+type testSource struct {
+	now time.Time
+}
+
+func (s testSource) Now() time.Time {
+	return s.now
+}
+
+func (testSource) NewTicker(time.Duration) Ticker {
+	return nil
+}
+
+func (testSource) NewTimer(time.Duration) Timer {
+	return nil
+}
+
+func TestClockSource(t *testing.T) {
+	require := require.New(t)
+
+	source := testSource{now: time.Unix(123, 0)}
+	clock := Clock{}
+	clock.SetSource(source)
+	require.Equal(source.now, clock.Time())
+
+	// The source takes precedence over a faked time.
+	clock.Set(time.Unix(456, 0))
+	require.Equal(source.now, clock.Time())
+
+	// Copies of the clock share the source.
+	clockCopy := clock
+	require.Equal(source.now, clockCopy.Time())
+	require.Nil(clockCopy.NewTimer(time.Second))
+}
+
+func TestClockWallTimers(t *testing.T) {
+	require := require.New(t)
+
+	clock := Clock{}
+	clock.Set(time.Unix(0, 0))
+
+	timer := clock.NewTimer(0)
+	<-timer.C()
+	require.False(timer.Stop())
+
+	ticker := clock.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mockable
+
+import "time"
+
+var (
+	_ Ticker = (*wallTicker)(nil)
+	_ Timer  = (*wallTimer)(nil)
+)
+
+// Source provides the time of a Clock and the timers created by it. It allows
+// many components to share a virtual clock, such as in a simulation.
+//
+// Implementations must be safe for concurrent use.
+type Source interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a ticker that ticks every [d].
+	NewTicker(d time.Duration) Ticker
+	// NewTimer returns a timer that fires once after [d].
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker delivers ticks on C at intervals, like a [time.Ticker].
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer delivers a single tick on C, like a [time.Timer].
+type Timer interface {
+	C() <-chan time.Time
+	// Stop returns false if the timer already fired or was stopped.
+	Stop() bool
+}
+
+type wallTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *wallTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *wallTicker) Stop() {
+	t.ticker.Stop()
+}
+
+type wallTimer struct {
+	timer *time.Timer
+}
+
+func (t *wallTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *wallTimer) Stop() bool {
+	return t.timer.Stop()
+}
@@ -23,7 +23,8 @@ import (
 const maxClockSkew = 10 * time.Second
 
 var (
-	_ Block = (*block)(nil)
+	_ Block                 = (*block)(nil)
+	_ smblock.WithPreVerify = (*block)(nil)
 
 	errMissingParent         = errors.New("missing parent block")
 	errMissingChild          = errors.New("missing child block")
@@ -80,6 +81,17 @@ func (b *block) Verify(ctx context.Context) error {
 	return b.VerifyWithContext(ctx, nil)
 }
 
+// PreVerify recovers the senders of the transactions. The recovered public keys
+// are cached, so that recovering the senders during Verify is cheaper.
+func (b *block) PreVerify(context.Context) error {
+	for _, tx := range b.Txs {
+		if _, err := tx.SenderID(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *block) Accept(context.Context) error {
 	if err := b.state.Commit(); err != nil {
 		return err
@@ -99,13 +111,17 @@ func (b *block) Accept(context.Context) error {
 
 	b.chain.lastAcceptedID = b.id
 	b.chain.lastAcceptedHeight = b.Height()
+	b.chain.verifiedBlocksLock.Lock()
 	delete(b.chain.verifiedBlocks, b.ParentID)
+	b.chain.verifiedBlocksLock.Unlock()
 	b.state = nil
 	return nil
 }
 
 func (b *block) Reject(context.Context) error {
+	b.chain.verifiedBlocksLock.Lock()
 	delete(b.chain.verifiedBlocks, b.id)
+	b.chain.verifiedBlocksLock.Unlock()
 	b.state = nil
 
 	// TODO: push transactions back into the mempool
@@ -161,7 +177,9 @@ func (b *block) VerifyWithContext(ctx context.Context, blockContext *smblock.Con
 	if b.state == nil {
 		b.state = blkState
 		parent.verifiedChildrenIDs.Add(b.id)
+		b.chain.verifiedBlocksLock.Lock()
 		b.chain.verifiedBlocks[b.id] = b
+		b.chain.verifiedBlocksLock.Unlock()
 	}
 
 	return nil
@@ -4,6 +4,8 @@
 package chain
 
 import (
+	"sync"
+
 	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow"
@@ -33,6 +35,11 @@ type chain struct {
 
 	lastAcceptedID     ids.ID
 	lastAcceptedHeight uint64
+
+	// verifiedBlocksLock must be held when modifying verifiedBlocks or when
+	// reading it from NewBlock, which may be called concurrently with the
+	// processing of other blocks.
+	verifiedBlocksLock sync.RWMutex
 	verifiedBlocks     map[ids.ID]*block
 }
 
@@ -81,8 +88,11 @@ func (c *chain) NewBlock(blk *xsblock.Stateless) (Block, error) {
 		return nil, err
 	}
 
-	if blk, exists := c.verifiedBlocks[blkID]; exists {
-		return blk, nil
+	c.verifiedBlocksLock.RLock()
+	verifiedBlk, exists := c.verifiedBlocks[blkID]
+	c.verifiedBlocksLock.RUnlock()
+	if exists {
+		return verifiedBlk, nil
 	}
 
 	blkBytes, err := xsblock.Codec.Marshal(xsblock.CodecVersion, blk)
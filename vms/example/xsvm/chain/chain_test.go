@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/database/memdb"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
+	"github.com/f01c5700/avalanchego/snow/snowtest"
+	"github.com/f01c5700/avalanchego/utils/crypto/secp256k1"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/execute"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/genesis"
+	"github.com/f01c5700/avalanchego/vms/example/xsvm/tx"
+
+	xsblock "github.com/f01c5700/avalanchego/vms/example/xsvm/block"
+)
+
+// BenchmarkBlockExecution measures executing blocks of transfers in order, as
+// is done while bootstrapping, and pre-verifying them, which the bootstrapper
+// may do in parallel ahead of their execution. Executing a pre-verified block
+// doesn't need to recover the senders of its transactions.
+func BenchmarkBlockExecution(b *testing.B) {
+	// The number of transactions exceeds the size of the signature cache, so
+	// the senders recovered by previous iterations are evicted.
+	const (
+		numBlocks   = 128
+		txsPerBlock = 32
+	)
+
+	var (
+		chainID = ids.GenerateTestID()
+		keys    = make([]*secp256k1.PrivateKey, txsPerBlock)
+		g       = &genesis.Genesis{
+			Allocations: make([]genesis.Allocation, txsPerBlock),
+		}
+	)
+	for i := range keys {
+		key, err := secp256k1.NewPrivateKey()
+		require.NoError(b, err)
+
+		keys[i] = key
+		g.Allocations[i] = genesis.Allocation{
+			Address: key.Address(),
+			Balance: numBlocks,
+		}
+	}
+
+	genesisBlk, err := genesis.Block(g)
+	require.NoError(b, err)
+	parentID, err := genesisBlk.ID()
+	require.NoError(b, err)
+
+	blocks := make([]*xsblock.Stateless, numBlocks)
+	for i := range blocks {
+		txs := make([]*tx.Tx, txsPerBlock)
+		for j, key := range keys {
+			txs[j], err = tx.Sign(
+				&tx.Transfer{
+					ChainID: chainID,
+					Nonce:   uint64(i),
+					AssetID: chainID,
+					Amount:  1,
+				},
+				key,
+			)
+			require.NoError(b, err)
+		}
+
+		blocks[i] = &xsblock.Stateless{
+			ParentID: parentID,
+			Height:   uint64(i + 1),
+			Txs:      txs,
+		}
+		parentID, err = blocks[i].ID()
+		require.NoError(b, err)
+	}
+
+	var c Chain
+	parse := func(i int) *block {
+		if i%numBlocks == 0 {
+			db := memdb.New()
+			require.NoError(b, execute.Genesis(db, chainID, g))
+
+			c, err = New(snowtest.Context(b, chainID), db)
+			require.NoError(b, err)
+			c.SetChainState(snow.Bootstrapping)
+		}
+
+		// Blocks are re-created to avoid reusing the state of a previous
+		// execution.
+		stateless := *blocks[i%numBlocks]
+		blk, err := c.NewBlock(&stateless)
+		require.NoError(b, err)
+		return blk.(*block)
+	}
+
+	b.Run("pre-verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			blk := parse(i)
+			b.StartTimer()
+
+			require.NoError(b, blk.PreVerify(context.Background()))
+		}
+	})
+	for _, preVerify := range []bool{false, true} {
+		b.Run(fmt.Sprintf("execute/pre-verified=%t", preVerify), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				blk := parse(i)
+				if preVerify {
+					require.NoError(b, blk.PreVerify(context.Background()))
+				}
+				b.StartTimer()
+
+				require.NoError(b, blk.Verify(context.Background()))
+				require.NoError(b, blk.Accept(context.Background()))
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 var (
 	_ smblock.ChainVM                      = (*VM)(nil)
 	_ smblock.BuildBlockWithContextChainVM = (*VM)(nil)
+	_ smblock.ParallelParsingChainVM       = (*VM)(nil)
 )
 
 type VM struct {
@@ -152,6 +153,12 @@ func (vm *VM) ParseBlock(_ context.Context, blkBytes []byte) (snowman.Block, err
 	return vm.chain.NewBlock(blk)
 }
 
+// ParallelParsingEnabled returns true because parsing and pre-verifying blocks
+// don't depend on the chain's state.
+func (*VM) ParallelParsingEnabled(context.Context) (bool, error) {
+	return true, nil
+}
+
 func (vm *VM) BuildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.builder.BuildBlock(ctx, nil)
 }
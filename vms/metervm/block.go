@@ -16,6 +16,7 @@ var (
 	_ snowman.Block           = (*meterBlock)(nil)
 	_ snowman.OracleBlock     = (*meterBlock)(nil)
 	_ block.WithVerifyContext = (*meterBlock)(nil)
+	_ block.WithPreVerify     = (*meterBlock)(nil)
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
@@ -39,6 +40,24 @@ func (mb *meterBlock) Verify(ctx context.Context) error {
 	return err
 }
 
+func (mb *meterBlock) PreVerify(ctx context.Context) error {
+	blkWithPreVerify, ok := mb.Block.(block.WithPreVerify)
+	if !ok {
+		return nil
+	}
+
+	start := mb.vm.clock.Time()
+	err := blkWithPreVerify.PreVerify(ctx)
+	end := mb.vm.clock.Time()
+	duration := float64(end.Sub(start))
+	if err != nil {
+		mb.vm.blockMetrics.preVerifyErr.Observe(duration)
+	} else {
+		mb.vm.preVerify.Observe(duration)
+	}
+	return err
+}
+
 func (mb *meterBlock) Accept(ctx context.Context) error {
 	start := mb.vm.clock.Time()
 	err := mb.Block.Accept(ctx)
@@ -21,6 +21,8 @@ type blockMetrics struct {
 	lastAccepted,
 	verify,
 	verifyErr,
+	preVerify,
+	preVerifyErr,
 	accept,
 	reject,
 	// Height metrics
@@ -62,6 +64,8 @@ func (m *blockMetrics) Initialize(
 	m.lastAccepted = newAverager("last_accepted", reg, &errs)
 	m.verify = newAverager("verify", reg, &errs)
 	m.verifyErr = newAverager("verify_err", reg, &errs)
+	m.preVerify = newAverager("pre_verify", reg, &errs)
+	m.preVerifyErr = newAverager("pre_verify_err", reg, &errs)
 	m.accept = newAverager("accept", reg, &errs)
 	m.reject = newAverager("reject", reg, &errs)
 	m.shouldVerifyWithContext = newAverager("should_verify_with_context", reg, &errs)
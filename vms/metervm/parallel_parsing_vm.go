@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import "context"
+
+func (vm *blockVM) ParallelParsingEnabled(ctx context.Context) (bool, error) {
+	if vm.ppVM == nil {
+		return false, nil
+	}
+
+	return vm.ppVM.ParallelParsingEnabled(ctx)
+}
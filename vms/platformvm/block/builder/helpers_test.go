@@ -166,6 +166,8 @@ func newEnvironment(t *testing.T, f upgradetest.Fork) *environment { //nolint:un
 		res.mempool,
 		res.backend.Config.PartialSyncPrimaryNetwork,
 		res.sender,
+		network.NewSignatureRequestVerifier(&res.ctx.Lock, res.state),
+		res.ctx.WarpSigner,
 		registerer,
 		network.DefaultConfig,
 	)
@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
@@ -17,6 +18,7 @@ var (
 	_ snowman.Block             = (*Block)(nil)
 	_ snowman.OracleBlock       = (*Block)(nil)
 	_ smblock.WithVerifyContext = (*Block)(nil)
+	_ smblock.WithPreVerify     = (*Block)(nil)
 )
 
 // Exported for testing in platformvm package.
@@ -59,6 +61,18 @@ func (b *Block) Verify(ctx context.Context) error {
 	return b.VerifyWithContext(ctx, nil)
 }
 
+// PreVerify syntactically verifies the transactions in the block. The result is
+// cached in the transactions, so that the syntactic verification performed
+// during Verify is skipped.
+func (b *Block) PreVerify(context.Context) error {
+	for _, tx := range b.Txs() {
+		if err := tx.SyntacticVerify(b.manager.ctx); err != nil {
+			return fmt.Errorf("tx %s failed syntactic verification: %w", tx.ID(), err)
+		}
+	}
+	return nil
+}
+
 func (b *Block) Accept(context.Context) error {
 	return b.Visit(b.manager.acceptor)
 }
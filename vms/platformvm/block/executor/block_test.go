@@ -13,16 +13,21 @@ import (
 
 	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow"
 	"github.com/f01c5700/avalanchego/snow/snowtest"
 	"github.com/f01c5700/avalanchego/snow/uptime/uptimemock"
 	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
 	"github.com/f01c5700/avalanchego/vms/platformvm/block"
 	"github.com/f01c5700/avalanchego/vms/platformvm/config"
 	"github.com/f01c5700/avalanchego/vms/platformvm/reward"
+	"github.com/f01c5700/avalanchego/vms/platformvm/signer"
 	"github.com/f01c5700/avalanchego/vms/platformvm/state"
 	"github.com/f01c5700/avalanchego/vms/platformvm/status"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs/executor"
+	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
 )
 
 func TestBlockOptions(t *testing.T) {
@@ -511,3 +516,149 @@ func TestBlockOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockPreVerify(t *testing.T) {
+	ctx := snowtest.Context(t, snowtest.PChainID)
+	tests := []struct {
+		name        string
+		weight      uint64
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			weight:      1,
+			expectedErr: nil,
+		},
+		{
+			name:        "no weight",
+			weight:      0,
+			expectedErr: txs.ErrWeightTooSmall,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx := newPermissionlessValidatorTx(t, ctx, test.weight)
+			statelessBlk, err := block.NewBanffStandardBlock(
+				time.Time{},
+				ids.GenerateTestID(),
+				1,
+				[]*txs.Tx{tx},
+			)
+			require.NoError(err)
+
+			blk := &Block{
+				Block: statelessBlk,
+				manager: &manager{
+					backend: &backend{
+						ctx: ctx,
+					},
+				},
+			}
+			err = blk.PreVerify(context.Background())
+			require.ErrorIs(err, test.expectedErr)
+
+			utx := tx.Unsigned.(*txs.AddPermissionlessValidatorTx)
+			require.Equal(test.expectedErr == nil, utx.SyntacticallyVerified)
+		})
+	}
+}
+
+// BenchmarkBlockPreVerify measures pre-verifying a block of permissionless
+// validator transactions, which is dominated by verifying the proofs of
+// possession. A block that was already pre-verified doesn't repeat the
+// syntactic verification, so the cost of pre-verifying a fresh block is moved
+// from the in-order verification to the parallel parsers while bootstrapping.
+func BenchmarkBlockPreVerify(b *testing.B) {
+	const numTxs = 16
+
+	ctx := snowtest.Context(b, snowtest.PChainID)
+	blkTxs := make([]*txs.Tx, numTxs)
+	for i := range blkTxs {
+		blkTxs[i] = newPermissionlessValidatorTx(b, ctx, 1)
+	}
+	statelessBlk, err := block.NewBanffStandardBlock(
+		time.Time{},
+		ids.GenerateTestID(),
+		1,
+		blkTxs,
+	)
+	require.NoError(b, err)
+
+	manager := &manager{
+		backend: &backend{
+			ctx: ctx,
+		},
+	}
+	parse := func() *Block {
+		statelessBlk, err := block.Parse(block.Codec, statelessBlk.Bytes())
+		require.NoError(b, err)
+		return &Block{
+			Block:   statelessBlk,
+			manager: manager,
+		}
+	}
+
+	b.Run("fresh", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			blk := parse()
+			b.StartTimer()
+
+			require.NoError(b, blk.PreVerify(context.Background()))
+		}
+	})
+	b.Run("pre-verified", func(b *testing.B) {
+		blk := parse()
+		require.NoError(b, blk.PreVerify(context.Background()))
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, blk.PreVerify(context.Background()))
+		}
+	})
+}
+
+func newPermissionlessValidatorTx(tb testing.TB, ctx *snow.Context, weight uint64) *txs.Tx {
+	require := require.New(tb)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	utx := &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+			},
+		},
+		Validator: txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    1,
+			Wght:   weight,
+		},
+		Subnet: constants.PrimaryNetworkID,
+		Signer: signer.NewProofOfPossession(sk),
+		StakeOuts: []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{
+					ID: ctx.AVAXAssetID,
+				},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          weight,
+					OutputOwners: *owner,
+				},
+			},
+		},
+		ValidatorRewardsOwner: owner,
+		DelegatorRewardsOwner: owner,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	require.NoError(err)
+	return tx
+}
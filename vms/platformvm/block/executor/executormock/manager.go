@@ -16,8 +16,10 @@ import (
 	snowman "github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	set "github.com/f01c5700/avalanchego/utils/set"
 	block "github.com/f01c5700/avalanchego/vms/platformvm/block"
+	executor "github.com/f01c5700/avalanchego/vms/platformvm/block/executor"
 	state "github.com/f01c5700/avalanchego/vms/platformvm/state"
 	txs "github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	executor0 "github.com/f01c5700/avalanchego/vms/platformvm/txs/executor"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -145,6 +147,36 @@ func (mr *ManagerMockRecorder) SetPreference(blkID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*Manager)(nil).SetPreference), blkID)
 }
 
+// SimulateStaker mocks base method.
+func (m *Manager) SimulateStaker(tx *txs.Tx) (*executor0.StakerSimulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateStaker", tx)
+	ret0, _ := ret[0].(*executor0.StakerSimulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateStaker indicates an expected call of SimulateStaker.
+func (mr *ManagerMockRecorder) SimulateStaker(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateStaker", reflect.TypeOf((*Manager)(nil).SimulateStaker), tx)
+}
+
+// SimulateTx mocks base method.
+func (m *Manager) SimulateTx(tx *txs.Tx, signed bool) (*executor.TxSimulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateTx", tx, signed)
+	ret0, _ := ret[0].(*executor.TxSimulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateTx indicates an expected call of SimulateTx.
+func (mr *ManagerMockRecorder) SimulateTx(tx, signed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateTx", reflect.TypeOf((*Manager)(nil).SimulateTx), tx, signed)
+}
+
 // VerifyTx mocks base method.
 func (m *Manager) VerifyTx(tx *txs.Tx) error {
 	m.ctrl.T.Helper()
@@ -14,6 +14,7 @@ import (
 	"github.com/f01c5700/avalanchego/vms/platformvm/state"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs/executor"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs/fee"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/f01c5700/avalanchego/vms/platformvm/validators"
 )
@@ -41,11 +42,35 @@ type Manager interface {
 	// preferred state. This should *not* be used to verify transactions in a block.
 	VerifyTx(tx *txs.Tx) error
 
+	// SimulateTx executes the transaction on top of the currently preferred
+	// state without persisting any of its changes. If [signed] is false, the
+	// credentials of the transaction aren't verified. This should *not* be
+	// used to verify transactions in a block.
+	SimulateTx(tx *txs.Tx, signed bool) (*TxSimulation, error)
+
+	// SimulateStaker verifies the staker transaction against the current and
+	// pending stakers of the currently preferred state. This should *not* be
+	// used to verify transactions in a block.
+	SimulateStaker(tx *txs.Tx) (*executor.StakerSimulation, error)
+
 	// VerifyUniqueInputs verifies that the inputs are not duplicated in the
 	// provided blk or any of its ancestors pinned in memory.
 	VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error
 }
 
+// TxSimulation is the result of executing a transaction on top of the
+// currently preferred state.
+type TxSimulation struct {
+	// State is the preferred state, advanced to the next block time, that the
+	// transaction was executed on. If [Err] is nil, State includes the changes
+	// made by the transaction.
+	State state.Diff
+	// FeeCalculator is the fee calculator the transaction was verified with.
+	FeeCalculator fee.Calculator
+	// Err is the reason the transaction failed verification, if any.
+	Err error
+}
+
 func NewManager(
 	mempool mempool.Mempool,
 	metrics metrics.Metrics,
@@ -118,32 +143,70 @@ func (m *manager) Preferred() ids.ID {
 }
 
 func (m *manager) VerifyTx(tx *txs.Tx) error {
+	simulation, err := m.SimulateTx(tx, true /*=signed*/)
+	if err != nil {
+		return err
+	}
+	return simulation.Err
+}
+
+func (m *manager) SimulateTx(tx *txs.Tx, signed bool) (*TxSimulation, error) {
+	stateDiff, err := m.nextBlockState()
+	if err != nil {
+		return nil, err
+	}
+
+	feeCalculator := state.PickFeeCalculator(m.txExecutorBackend.Config, stateDiff)
+	simulation := &TxSimulation{
+		State:         stateDiff,
+		FeeCalculator: feeCalculator,
+	}
+	if signed {
+		simulation.Err = tx.Unsigned.Visit(&executor.StandardTxExecutor{
+			Backend:       m.txExecutorBackend,
+			State:         stateDiff,
+			FeeCalculator: feeCalculator,
+			Tx:            tx,
+		})
+	} else {
+		simulation.Err = executor.ExecuteUnsignedTx(m.txExecutorBackend, feeCalculator, stateDiff, tx)
+	}
+	return simulation, nil
+}
+
+func (m *manager) SimulateStaker(tx *txs.Tx) (*executor.StakerSimulation, error) {
+	stateDiff, err := m.nextBlockState()
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.SimulateStaker(
+		m.txExecutorBackend,
+		state.PickFeeCalculator(m.txExecutorBackend.Config, stateDiff),
+		stateDiff,
+		tx,
+	)
+}
+
+// nextBlockState returns the currently preferred state advanced to the time of
+// the next block.
+func (m *manager) nextBlockState() (state.Diff, error) {
 	if !m.txExecutorBackend.Bootstrapped.Get() {
-		return ErrChainNotSynced
+		return nil, ErrChainNotSynced
 	}
 
 	stateDiff, err := state.NewDiff(m.preferred, m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	nextBlkTime, _, err := state.NextBlockTime(stateDiff, m.txExecutorBackend.Clk)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	_, err = executor.AdvanceTimeTo(m.txExecutorBackend, stateDiff, nextBlkTime)
-	if err != nil {
-		return err
-	}
-
-	feeCalculator := state.PickFeeCalculator(m.txExecutorBackend.Config, stateDiff)
-	return tx.Unsigned.Visit(&executor.StandardTxExecutor{
-		Backend:       m.txExecutorBackend,
-		State:         stateDiff,
-		FeeCalculator: feeCalculator,
-		Tx:            tx,
-	})
+	return stateDiff, err
 }
 
 func (m *manager) VerifyUniqueInputs(blkID ids.ID, inputs set.Set[ids.ID]) error {
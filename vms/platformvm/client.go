@@ -60,12 +60,33 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetAddressTxs returns the IDs of up to [pageSize] txs that touched
+	// [addr], skipping the first [cursor] txs, along with the cursor of the
+	// next page
+	GetAddressTxs(
+		ctx context.Context,
+		addr ids.ShortID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 	// GetSubnet returns information about the specified subnet
 	GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (GetSubnetClientResponse, error)
 	// GetSubnets returns information about the specified subnets
 	//
 	// Deprecated: Subnets should be fetched from a dedicated indexer.
 	GetSubnets(ctx context.Context, subnetIDs []ids.ID, options ...rpc.Option) ([]ClientSubnet, error)
+	// GetSubnetLifecycle returns up to [pageSize] txs that created and
+	// modified the subnet [subnetID], skipping the first [cursor] txs, along
+	// with the size of its current validator set and the cursor of the next
+	// page
+	GetSubnetLifecycle(
+		ctx context.Context,
+		subnetID ids.ID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) (*GetSubnetLifecycleReply, error)
 	// GetStakingAssetID returns the assetID of the asset used for staking on
 	// subnet corresponding to [subnetID]
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
@@ -87,10 +108,32 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// SimulateTx executes the signed or unsigned transaction against the
+	// currently preferred state without issuing it
+	SimulateTx(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateTxReply, error)
+	// SimulateStaker verifies the signed or unsigned staker transaction
+	// against the current and pending stakers without checking that it is
+	// funded
+	SimulateStaker(ctx context.Context, tx []byte, options ...rpc.Option) (*SimulateStakerReply, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
+	// GetMempool returns at most [limit] transactions in the mempool, from
+	// oldest to newest, that were added after [cursor]. If [cursor] is empty,
+	// the oldest transactions are returned.
+	GetMempool(
+		ctx context.Context,
+		limit uint32,
+		cursor ids.ID,
+		options ...rpc.Option,
+	) (*GetMempoolReply, error)
+	// GetMempoolTx returns the description and byte representation of the
+	// mempool transaction corresponding to [txID]
+	GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (APIMempoolTx, []byte, error)
+	// GetDroppedTxs returns the transactions most recently dropped from the
+	// mempool along with the reason they were dropped
+	GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]APIDroppedTx, error)
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -110,6 +153,12 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetRewardHistory returns the staking periods and rewards of the stakers
+	// added by [txIDs]
+	GetRewardHistory(ctx context.Context, txIDs []ids.ID, options ...rpc.Option) ([]APIRewardHistory, error)
+	// EstimateReward returns the reward that a new staker described by [args]
+	// would receive with the current supply
+	EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -120,6 +169,16 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorSetDiffs returns the changes made to the validator set of a
+	// provided subnet at heights in (startHeight, endHeight].
+	GetValidatorSetDiffs(
+		ctx context.Context,
+		subnetID ids.ID,
+		startHeight uint64,
+		endHeight uint64,
+		limit uint32,
+		options ...rpc.Option,
+	) (*GetValidatorSetDiffsReply, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
@@ -128,6 +187,20 @@ type Client interface {
 	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
 	// GetFeeState returns the current fee state of the chain.
 	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+	// EstimateFee returns the fee of the transaction described by [args] if it
+	// were included in the next block, along with its projected fees.
+	EstimateFee(ctx context.Context, args *EstimateFeeArgs, options ...rpc.Option) (*EstimateFeeReply, error)
+	// GetUptimeHistory returns the uptime that the node observed for the
+	// validators of [subnetID] during each window from [startTime] to
+	// [endTime]. If [nodeIDs] is empty, all current validators are returned.
+	GetUptimeHistory(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeIDs []ids.NodeID,
+		startTime time.Time,
+		endTime time.Time,
+		options ...rpc.Option,
+	) ([]APIUptimeHistory, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -240,6 +313,22 @@ type GetSubnetClientResponse struct {
 	ManagerAddress []byte
 }
 
+func (c *client) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
 func (c *client) GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (GetSubnetClientResponse, error) {
 	res := &GetSubnetResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getSubnet", &GetSubnetArgs{
@@ -299,6 +388,22 @@ func (c *client) GetSubnets(ctx context.Context, ids []ids.ID, options ...rpc.Op
 	return subnets, nil
 }
 
+func (c *client) GetSubnetLifecycle(
+	ctx context.Context,
+	subnetID ids.ID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) (*GetSubnetLifecycleReply, error) {
+	res := &GetSubnetLifecycleReply{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetLifecycle", &GetSubnetLifecycleArgs{
+		SubnetID: subnetID,
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error) {
 	res := &GetStakingAssetIDResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getStakingAssetID", &GetStakingAssetIDArgs{
@@ -385,6 +490,34 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) SimulateTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateTxReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateTxReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) SimulateStaker(ctx context.Context, txBytes []byte, options ...rpc.Option) (*SimulateStakerReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SimulateStakerReply{}
+	err = c.requester.SendRequest(ctx, "platform.simulateStaker", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "platform.getTx", &api.GetTxArgs{
@@ -411,6 +544,43 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetMempool(
+	ctx context.Context,
+	limit uint32,
+	cursor ids.ID,
+	options ...rpc.Option,
+) (*GetMempoolReply, error) {
+	res := &GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", &GetMempoolArgs{
+		Limit:  json.Uint32(limit),
+		Cursor: cursor,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMempoolTx(ctx context.Context, txID ids.ID, options ...rpc.Option) (APIMempoolTx, []byte, error) {
+	res := &struct {
+		APIMempoolTx
+		api.FormattedTx
+	}{}
+	err := c.requester.SendRequest(ctx, "platform.getMempoolTx", &api.GetTxArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return APIMempoolTx{}, nil, err
+	}
+
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	return res.APIMempoolTx, txBytes, err
+}
+
+func (c *client) GetDroppedTxs(ctx context.Context, options ...rpc.Option) ([]APIDroppedTx, error) {
+	res := &GetDroppedTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getDroppedTxs", struct{}{}, res, options...)
+	return res.Txs, err
+}
+
 func (c *client) GetStake(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -484,6 +654,21 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetRewardHistory(ctx context.Context, txIDs []ids.ID, options ...rpc.Option) ([]APIRewardHistory, error) {
+	res := &GetRewardHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getRewardHistory", &GetRewardHistoryArgs{
+		TxIDs:    txIDs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Stakers, err
+}
+
+func (c *client) EstimateReward(ctx context.Context, args *EstimateRewardArgs, options ...rpc.Option) (*EstimateRewardReply, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateReward", args, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
@@ -504,6 +689,24 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetDiffs(
+	ctx context.Context,
+	subnetID ids.ID,
+	startHeight uint64,
+	endHeight uint64,
+	limit uint32,
+	options ...rpc.Option,
+) (*GetValidatorSetDiffsReply, error) {
+	res := &GetValidatorSetDiffsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorSetDiffs", &GetValidatorSetDiffsArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+		Limit:       json.Uint32(limit),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -539,6 +742,30 @@ func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (gas.St
 	return res.State, res.Price, res.Time, err
 }
 
+func (c *client) EstimateFee(ctx context.Context, args *EstimateFeeArgs, options ...rpc.Option) (*EstimateFeeReply, error) {
+	res := &EstimateFeeReply{}
+	err := c.requester.SendRequest(ctx, "platform.estimateFee", args, res, options...)
+	return res, err
+}
+
+func (c *client) GetUptimeHistory(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	startTime time.Time,
+	endTime time.Time,
+	options ...rpc.Option,
+) ([]APIUptimeHistory, error) {
+	res := &GetUptimeHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getUptimeHistory", &GetUptimeHistoryArgs{
+		SubnetID:  subnetID,
+		NodeIDs:   nodeIDs,
+		StartTime: json.Uint64(startTime.Unix()),
+		EndTime:   json.Uint64(endTime.Unix()),
+	}, res, options...)
+	return res.Validators, err
+}
+
 func AwaitTxAccepted(
 	c Client,
 	ctx context.Context,
@@ -24,6 +24,8 @@ var DefaultExecutionConfig = ExecutionConfig{
 	SubnetManagerCacheSize:       4 * units.MiB,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	IndexTransactions:            false,
+	IndexSubnetHistory:           false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -40,6 +42,14 @@ type ExecutionConfig struct {
 	SubnetManagerCacheSize       int            `json:"subnet-manager-cache-size"`
 	ChecksumsEnabled             bool           `json:"checksums-enabled"`
 	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	// IndexTransactions enables the index of the transactions that touched
+	// each address. If enabled on a node that previously ran without it, the
+	// index is rebuilt from genesis in the background.
+	IndexTransactions bool `json:"index-transactions"`
+	// IndexSubnetHistory enables the index of the transactions that created
+	// or modified each subnet. If enabled on a node that previously ran
+	// without it, the index is rebuilt from genesis in the background.
+	IndexSubnetHistory bool `json:"index-subnet-history"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/f01c5700/avalanchego/cache"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/network/p2p"
 	"github.com/f01c5700/avalanchego/network/p2p/gossip"
@@ -19,8 +20,12 @@ import (
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs/mempool"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
 )
 
+// signatureCacheSize is the number of warp message signatures to cache
+const signatureCacheSize = 1024
+
 var errMempoolDisabledWithPartialSync = errors.New("mempool is disabled partial syncing")
 
 type Network struct {
@@ -36,6 +41,8 @@ type Network struct {
 	txPushGossipFrequency time.Duration
 	txPullGossiper        gossip.Gossiper
 	txPullGossipFrequency time.Duration
+
+	signatureClient *SignatureClient
 }
 
 func New(
@@ -47,6 +54,8 @@ func New(
 	mempool mempool.Mempool,
 	partialSyncPrimaryNetwork bool,
 	appSender common.AppSender,
+	signatureRequestVerifier SignatureRequestVerifier,
+	signer warp.Signer,
 	registerer prometheus.Registerer,
 	config Config,
 ) (*Network, error) {
@@ -156,6 +165,18 @@ func New(
 		return nil, err
 	}
 
+	// Signature requests are served to all peers, as the requester may not be
+	// a validator.
+	signatureHandler := NewSignatureHandler(
+		log,
+		signatureRequestVerifier,
+		signer,
+		&cache.LRU[ids.ID, []byte]{Size: signatureCacheSize},
+	)
+	if err := p2pNetwork.AddHandler(p2p.SignatureRequestHandlerID, signatureHandler); err != nil {
+		return nil, err
+	}
+
 	return &Network{
 		Network:                   p2pNetwork,
 		log:                       log,
@@ -167,9 +188,16 @@ func New(
 		txPushGossipFrequency:     config.PushGossipFrequency,
 		txPullGossiper:            txPullGossiper,
 		txPullGossipFrequency:     config.PullGossipFrequency,
+		signatureClient:           NewSignatureClient(p2pNetwork.NewClient(p2p.SignatureRequestHandlerID)),
 	}, nil
 }
 
+// SignatureClient returns the client used to request signatures for warp
+// messages from other P-chain nodes.
+func (n *Network) SignatureClient() *SignatureClient {
+	return n.signatureClient
+}
+
 func (n *Network) PushGossip(ctx context.Context) {
 	// TODO: Even though the node is running partial sync, we should support
 	// issuing transactions from the RPC.
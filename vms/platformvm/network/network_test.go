@@ -175,6 +175,8 @@ func TestNetworkIssueTxFromRPC(t *testing.T) {
 				tt.mempoolFunc(ctrl),
 				tt.partialSyncPrimaryNetwork,
 				tt.appSenderFunc(ctrl),
+				testSignatureRequestVerifier{},
+				snowCtx.WarpSigner,
 				prometheus.NewRegistry(),
 				testConfig,
 			)
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/proto/pb/sdk"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+// SignatureClient requests signatures for warp messages from peers that run
+// a [SignatureHandler].
+type SignatureClient struct {
+	client *p2p.Client
+}
+
+func NewSignatureClient(client *p2p.Client) *SignatureClient {
+	return &SignatureClient{
+		client: client,
+	}
+}
+
+type signatureResponse struct {
+	responseBytes []byte
+	err           error
+}
+
+// RequestSignature requests [nodeID]'s signature over [msg]. [justification]
+// is forwarded to the peer to allow it to verify [msg].
+//
+// The returned signature is not verified, so the caller must verify it
+// against [nodeID]'s public key.
+//
+// The response is delivered by the VM, so this must not be called while
+// holding the context lock.
+func (c *SignatureClient) RequestSignature(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	msg *warp.UnsignedMessage,
+	justification []byte,
+) (*bls.Signature, error) {
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message:       msg.Bytes(),
+		Justification: justification,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(chan signatureResponse, 1)
+	onResponse := func(_ context.Context, _ ids.NodeID, responseBytes []byte, err error) {
+		responses <- signatureResponse{
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	if err := c.client.AppRequest(ctx, set.Of(nodeID), requestBytes, onResponse); err != nil {
+		return nil, err
+	}
+
+	var response signatureResponse
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case response = <-responses:
+	}
+	if response.err != nil {
+		return nil, fmt.Errorf("signature request to %s failed: %w", nodeID, response.err)
+	}
+
+	responseMsg := &sdk.SignatureResponse{}
+	if err := proto.Unmarshal(response.responseBytes, responseMsg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature response from %s: %w", nodeID, err)
+	}
+	signature, err := bls.SignatureFromBytes(responseMsg.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature from %s: %w", nodeID, err)
+	}
+	return signature, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/f01c5700/avalanchego/cache"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/proto/pb/sdk"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+var _ p2p.Handler = (*SignatureHandler)(nil)
+
+// SignatureHandler serves signature requests for warp messages, as defined in
+// ACP-118.
+type SignatureHandler struct {
+	p2p.NoOpHandler
+
+	log            logging.Logger
+	verifier       SignatureRequestVerifier
+	signer         warp.Signer
+	signatureCache cache.Cacher[ids.ID, []byte]
+}
+
+// NewSignatureHandler returns a handler that signs the messages that are
+// verified by [verifier]. Signatures are cached in [signatureCache] by the ID
+// of the message, so [verifier] must only allow signing messages that will
+// always be valid.
+func NewSignatureHandler(
+	log logging.Logger,
+	verifier SignatureRequestVerifier,
+	signer warp.Signer,
+	signatureCache cache.Cacher[ids.ID, []byte],
+) *SignatureHandler {
+	return &SignatureHandler{
+		log:            log,
+		verifier:       verifier,
+		signer:         signer,
+		signatureCache: signatureCache,
+	}
+}
+
+func (s *SignatureHandler) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, *common.AppError) {
+	request := &sdk.SignatureRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		s.log.Debug("failed to unmarshal signature request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil, ErrFailedToParseRequest
+	}
+
+	msg, err := warp.ParseUnsignedMessage(request.Message)
+	if err != nil {
+		s.log.Debug("failed to parse warp message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil, ErrFailedToParseRequest
+	}
+
+	msgID := msg.ID()
+	signature, ok := s.signatureCache.Get(msgID)
+	if !ok {
+		if appErr := s.verifier.Verify(ctx, msg, request.Justification); appErr != nil {
+			s.log.Debug("refusing to sign warp message",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageID", msgID),
+				zap.Error(appErr),
+			)
+			return nil, appErr
+		}
+
+		signature, err = s.signer.Sign(msg)
+		if err != nil {
+			s.log.Debug("failed to sign warp message",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageID", msgID),
+				zap.Error(err),
+			)
+			return nil, ErrFailedToSign
+		}
+		s.signatureCache.Put(msgID, signature)
+	}
+
+	responseBytes, err := proto.Marshal(&sdk.SignatureResponse{
+		Signature: signature,
+	})
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	return responseBytes, nil
+}
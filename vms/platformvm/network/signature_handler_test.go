@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/f01c5700/avalanchego/cache"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/proto/pb/sdk"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/snow/engine/enginetest"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+func TestSignatureHandler(t *testing.T) {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	pk := bls.PublicFromSecretKey(sk)
+	signer := warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID)
+
+	msg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		constants.PlatformChainID,
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+	otherChainMsg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		requestBytes  []byte
+		verifier      SignatureRequestVerifier
+		cachedMsg     *warp.UnsignedMessage
+		expectedMsg   *warp.UnsignedMessage
+		expectedError *common.AppError
+	}{
+		{
+			name:          "invalid request",
+			requestBytes:  []byte{0xff},
+			verifier:      testSignatureRequestVerifier{},
+			expectedError: ErrFailedToParseRequest,
+		},
+		{
+			name:          "invalid message",
+			requestBytes:  marshalSignatureRequest(t, []byte("message")),
+			verifier:      testSignatureRequestVerifier{},
+			expectedError: ErrFailedToParseRequest,
+		},
+		{
+			name:         "verification fails",
+			requestBytes: marshalSignatureRequest(t, msg.Bytes()),
+			verifier: testSignatureRequestVerifier{
+				err: ErrTxNotAccepted,
+			},
+			expectedError: ErrTxNotAccepted,
+		},
+		{
+			name:          "signing fails",
+			requestBytes:  marshalSignatureRequest(t, otherChainMsg.Bytes()),
+			verifier:      testSignatureRequestVerifier{},
+			expectedError: ErrFailedToSign,
+		},
+		{
+			name:         "cached signature",
+			requestBytes: marshalSignatureRequest(t, msg.Bytes()),
+			verifier: testSignatureRequestVerifier{
+				err: ErrTxNotAccepted,
+			},
+			cachedMsg:   msg,
+			expectedMsg: msg,
+		},
+		{
+			name:         "signed",
+			requestBytes: marshalSignatureRequest(t, msg.Bytes()),
+			verifier:     testSignatureRequestVerifier{},
+			expectedMsg:  msg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			signatureCache := &cache.LRU[ids.ID, []byte]{Size: 1}
+			if tt.cachedMsg != nil {
+				signature, err := signer.Sign(tt.cachedMsg)
+				require.NoError(err)
+				signatureCache.Put(tt.cachedMsg.ID(), signature)
+			}
+
+			handler := NewSignatureHandler(
+				logging.NoLog{},
+				tt.verifier,
+				signer,
+				signatureCache,
+			)
+			responseBytes, appErr := handler.AppRequest(
+				context.Background(),
+				ids.GenerateTestNodeID(),
+				time.Time{},
+				tt.requestBytes,
+			)
+			require.Equal(tt.expectedError, appErr)
+			if tt.expectedError != nil {
+				return
+			}
+
+			response := &sdk.SignatureResponse{}
+			require.NoError(proto.Unmarshal(responseBytes, response))
+			signature, err := bls.SignatureFromBytes(response.Signature)
+			require.NoError(err)
+			require.True(bls.Verify(pk, signature, tt.expectedMsg.Bytes()))
+
+			_, ok := signatureCache.Get(tt.expectedMsg.ID())
+			require.True(ok)
+		})
+	}
+}
+
+func TestSignatureClient(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	signer := warp.NewSigner(sk, constants.UnitTestID, constants.PlatformChainID)
+
+	var (
+		ctx          = context.Background()
+		clientNodeID = ids.GenerateTestNodeID()
+		serverNodeID = ids.GenerateTestNodeID()
+
+		clientNetwork *p2p.Network
+		serverNetwork *p2p.Network
+	)
+	clientSender := &enginetest.Sender{
+		SendAppRequestF: func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+			go func() {
+				require.NoError(serverNetwork.AppRequest(ctx, clientNodeID, requestID, time.Time{}, requestBytes))
+			}()
+			return nil
+		},
+	}
+	serverSender := &enginetest.Sender{
+		SendAppResponseF: func(ctx context.Context, _ ids.NodeID, requestID uint32, responseBytes []byte) error {
+			return clientNetwork.AppResponse(ctx, serverNodeID, requestID, responseBytes)
+		},
+		SendAppErrorF: func(ctx context.Context, _ ids.NodeID, requestID uint32, errorCode int32, errorMessage string) error {
+			return clientNetwork.AppRequestFailed(ctx, serverNodeID, requestID, &common.AppError{
+				Code:    errorCode,
+				Message: errorMessage,
+			})
+		},
+	}
+
+	clientNetwork, err = p2p.NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	serverNetwork, err = p2p.NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	verifier := &testSignatureRequestVerifier{}
+	require.NoError(serverNetwork.AddHandler(
+		p2p.SignatureRequestHandlerID,
+		NewSignatureHandler(
+			logging.NoLog{},
+			verifier,
+			signer,
+			&cache.Empty[ids.ID, []byte]{},
+		),
+	))
+	client := NewSignatureClient(clientNetwork.NewClient(p2p.SignatureRequestHandlerID))
+
+	msg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		constants.PlatformChainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	signature, err := client.RequestSignature(ctx, serverNodeID, msg, nil)
+	require.NoError(err)
+	require.True(bls.Verify(pk, signature, msg.Bytes()))
+
+	verifier.err = ErrTxNotAccepted
+	_, err = client.RequestSignature(ctx, serverNodeID, msg, nil)
+	require.ErrorIs(err, ErrTxNotAccepted)
+}
+
+func marshalSignatureRequest(t *testing.T, msgBytes []byte) []byte {
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message: msgBytes,
+	})
+	require.NoError(t, err)
+	return requestBytes
+}
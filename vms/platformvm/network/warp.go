@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"sync"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/vms/platformvm/status"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp/payload"
+)
+
+var (
+	_ SignatureRequestVerifier = (*signatureRequestVerifier)(nil)
+
+	// ErrFailedToParseRequest is returned when a signature request can not be
+	// parsed
+	ErrFailedToParseRequest = &common.AppError{
+		Code:    1,
+		Message: "failed to parse request",
+	}
+	// ErrUnsupportedPayload is returned when a signature is requested for a
+	// payload that the P-chain does not attest to
+	ErrUnsupportedPayload = &common.AppError{
+		Code:    2,
+		Message: "unsupported payload",
+	}
+	// ErrTxNotAccepted is returned when a signature is requested for a
+	// transaction that has not been accepted
+	ErrTxNotAccepted = &common.AppError{
+		Code:    3,
+		Message: "tx not accepted",
+	}
+	// ErrFailedToSign is returned when the node is unable to sign a message
+	ErrFailedToSign = &common.AppError{
+		Code:    4,
+		Message: "failed to sign",
+	}
+)
+
+type SignatureRequestVerifier interface {
+	// Verify verifies that [msg] should be signed. [justification] is
+	// additional data provided by the requester that may be used to verify
+	// [msg].
+	Verify(
+		ctx context.Context,
+		msg *warp.UnsignedMessage,
+		justification []byte,
+	) *common.AppError
+}
+
+// TxGetter provides the accepted transactions of the P-chain.
+type TxGetter interface {
+	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
+}
+
+// NewSignatureRequestVerifier returns a verifier that only allows signing
+// messages whose payload is the hash of an accepted P-chain transaction.
+//
+// [lock] is grabbed before reading from [state].
+func NewSignatureRequestVerifier(lock sync.Locker, state TxGetter) SignatureRequestVerifier {
+	return &signatureRequestVerifier{
+		lock:  lock,
+		state: state,
+	}
+}
+
+type signatureRequestVerifier struct {
+	lock  sync.Locker
+	state TxGetter
+}
+
+func (s *signatureRequestVerifier) Verify(
+	_ context.Context,
+	msg *warp.UnsignedMessage,
+	_ []byte,
+) *common.AppError {
+	parsed, err := payload.Parse(msg.Payload)
+	if err != nil {
+		return ErrFailedToParseRequest
+	}
+
+	hash, ok := parsed.(*payload.Hash)
+	if !ok {
+		return ErrUnsupportedPayload
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, txStatus, err := s.state.GetTx(hash.Hash)
+	switch {
+	case err == database.ErrNotFound:
+		return ErrTxNotAccepted
+	case err != nil:
+		return p2p.ErrUnexpected
+	case txStatus != status.Committed:
+		return ErrTxNotAccepted
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/network/p2p"
+	"github.com/f01c5700/avalanchego/snow/engine/common"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/vms/platformvm/status"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp/payload"
+)
+
+var (
+	_ SignatureRequestVerifier = (*testSignatureRequestVerifier)(nil)
+	_ TxGetter                 = (*testTxGetter)(nil)
+)
+
+type testSignatureRequestVerifier struct {
+	err *common.AppError
+}
+
+func (t testSignatureRequestVerifier) Verify(context.Context, *warp.UnsignedMessage, []byte) *common.AppError {
+	return t.err
+}
+
+type testTxGetter struct {
+	status status.Status
+	err    error
+}
+
+func (t testTxGetter) GetTx(ids.ID) (*txs.Tx, status.Status, error) {
+	return nil, t.status, t.err
+}
+
+func TestSignatureRequestVerifier(t *testing.T) {
+	hash, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(t, err)
+	addressedCall, err := payload.NewAddressedCall(nil, []byte("payload"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		payload     []byte
+		state       TxGetter
+		expectedErr *common.AppError
+	}{
+		{
+			name:        "invalid payload",
+			payload:     []byte("payload"),
+			state:       testTxGetter{},
+			expectedErr: ErrFailedToParseRequest,
+		},
+		{
+			name:        "unsupported payload",
+			payload:     addressedCall.Bytes(),
+			state:       testTxGetter{},
+			expectedErr: ErrUnsupportedPayload,
+		},
+		{
+			name:    "unknown tx",
+			payload: hash.Bytes(),
+			state: testTxGetter{
+				err: database.ErrNotFound,
+			},
+			expectedErr: ErrTxNotAccepted,
+		},
+		{
+			name:    "database error",
+			payload: hash.Bytes(),
+			state: testTxGetter{
+				err: database.ErrClosed,
+			},
+			expectedErr: p2p.ErrUnexpected,
+		},
+		{
+			name:    "aborted tx",
+			payload: hash.Bytes(),
+			state: testTxGetter{
+				status: status.Aborted,
+			},
+			expectedErr: ErrTxNotAccepted,
+		},
+		{
+			name:    "committed tx",
+			payload: hash.Bytes(),
+			state: testTxGetter{
+				status: status.Committed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			msg, err := warp.NewUnsignedMessage(
+				constants.UnitTestID,
+				constants.PlatformChainID,
+				tt.payload,
+			)
+			require.NoError(err)
+
+			verifier := NewSignatureRequestVerifier(&sync.Mutex{}, tt.state)
+			appErr := verifier.Verify(context.Background(), msg, nil)
+			require.Equal(tt.expectedErr, appErr)
+		})
+	}
+}
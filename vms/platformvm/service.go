@@ -19,12 +19,14 @@ import (
 	"github.com/f01c5700/avalanchego/cache"
 	"github.com/f01c5700/avalanchego/database"
 	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/uptime"
 	"github.com/f01c5700/avalanchego/snow/validators"
 	"github.com/f01c5700/avalanchego/utils"
 	"github.com/f01c5700/avalanchego/utils/constants"
 	"github.com/f01c5700/avalanchego/utils/crypto/bls"
 	"github.com/f01c5700/avalanchego/utils/crypto/secp256k1"
 	"github.com/f01c5700/avalanchego/utils/formatting"
+	"github.com/f01c5700/avalanchego/utils/iterator"
 	"github.com/f01c5700/avalanchego/utils/logging"
 	"github.com/f01c5700/avalanchego/utils/set"
 	"github.com/f01c5700/avalanchego/vms/components/avax"
@@ -37,24 +39,39 @@ import (
 	"github.com/f01c5700/avalanchego/vms/platformvm/state"
 	"github.com/f01c5700/avalanchego/vms/platformvm/status"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs/fee"
 	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
 	"github.com/f01c5700/avalanchego/vms/types"
 
 	avajson "github.com/f01c5700/avalanchego/utils/json"
 	safemath "github.com/f01c5700/avalanchego/utils/math"
 	platformapi "github.com/f01c5700/avalanchego/vms/platformvm/api"
+	blockbuilder "github.com/f01c5700/avalanchego/vms/platformvm/block/builder"
+	txexecutor "github.com/f01c5700/avalanchego/vms/platformvm/txs/executor"
 )
 
 const (
+	// Max number of fee projections returned by EstimateFee
+	maxFeeProjections = 1024
+
 	// Max number of addresses that can be passed in as argument to GetUTXOs
 	maxGetUTXOsAddrs = 1024
 
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of txIDs that can be passed in as argument to
+	// GetRewardHistory
+	maxGetRewardHistoryTxIDs = 256
+
 	// Max number of items allowed in a page
 	maxPageSize = 1024
 
+	// Default and max duration of the period that GetUptimeHistory can report
+	// the uptimes of
+	defaultUptimeHistoryDuration = 7 * uptime.Window
+	maxUptimeHistoryDuration     = 366 * uptime.Window
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -65,6 +82,33 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errInvalidUptimePeriod        = errors.New("invalid uptime period")
+	errUntrackedSubnet            = errors.New("subnet isn't tracked")
+	errTxNotInMempool             = errors.New("tx not in mempool")
+	errDynamicFeesNotActive       = errors.New("dynamic fees aren't active")
+	errUnsupportedTxType          = errors.New("unsupported tx type")
+	errTooManyFeeProjections      = errors.New("too many fee projections")
+	errInvalidHeightRange         = errors.New("invalid height range")
+	errNotStakerTx                = errors.New("tx doesn't add a staker")
+	errNotDelegatable             = errors.New("validator doesn't accept delegators")
+	errInvalidStakeDuration       = errors.New("invalid stake duration")
+	errSubnetNotFound             = errors.New("subnet not found")
+
+	// intrinsicTxComplexities are the intrinsic complexities of the tx types
+	// that support dynamic fees, keyed by the name of the tx type.
+	intrinsicTxComplexities = map[string]gas.Dimensions{
+		"AddPermissionlessDelegatorTx": fee.IntrinsicAddPermissionlessDelegatorTxComplexities,
+		"AddPermissionlessValidatorTx": fee.IntrinsicAddPermissionlessValidatorTxComplexities,
+		"AddSubnetValidatorTx":         fee.IntrinsicAddSubnetValidatorTxComplexities,
+		"BaseTx":                       fee.IntrinsicBaseTxComplexities,
+		"ConvertSubnetTx":              fee.IntrinsicConvertSubnetTxComplexities,
+		"CreateChainTx":                fee.IntrinsicCreateChainTxComplexities,
+		"CreateSubnetTx":               fee.IntrinsicCreateSubnetTxComplexities,
+		"ExportTx":                     fee.IntrinsicExportTxComplexities,
+		"ImportTx":                     fee.IntrinsicImportTxComplexities,
+		"RemoveSubnetValidatorTx":      fee.IntrinsicRemoveSubnetValidatorTxComplexities,
+		"TransferSubnetOwnershipTx":    fee.IntrinsicTransferSubnetOwnershipTxComplexities,
+	}
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -423,6 +467,67 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	return nil
 }
 
+// GetAddressTxsArgs are the arguments for GetAddressTxs
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor is the number of txs of the address to skip
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize is the maximum number of tx IDs to return
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// GetAddressTxsReply is the response from GetAddressTxs
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor is the cursor to request the next page with
+	Cursor avajson.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that consumed,
+// produced, staked or set the reward owner of funds of the given address, in
+// the order they were accepted.
+//
+// The node must be run with the index-transactions config enabled.
+func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	var (
+		cursor   = uint64(args.Cursor)
+		pageSize = int(args.PageSize)
+	)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+		zap.Uint64("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+	)
+
+	if pageSize <= 0 || maxPageSize < pageSize {
+		pageSize = maxPageSize
+	}
+
+	address, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse %s to address: %w", args.Address, err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	txIDs, err := s.vm.state.GetAddressTxs(address, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get txs of %s: %w", args.Address, err)
+	}
+
+	reply.TxIDs = txIDs
+	if reply.TxIDs == nil {
+		reply.TxIDs = []ids.ID{}
+	}
+	// To get the next page, the cursor should be advanced past the returned
+	// tx IDs.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(txIDs)))
+	return nil
+}
+
 // GetSubnetArgs are the arguments to GetSubnet
 type GetSubnetArgs struct {
 	// ID of the subnet to retrieve information about
@@ -651,6 +756,163 @@ func (s *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, response *Ge
 	return nil
 }
 
+const (
+	SubnetEventCreated              = "created"
+	SubnetEventOwnershipTransferred = "ownershipTransferred"
+	SubnetEventChainCreated         = "chainCreated"
+	SubnetEventTransformed          = "transformed"
+	SubnetEventConverted            = "converted"
+)
+
+// GetSubnetLifecycleArgs are the arguments for GetSubnetLifecycle
+type GetSubnetLifecycleArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// Cursor is the number of txs of the subnet to skip
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize is the maximum number of txs to return
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// APISubnetEvent is an accepted tx that created or modified a subnet
+type APISubnetEvent struct {
+	Type   string         `json:"type"`
+	TxID   ids.ID         `json:"txID"`
+	Height avajson.Uint64 `json:"height"`
+	// Owner is only populated for created and ownershipTransferred events
+	Owner *platformapi.Owner `json:"owner,omitempty"`
+	// ChainID, ChainName and VMID are only populated for chainCreated events
+	ChainID   *ids.ID `json:"chainID,omitempty"`
+	ChainName string  `json:"chainName,omitempty"`
+	VMID      *ids.ID `json:"vmID,omitempty"`
+	// AssetID is only populated for transformed events
+	AssetID *ids.ID `json:"assetID,omitempty"`
+	// ManagerChainID and ManagerAddress are only populated for converted
+	// events
+	ManagerChainID *ids.ID             `json:"managerChainID,omitempty"`
+	ManagerAddress types.JSONByteSlice `json:"managerAddress,omitempty"`
+}
+
+// GetSubnetLifecycleReply is the response from GetSubnetLifecycle
+type GetSubnetLifecycleReply struct {
+	// Events are ordered by the order they were accepted in, starting with
+	// the creation of the subnet
+	Events []APISubnetEvent `json:"events"`
+	// CurrentValidators is the number of current validators of the subnet
+	CurrentValidators avajson.Uint64 `json:"currentValidators"`
+	// CurrentWeight is the total weight of the current validators of the
+	// subnet
+	CurrentWeight avajson.Uint64 `json:"currentWeight"`
+	// Cursor is the cursor to request the next page with
+	Cursor avajson.Uint64 `json:"cursor"`
+}
+
+// GetSubnetLifecycle returns the txs that created and modified a subnet, along
+// with the size of its current validator set.
+//
+// The node must be run with the index-subnet-history config enabled.
+func (s *Service) GetSubnetLifecycle(_ *http.Request, args *GetSubnetLifecycleArgs, reply *GetSubnetLifecycleReply) error {
+	var (
+		cursor   = uint64(args.Cursor)
+		pageSize = int(args.PageSize)
+	)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetLifecycle"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("cursor", cursor),
+		zap.Int("pageSize", pageSize),
+	)
+
+	if pageSize <= 0 || maxPageSize < pageSize {
+		pageSize = maxPageSize
+	}
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkIsNotASubnet
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	events, err := s.vm.state.GetSubnetHistory(args.SubnetID, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get history of subnet %s: %w", args.SubnetID, err)
+	}
+	// Every subnet has at least one event, its creation.
+	if cursor == 0 && len(events) == 0 {
+		return fmt.Errorf("%w: %s", errSubnetNotFound, args.SubnetID)
+	}
+
+	reply.Events = make([]APISubnetEvent, len(events))
+	for i, event := range events {
+		tx, _, err := s.vm.state.GetTx(event.TxID)
+		if err != nil {
+			return fmt.Errorf("couldn't get tx %s: %w", event.TxID, err)
+		}
+		reply.Events[i], err = s.getAPISubnetEvent(tx, event.Height)
+		if err != nil {
+			return fmt.Errorf("couldn't format tx %s: %w", event.TxID, err)
+		}
+	}
+
+	totalWeight, err := s.vm.Validators.TotalWeight(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get total weight of subnet %s: %w", args.SubnetID, err)
+	}
+	reply.CurrentValidators = avajson.Uint64(s.vm.Validators.Count(args.SubnetID))
+	reply.CurrentWeight = avajson.Uint64(totalWeight)
+	// To get the next page, the cursor should be advanced past the returned
+	// events.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(events)))
+	return nil
+}
+
+func (s *Service) getAPISubnetEvent(tx *txs.Tx, height uint64) (APISubnetEvent, error) {
+	txID := tx.ID()
+	event := APISubnetEvent{
+		TxID:   txID,
+		Height: avajson.Uint64(height),
+	}
+
+	var owner fx.Owner
+	switch utx := tx.Unsigned.(type) {
+	case *txs.CreateSubnetTx:
+		event.Type = SubnetEventCreated
+		owner = utx.Owner
+	case *txs.TransferSubnetOwnershipTx:
+		event.Type = SubnetEventOwnershipTransferred
+		owner = utx.Owner
+	case *txs.CreateChainTx:
+		event.Type = SubnetEventChainCreated
+		// The ID of a chain is the ID of the tx that created it.
+		event.ChainID = &txID
+		event.ChainName = utx.ChainName
+		event.VMID = &utx.VMID
+	case *txs.TransformSubnetTx:
+		event.Type = SubnetEventTransformed
+		event.AssetID = &utx.AssetID
+	case *txs.ConvertSubnetTx:
+		event.Type = SubnetEventConverted
+		event.ManagerChainID = &utx.ChainID
+		event.ManagerAddress = utx.Address
+	default:
+		return APISubnetEvent{}, fmt.Errorf("%w: %T", errUnsupportedTxType, utx)
+	}
+
+	if owner != nil {
+		outputOwner, ok := owner.(*secp256k1fx.OutputOwners)
+		if !ok {
+			return APISubnetEvent{}, fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", owner)
+		}
+		apiOwner, err := s.getAPIOwner(outputOwner)
+		if err != nil {
+			return APISubnetEvent{}, err
+		}
+		event.Owner = apiOwner
+	}
+	return event, nil
+}
+
 // GetStakingAssetIDArgs are the arguments to GetStakingAssetID
 type GetStakingAssetIDArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1325,6 +1587,277 @@ func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response *api.
 	return nil
 }
 
+// SimulateTxReply is the response from calling SimulateTx
+type SimulateTxReply struct {
+	TxID ids.ID `json:"txID"`
+	// Timestamp is the chain time the tx was executed at
+	Timestamp time.Time `json:"timestamp"`
+	// Error is the reason the tx failed verification. Empty if the tx would
+	// have been added to the mempool.
+	Error string `json:"error,omitempty"`
+	// Fee is the fee, in nAVAX, the tx is required to burn. Omitted if the
+	// fee couldn't be calculated.
+	Fee *avajson.Uint64 `json:"fee,omitempty"`
+	// Complexity of the tx used to calculate the dynamic fee. Omitted if the
+	// tx type doesn't support dynamic fees.
+	Complexity *gas.Dimensions `json:"complexity,omitempty"`
+	// ConsumedUTXOIDs are the IDs of the UTXOs the tx consumes. Only populated
+	// if the tx is valid.
+	ConsumedUTXOIDs []ids.ID `json:"consumedUTXOIDs"`
+	// ProducedUTXOs are the UTXOs the tx produces on the P-chain. Only
+	// populated if the tx is valid.
+	ProducedUTXOs []string `json:"producedUTXOs"`
+	// StakerChanges are the stakers the tx adds or removes. Only populated if
+	// the tx is valid.
+	StakerChanges []APIStakerChange `json:"stakerChanges"`
+	// Encoding of [ProducedUTXOs]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// APIStakerChange is a staker that is added or removed by a simulated tx
+type APIStakerChange struct {
+	TxID            ids.ID         `json:"txID"`
+	NodeID          ids.NodeID     `json:"nodeID"`
+	SubnetID        ids.ID         `json:"subnetID"`
+	Weight          avajson.Uint64 `json:"weight"`
+	StartTime       avajson.Uint64 `json:"startTime"`
+	EndTime         avajson.Uint64 `json:"endTime"`
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	Delegator       bool           `json:"delegator"`
+	Pending         bool           `json:"pending"`
+	Removed         bool           `json:"removed"`
+}
+
+// SimulateTx executes a tx against the currently preferred state without
+// issuing it. The tx may be signed or unsigned. The credentials of unsigned txs
+// aren't verified.
+func (s *Service) SimulateTx(_ *http.Request, args *api.FormattedTx, reply *SimulateTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateTx"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, signed, err := parseSignedOrUnsignedTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	simulation, err := s.vm.manager.SimulateTx(tx, signed)
+	if err != nil {
+		return fmt.Errorf("couldn't simulate tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.Timestamp = simulation.State.GetTimestamp()
+	reply.Encoding = args.Encoding
+	if txFee, err := simulation.FeeCalculator.CalculateFee(tx.Unsigned); err == nil {
+		reply.Fee = (*avajson.Uint64)(&txFee)
+	}
+	if complexity, err := fee.TxComplexity(tx.Unsigned); err == nil {
+		reply.Complexity = &complexity
+	}
+	if simulation.Err != nil {
+		reply.Error = simulation.Err.Error()
+		return nil
+	}
+
+	reply.ConsumedUTXOIDs = tx.InputIDs().List()
+	utils.Sort(reply.ConsumedUTXOIDs)
+
+	utxos := tx.UTXOs()
+	reply.ProducedUTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
+		}
+		reply.ProducedUTXOs[i], err = formatting.Encode(args.Encoding, bytes)
+		if err != nil {
+			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), args.Encoding, err)
+		}
+	}
+
+	reply.StakerChanges, err = s.getStakerChanges(tx, simulation.State)
+	return err
+}
+
+// SimulateStakerReply is the response from calling SimulateStaker
+type SimulateStakerReply struct {
+	TxID     ids.ID     `json:"txID"`
+	SubnetID ids.ID     `json:"subnetID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	// StartTime and EndTime are the staking period the tx was verified with
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	// Error is the staking constraint the tx violates. Empty if the staker
+	// would be accepted.
+	Error string `json:"error,omitempty"`
+	// Timeline is the total weight of the validator, including the proposed
+	// staker, at every point of the staking period that it changes.
+	Timeline []APIWeightChange `json:"timeline"`
+	// PeakWeight is the maximum weight in [Timeline]
+	PeakWeight avajson.Uint64 `json:"peakWeight"`
+	// MaxWeight is the maximum total weight the validator may have
+	MaxWeight avajson.Uint64 `json:"maxWeight"`
+	// Headroom is the weight that can still be delegated to the validator
+	// over the entire staking period after adding the proposed staker
+	Headroom avajson.Uint64 `json:"headroom"`
+}
+
+// APIWeightChange is the total weight of a validator starting at [Time]
+type APIWeightChange struct {
+	Time   avajson.Uint64 `json:"time"`
+	Weight avajson.Uint64 `json:"weight"`
+}
+
+// SimulateStaker verifies an AddPermissionlessValidatorTx or
+// AddPermissionlessDelegatorTx against the current and pending stakers of the
+// currently preferred state, without checking that it is funded.
+func (s *Service) SimulateStaker(_ *http.Request, args *api.FormattedTx, reply *SimulateStakerReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "simulateStaker"),
+	)
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, _, err := parseSignedOrUnsignedTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	simulation, err := s.vm.manager.SimulateStaker(tx)
+	if err != nil {
+		return fmt.Errorf("couldn't simulate staker: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.SubnetID = simulation.SubnetID
+	reply.NodeID = simulation.NodeID
+	reply.StartTime = avajson.Uint64(simulation.StartTime.Unix())
+	reply.EndTime = avajson.Uint64(simulation.EndTime.Unix())
+	if simulation.Err != nil {
+		reply.Error = simulation.Err.Error()
+	}
+	reply.Timeline = make([]APIWeightChange, len(simulation.Timeline))
+	for i, change := range simulation.Timeline {
+		reply.Timeline[i] = APIWeightChange{
+			Time:   avajson.Uint64(change.Time.Unix()),
+			Weight: avajson.Uint64(change.Weight),
+		}
+	}
+	reply.PeakWeight = avajson.Uint64(simulation.PeakWeight)
+	reply.MaxWeight = avajson.Uint64(simulation.MaxWeight)
+	reply.Headroom = avajson.Uint64(simulation.Headroom)
+	return nil
+}
+
+// parseSignedOrUnsignedTx parses [txBytes] as a signed tx. If that fails, it
+// is parsed as an unsigned tx with no credentials. Returns true if the tx is
+// signed.
+func parseSignedOrUnsignedTx(txBytes []byte) (*txs.Tx, bool, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err == nil {
+		return tx, true, nil
+	}
+
+	var unsignedTx txs.UnsignedTx
+	if _, unsignedErr := txs.Codec.Unmarshal(txBytes, &unsignedTx); unsignedErr != nil {
+		return nil, false, err
+	}
+	tx = &txs.Tx{Unsigned: unsignedTx}
+	if err := tx.Initialize(txs.Codec); err != nil {
+		return nil, false, fmt.Errorf("couldn't initialize tx: %w", err)
+	}
+	return tx, false, nil
+}
+
+// getStakerChanges returns the stakers that [tx] added to or removed from
+// [onAcceptState].
+//
+// Invariant: [tx] was successfully executed on [onAcceptState].
+func (s *Service) getStakerChanges(tx *txs.Tx, onAcceptState state.Chain) ([]APIStakerChange, error) {
+	switch utx := tx.Unsigned.(type) {
+	case txs.Staker:
+		staker, err := getAddedStaker(onAcceptState, tx.ID(), utx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get added staker: %w", err)
+		}
+		return []APIStakerChange{
+			newAPIStakerChange(staker, false),
+		}, nil
+	case *txs.RemoveSubnetValidatorTx:
+		preferredState, ok := s.vm.manager.GetState(s.vm.manager.Preferred())
+		if !ok {
+			return nil, fmt.Errorf("couldn't get preferred state %s", s.vm.manager.Preferred())
+		}
+		staker, err := txexecutor.GetValidator(preferredState, utx.Subnet, utx.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get removed staker: %w", err)
+		}
+		return []APIStakerChange{
+			newAPIStakerChange(staker, true),
+		}, nil
+	default:
+		return []APIStakerChange{}, nil
+	}
+}
+
+// getAddedStaker returns the staker that [txID] added to [chain].
+func getAddedStaker(chain state.Chain, txID ids.ID, stakerTx txs.Staker) (*state.Staker, error) {
+	subnetID := stakerTx.SubnetID()
+	nodeID := stakerTx.NodeID()
+	if stakerTx.CurrentPriority().IsValidator() {
+		return txexecutor.GetValidator(chain, subnetID, nodeID)
+	}
+
+	for _, getIterator := range []func(ids.ID, ids.NodeID) (iterator.Iterator[*state.Staker], error){
+		chain.GetCurrentDelegatorIterator,
+		chain.GetPendingDelegatorIterator,
+	} {
+		delegators, err := getIterator(subnetID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		for delegators.Next() {
+			delegator := delegators.Value()
+			if delegator.TxID == txID {
+				delegators.Release()
+				return delegator, nil
+			}
+		}
+		delegators.Release()
+	}
+	return nil, database.ErrNotFound
+}
+
+func newAPIStakerChange(staker *state.Staker, removed bool) APIStakerChange {
+	return APIStakerChange{
+		TxID:            staker.TxID,
+		NodeID:          staker.NodeID,
+		SubnetID:        staker.SubnetID,
+		Weight:          avajson.Uint64(staker.Weight),
+		StartTime:       avajson.Uint64(staker.StartTime.Unix()),
+		EndTime:         avajson.Uint64(staker.EndTime.Unix()),
+		PotentialReward: avajson.Uint64(staker.PotentialReward),
+		Delegator:       staker.Priority.IsDelegator(),
+		Pending:         staker.Priority.IsPending(),
+		Removed:         removed,
+	}
+}
+
 func (s *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1424,86 +1957,330 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
-type GetStakeArgs struct {
-	api.JSONAddresses
-	ValidatorsOnly bool                `json:"validatorsOnly"`
-	Encoding       formatting.Encoding `json:"encoding"`
-}
-
-// GetStakeReply is the response from calling GetStake.
-type GetStakeReply struct {
-	Staked  avajson.Uint64            `json:"staked"`
-	Stakeds map[ids.ID]avajson.Uint64 `json:"stakeds"`
-	// String representation of staked outputs
-	// Each is of type avax.TransferableOutput
-	Outputs []string `json:"stakedOutputs"`
-	// Encoding of [Outputs]
-	Encoding formatting.Encoding `json:"encoding"`
-}
-
-// GetStake returns the amount of nAVAX that [args.Addresses] have cumulatively
-// staked on the Primary Network.
-//
-// This method assumes that each stake output has only owner
-// This method assumes only AVAX can be staked
-// This method only concerns itself with the Primary Network, not subnets
-// TODO: Improve the performance of this method by maintaining this data
-// in a data structure rather than re-calculating it by iterating over stakers
-func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetStakeReply) error {
-	s.vm.ctx.Log.Debug("deprecated API called",
+// APIMempoolTx is a tx in the mempool
+type APIMempoolTx struct {
+	TxID ids.ID `json:"txID"`
+	// Type of the unsigned tx
+	Type string         `json:"type"`
+	Size avajson.Uint64 `json:"size"`
+	// Fee is the fee, in nAVAX, the tx is required to burn based on the
+	// currently preferred state. Omitted if the fee couldn't be calculated.
+	Fee *avajson.Uint64 `json:"fee,omitempty"`
+	// Complexity of the tx used to calculate the dynamic fee. Omitted if the
+	// tx type doesn't support dynamic fees.
+	Complexity *gas.Dimensions `json:"complexity,omitempty"`
+	TimeAdded  time.Time       `json:"timeAdded"`
+}
+
+// GetMempoolArgs are the arguments for GetMempool
+type GetMempoolArgs struct {
+	// Limit is the maximum number of txs to return
+	Limit avajson.Uint32 `json:"limit"`
+	// Cursor is the tx after which txs are returned. If empty, txs are returned
+	// starting from the oldest tx in the mempool.
+	Cursor ids.ID `json:"cursor"`
+}
+
+// GetMempoolReply is the response from calling GetMempool
+type GetMempoolReply struct {
+	// Txs are ordered from the oldest to the newest tx in the mempool
+	Txs []APIMempoolTx `json:"txs"`
+	// NextCursor is the Cursor to request the next page with. If nil, there
+	// are no more txs in the mempool.
+	NextCursor *ids.ID `json:"nextCursor,omitempty"`
+}
+
+// GetMempool returns the txs in the mempool
+func (s *Service) GetMempool(_ *http.Request, args *GetMempoolArgs, reply *GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
-		zap.String("method", "getStake"),
+		zap.String("method", "getMempool"),
+		zap.Stringer("cursor", args.Cursor),
 	)
 
-	if len(args.Addresses) > maxGetStakeAddrs {
-		return fmt.Errorf("%d addresses provided but this method can take at most %d", len(args.Addresses), maxGetStakeAddrs)
-	}
-
-	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
-	if err != nil {
-		return err
+	limit := int(args.Limit)
+	if limit <= 0 || maxPageSize < limit {
+		limit = maxPageSize
 	}
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
 
-	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if args.Cursor != ids.Empty {
+		if _, ok := s.vm.Builder.Get(args.Cursor); !ok {
+			return fmt.Errorf("%w: %s", errTxNotInMempool, args.Cursor)
+		}
+	}
+
+	feeCalculator, err := s.preferredFeeCalculator()
 	if err != nil {
 		return err
 	}
-	defer currentStakerIterator.Release()
 
 	var (
-		totalAmountStaked = make(map[ids.ID]uint64)
-		stakedOuts        []avax.TransferableOutput
+		foundCursor = args.Cursor == ids.Empty
+		mempoolTxs  []*txs.Tx
+		hasMore     bool
 	)
-	for currentStakerIterator.Next() { // Iterates over current stakers
-		staker := currentStakerIterator.Value()
-
-		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
-			continue
+	s.vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		if !foundCursor {
+			foundCursor = tx.ID() == args.Cursor
+			return true
 		}
-
-		tx, _, err := s.vm.state.GetTx(staker.TxID)
-		if err != nil {
-			return err
+		if len(mempoolTxs) == limit {
+			hasMore = true
+			return false
 		}
-
-		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+		mempoolTxs = append(mempoolTxs, tx)
+		return true
+	})
+
+	reply.Txs = make([]APIMempoolTx, 0, len(mempoolTxs))
+	for _, tx := range mempoolTxs {
+		// The tx may have been removed since it was iterated over.
+		timeAdded, ok := s.vm.Builder.GetAddedTime(tx.ID())
+		if !ok {
+			continue
+		}
+		reply.Txs = append(reply.Txs, newAPIMempoolTx(tx, feeCalculator, timeAdded))
 	}
-
-	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
-	if err != nil {
-		return err
+	if hasMore {
+		nextCursor := mempoolTxs[len(mempoolTxs)-1].ID()
+		reply.NextCursor = &nextCursor
 	}
-	defer pendingStakerIterator.Release()
+	return nil
+}
 
-	for pendingStakerIterator.Next() { // Iterates over pending stakers
-		staker := pendingStakerIterator.Value()
+// GetMempoolTxReply is the response from calling GetMempoolTx
+type GetMempoolTxReply struct {
+	APIMempoolTx
+	Tx       json.RawMessage     `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
 
-		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
-			continue
-		}
+// GetMempoolTx returns a tx in the mempool
+func (s *Service) GetMempoolTx(_ *http.Request, args *api.GetTxArgs, reply *GetMempoolTxReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempoolTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	tx, ok := s.vm.Builder.Get(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+	timeAdded, ok := s.vm.Builder.GetAddedTime(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+
+	feeCalculator, err := s.preferredFeeCalculator()
+	if err != nil {
+		return err
+	}
+	reply.APIMempoolTx = newAPIMempoolTx(tx, feeCalculator, timeAdded)
+	reply.Encoding = args.Encoding
+
+	var result any
+	if args.Encoding == formatting.JSON {
+		tx.Unsigned.InitCtx(s.vm.ctx)
+		result = tx
+	} else {
+		result, err = formatting.Encode(args.Encoding, tx.Bytes())
+		if err != nil {
+			return fmt.Errorf("couldn't encode tx as %s: %w", args.Encoding, err)
+		}
+	}
+
+	reply.Tx, err = json.Marshal(result)
+	return err
+}
+
+// APIDroppedTx is a tx that was recently dropped from the mempool
+type APIDroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+}
+
+// GetDroppedTxsReply is the response from calling GetDroppedTxs
+type GetDroppedTxsReply struct {
+	// Txs are ordered from the least to the most recently dropped tx
+	Txs []APIDroppedTx `json:"txs"`
+}
+
+// GetDroppedTxs returns the txs that were most recently dropped from the
+// mempool along with the reason they were dropped
+func (s *Service) GetDroppedTxs(_ *http.Request, _ *struct{}, reply *GetDroppedTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getDroppedTxs"),
+	)
+
+	reply.Txs = []APIDroppedTx{}
+	s.vm.Builder.IterateDropped(func(txID ids.ID, reason error) bool {
+		reply.Txs = append(reply.Txs, APIDroppedTx{
+			TxID:   txID,
+			Reason: reason.Error(),
+		})
+		return true
+	})
+	return nil
+}
+
+// preferredFeeCalculator returns the fee calculator of the currently preferred
+// state.
+//
+// Invariant: Assumes the context lock is held.
+func (s *Service) preferredFeeCalculator() (fee.Calculator, error) {
+	preferredID := s.vm.manager.Preferred()
+	preferredState, ok := s.vm.manager.GetState(preferredID)
+	if !ok {
+		return nil, fmt.Errorf("could not retrieve state for block %s", preferredID)
+	}
+	return state.PickFeeCalculator(&s.vm.Config, preferredState), nil
+}
+
+func newAPIMempoolTx(tx *txs.Tx, feeCalculator fee.Calculator, timeAdded time.Time) APIMempoolTx {
+	apiTx := APIMempoolTx{
+		TxID:      tx.ID(),
+		Type:      txTypeName(tx.Unsigned),
+		Size:      avajson.Uint64(tx.Size()),
+		TimeAdded: timeAdded,
+	}
+	if txFee, err := feeCalculator.CalculateFee(tx.Unsigned); err == nil {
+		apiTx.Fee = (*avajson.Uint64)(&txFee)
+	}
+	if complexity, err := fee.TxComplexity(tx.Unsigned); err == nil {
+		apiTx.Complexity = &complexity
+	}
+	return apiTx
+}
+
+// txTypeName returns the name of the type of [utx]. The names match the tx
+// types accepted by EstimateFee.
+func txTypeName(utx txs.UnsignedTx) string {
+	switch utx.(type) {
+	case *txs.AddValidatorTx:
+		return "AddValidatorTx"
+	case *txs.AddSubnetValidatorTx:
+		return "AddSubnetValidatorTx"
+	case *txs.AddDelegatorTx:
+		return "AddDelegatorTx"
+	case *txs.CreateChainTx:
+		return "CreateChainTx"
+	case *txs.CreateSubnetTx:
+		return "CreateSubnetTx"
+	case *txs.ImportTx:
+		return "ImportTx"
+	case *txs.ExportTx:
+		return "ExportTx"
+	case *txs.AdvanceTimeTx:
+		return "AdvanceTimeTx"
+	case *txs.RewardValidatorTx:
+		return "RewardValidatorTx"
+	case *txs.RemoveSubnetValidatorTx:
+		return "RemoveSubnetValidatorTx"
+	case *txs.TransformSubnetTx:
+		return "TransformSubnetTx"
+	case *txs.AddPermissionlessValidatorTx:
+		return "AddPermissionlessValidatorTx"
+	case *txs.AddPermissionlessDelegatorTx:
+		return "AddPermissionlessDelegatorTx"
+	case *txs.TransferSubnetOwnershipTx:
+		return "TransferSubnetOwnershipTx"
+	case *txs.ConvertSubnetTx:
+		return "ConvertSubnetTx"
+	case *txs.BaseTx:
+		return "BaseTx"
+	default:
+		return "Unknown"
+	}
+}
+
+type GetStakeArgs struct {
+	api.JSONAddresses
+	ValidatorsOnly bool                `json:"validatorsOnly"`
+	Encoding       formatting.Encoding `json:"encoding"`
+}
+
+// GetStakeReply is the response from calling GetStake.
+type GetStakeReply struct {
+	Staked  avajson.Uint64            `json:"staked"`
+	Stakeds map[ids.ID]avajson.Uint64 `json:"stakeds"`
+	// String representation of staked outputs
+	// Each is of type avax.TransferableOutput
+	Outputs []string `json:"stakedOutputs"`
+	// Encoding of [Outputs]
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetStake returns the amount of nAVAX that [args.Addresses] have cumulatively
+// staked on the Primary Network.
+//
+// This method assumes that each stake output has only owner
+// This method assumes only AVAX can be staked
+// This method only concerns itself with the Primary Network, not subnets
+// TODO: Improve the performance of this method by maintaining this data
+// in a data structure rather than re-calculating it by iterating over stakers
+func (s *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *GetStakeReply) error {
+	s.vm.ctx.Log.Debug("deprecated API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStake"),
+	)
+
+	if len(args.Addresses) > maxGetStakeAddrs {
+		return fmt.Errorf("%d addresses provided but this method can take at most %d", len(args.Addresses), maxGetStakeAddrs)
+	}
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer currentStakerIterator.Release()
+
+	var (
+		totalAmountStaked = make(map[ids.ID]uint64)
+		stakedOuts        []avax.TransferableOutput
+	)
+	for currentStakerIterator.Next() { // Iterates over current stakers
+		staker := currentStakerIterator.Value()
+
+		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
+			continue
+		}
+
+		tx, _, err := s.vm.state.GetTx(staker.TxID)
+		if err != nil {
+			return err
+		}
+
+		stakedOuts = append(stakedOuts, getStakeHelper(tx, addrs, totalAmountStaked)...)
+	}
+
+	pendingStakerIterator, err := s.vm.state.GetPendingStakerIterator()
+	if err != nil {
+		return err
+	}
+	defer pendingStakerIterator.Release()
+
+	for pendingStakerIterator.Next() { // Iterates over pending stakers
+		staker := pendingStakerIterator.Value()
+
+		if args.ValidatorsOnly && !staker.Priority.IsValidator() {
+			continue
+		}
 
 		tx, _, err := s.vm.state.GetTx(staker.TxID)
 		if err != nil {
@@ -1658,6 +2435,415 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+const (
+	StakerTypeValidator = "validator"
+	StakerTypeDelegator = "delegator"
+
+	StakerStatusPending     = "pending"
+	StakerStatusCurrent     = "current"
+	StakerStatusRewarded    = "rewarded"
+	StakerStatusNotRewarded = "notRewarded"
+	StakerStatusRemoved     = "removed"
+)
+
+// GetRewardHistoryArgs are the arguments for calling GetRewardHistory
+type GetRewardHistoryArgs struct {
+	// IDs of the txs that added the stakers to report the rewards of
+	TxIDs    []ids.ID            `json:"txIDs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// APIRewardHistory is the reward history of a staker
+type APIRewardHistory struct {
+	TxID     ids.ID     `json:"txID"`
+	NodeID   ids.NodeID `json:"nodeID"`
+	SubnetID ids.ID     `json:"subnetID"`
+	// Either "validator" or "delegator"
+	Type string `json:"type"`
+	// One of "pending", "current", "rewarded", "notRewarded", or "removed"
+	Status string         `json:"status"`
+	Weight avajson.Uint64 `json:"weight"`
+	// Unix time in seconds
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	// Reward the staker receives if it is rewarded, prior to paying any
+	// delegation fee. Not populated for pending stakers.
+	PotentialReward *avajson.Uint64 `json:"potentialReward,omitempty"`
+	// Percentage (0-100) of the staking period that the validator was
+	// considered online by this node. Only populated for validators.
+	Uptime *avajson.Float32 `json:"uptime,omitempty"`
+	// Fee that the delegator pays, or paid, to the validator out of the
+	// delegator's reward. Only populated for delegators.
+	DelegationFee *avajson.Uint64 `json:"delegationFee,omitempty"`
+	// Fees that the validator has accrued from its delegators. Only populated
+	// for validators.
+	DelegateeReward *avajson.Uint64 `json:"delegateeReward,omitempty"`
+	// Reward UTXOs that were issued to the staker
+	RewardUTXOs []string `json:"rewardUTXOs"`
+}
+
+// GetRewardHistoryReply is the response from calling GetRewardHistory
+type GetRewardHistoryReply struct {
+	Stakers  []APIRewardHistory  `json:"stakers"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetRewardHistory returns the staking periods and rewards of the stakers
+// added by the provided txs.
+//
+// Note: uptimes and delegation fees of stakers that were removed before this
+// node started recording completed stakers are not reported.
+func (s *Service) GetRewardHistory(_ *http.Request, args *GetRewardHistoryArgs, reply *GetRewardHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getRewardHistory"),
+		zap.Int("numTxIDs", len(args.TxIDs)),
+	)
+
+	if len(args.TxIDs) > maxGetRewardHistoryTxIDs {
+		return fmt.Errorf("%d txIDs provided, but the limit is %d", len(args.TxIDs), maxGetRewardHistoryTxIDs)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Stakers = make([]APIRewardHistory, len(args.TxIDs))
+	for i, txID := range args.TxIDs {
+		history, err := s.getRewardHistory(txID, args.Encoding)
+		if err != nil {
+			return fmt.Errorf("couldn't get reward history of %s: %w", txID, err)
+		}
+		reply.Stakers[i] = *history
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+func (s *Service) getRewardHistory(txID ids.ID, encoding formatting.Encoding) (*APIRewardHistory, error) {
+	tx, txStatus, err := s.vm.state.GetTx(txID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get tx: %w", err)
+	}
+	if txStatus != status.Committed {
+		return nil, fmt.Errorf("%w: status is %s", errNotStakerTx, txStatus)
+	}
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errNotStakerTx, tx.Unsigned)
+	}
+
+	history := &APIRewardHistory{
+		TxID:        txID,
+		NodeID:      stakerTx.NodeID(),
+		SubnetID:    stakerTx.SubnetID(),
+		Type:        StakerTypeValidator,
+		Weight:      avajson.Uint64(stakerTx.Weight()),
+		EndTime:     avajson.Uint64(stakerTx.EndTime().Unix()),
+		RewardUTXOs: []string{},
+	}
+	if scheduledTx, ok := tx.Unsigned.(txs.ScheduledStaker); ok {
+		history.StartTime = avajson.Uint64(scheduledTx.StartTime().Unix())
+	}
+	_, isDelegator := tx.Unsigned.(txs.DelegatorTx)
+	if isDelegator {
+		history.Type = StakerTypeDelegator
+	}
+
+	rewardTx, err := blockbuilder.NewRewardValidatorTx(s.vm.ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	_, rewardStatus, err := s.vm.state.GetTx(rewardTx.ID())
+	switch {
+	case err == nil:
+		history.Status = StakerStatusNotRewarded
+		if rewardStatus == status.Committed {
+			history.Status = StakerStatusRewarded
+		}
+	case errors.Is(err, database.ErrNotFound):
+		staker, stakerStatus, err := s.getStaker(history.SubnetID, history.NodeID, txID, isDelegator)
+		if err != nil {
+			return nil, err
+		}
+		if staker != nil {
+			history.Status = stakerStatus
+			if err := s.setActiveRewardHistory(history, staker, isDelegator); err != nil {
+				return nil, err
+			}
+			return history, nil
+		}
+		history.Status = StakerStatusRemoved
+	default:
+		return nil, fmt.Errorf("couldn't get reward tx: %w", err)
+	}
+
+	completed, err := s.vm.state.GetCompletedStaker(txID)
+	switch {
+	case err == nil:
+		s.setCompletedRewardHistory(history, completed, isDelegator)
+	case !errors.Is(err, database.ErrNotFound):
+		return nil, fmt.Errorf("couldn't get completed staker: %w", err)
+	}
+
+	utxos, err := s.vm.state.GetRewardUTXOs(txID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get reward UTXOs: %w", err)
+	}
+	history.RewardUTXOs = make([]string, len(utxos))
+	for i, utxo := range utxos {
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode UTXO to bytes: %w", err)
+		}
+
+		history.RewardUTXOs[i], err = formatting.Encode(encoding, utxoBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode utxo as %s: %w", encoding, err)
+		}
+	}
+	return history, nil
+}
+
+// getStaker returns the current or pending staker added by [txID]. If the
+// staker is neither current nor pending, nil is returned.
+func (s *Service) getStaker(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	txID ids.ID,
+	isDelegator bool,
+) (*state.Staker, string, error) {
+	if !isDelegator {
+		staker, err := s.vm.state.GetCurrentValidator(subnetID, nodeID)
+		switch {
+		case err == nil && staker.TxID == txID:
+			return staker, StakerStatusCurrent, nil
+		case err != nil && !errors.Is(err, database.ErrNotFound):
+			return nil, "", err
+		}
+
+		staker, err = s.vm.state.GetPendingValidator(subnetID, nodeID)
+		switch {
+		case err == nil && staker.TxID == txID:
+			return staker, StakerStatusPending, nil
+		case err != nil && !errors.Is(err, database.ErrNotFound):
+			return nil, "", err
+		}
+		return nil, "", nil
+	}
+
+	currentIterator, err := s.vm.state.GetCurrentDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, "", err
+	}
+	staker, found := findStaker(currentIterator, txID)
+	if found {
+		return staker, StakerStatusCurrent, nil
+	}
+
+	pendingIterator, err := s.vm.state.GetPendingDelegatorIterator(subnetID, nodeID)
+	if err != nil {
+		return nil, "", err
+	}
+	staker, found = findStaker(pendingIterator, txID)
+	if found {
+		return staker, StakerStatusPending, nil
+	}
+	return nil, "", nil
+}
+
+func findStaker(it iterator.Iterator[*state.Staker], txID ids.ID) (*state.Staker, bool) {
+	defer it.Release()
+
+	for it.Next() {
+		if staker := it.Value(); staker.TxID == txID {
+			return staker, true
+		}
+	}
+	return nil, false
+}
+
+// setActiveRewardHistory populates [history] with the details of the current
+// or pending [staker].
+func (s *Service) setActiveRewardHistory(history *APIRewardHistory, staker *state.Staker, isDelegator bool) error {
+	history.StartTime = avajson.Uint64(staker.StartTime.Unix())
+	if staker.Priority.IsPending() {
+		return nil
+	}
+
+	potentialReward := avajson.Uint64(staker.PotentialReward)
+	history.PotentialReward = &potentialReward
+
+	if isDelegator {
+		validator, err := s.vm.state.GetCurrentValidator(staker.SubnetID, staker.NodeID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator: %w", err)
+		}
+		shares, err := s.getDelegationShares(validator.TxID)
+		if err != nil {
+			return err
+		}
+		delegationFee, _ := reward.Split(staker.PotentialReward, shares)
+		history.DelegationFee = (*avajson.Uint64)(&delegationFee)
+		return nil
+	}
+
+	uptime, err := s.getAPIUptime(staker)
+	if err != nil {
+		return err
+	}
+	history.Uptime = uptime
+
+	delegateeReward, err := s.vm.state.GetDelegateeReward(staker.SubnetID, staker.NodeID)
+	if err != nil {
+		return fmt.Errorf("couldn't get delegatee reward: %w", err)
+	}
+	history.DelegateeReward = (*avajson.Uint64)(&delegateeReward)
+	return nil
+}
+
+// setCompletedRewardHistory populates [history] with the details of the
+// removed [staker].
+func (*Service) setCompletedRewardHistory(history *APIRewardHistory, staker *state.CompletedStaker, isDelegator bool) {
+	history.StartTime = avajson.Uint64(staker.StartTime.Unix())
+	history.PotentialReward = (*avajson.Uint64)(&staker.PotentialReward)
+
+	rewarded := history.Status == StakerStatusRewarded
+	if isDelegator {
+		var delegationFee uint64
+		if rewarded {
+			delegationFee, _ = reward.Split(staker.PotentialReward, staker.DelegationShares)
+		}
+		history.DelegationFee = (*avajson.Uint64)(&delegationFee)
+		return
+	}
+
+	var delegateeReward uint64
+	if rewarded {
+		delegateeReward = staker.PotentialDelegateeReward
+	}
+	history.DelegateeReward = (*avajson.Uint64)(&delegateeReward)
+
+	if observedDuration := staker.LastUpdated.Sub(staker.StartTime); observedDuration > 0 {
+		// Transform this to a percentage (0-100) to make it consistent
+		// with the uptime reported for current validators
+		uptime := avajson.Float32(100 * float64(staker.UpDuration) / float64(observedDuration))
+		history.Uptime = &uptime
+	}
+}
+
+// getDelegationShares returns the fee rate charged by the validator added by
+// [validatorTxID].
+func (s *Service) getDelegationShares(validatorTxID ids.ID) (uint32, error) {
+	validatorTx, _, err := s.vm.state.GetTx(validatorTxID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get validator tx: %w", err)
+	}
+	uValidatorTx, ok := validatorTx.Unsigned.(txs.ValidatorTx)
+	if !ok {
+		return 0, fmt.Errorf("%w: %T", errNotDelegatable, validatorTx.Unsigned)
+	}
+	return uValidatorTx.Shares(), nil
+}
+
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Subnet to stake on. If omitted, defaults to the primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// Amount to stake
+	Weight avajson.Uint64 `json:"weight"`
+	// Duration of the staking period in seconds
+	Duration avajson.Uint64 `json:"duration"`
+	// If provided, the reward is estimated for delegating to this current
+	// validator rather than for validating.
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// EstimateRewardReply is the response from calling EstimateReward
+type EstimateRewardReply struct {
+	// Reward that would be issued for the stake, prior to paying any
+	// delegation fee
+	PotentialReward avajson.Uint64 `json:"potentialReward"`
+	// Fee that would be paid to the validator out of the potential reward.
+	// Only non-zero when delegating.
+	DelegationFee avajson.Uint64 `json:"delegationFee"`
+	// Reward the staker would receive
+	Reward avajson.Uint64 `json:"reward"`
+	// Current supply the estimate was calculated with
+	CurrentSupply avajson.Uint64 `json:"currentSupply"`
+}
+
+// EstimateReward returns the reward that a new staker would receive with the
+// current supply if it were rewarded.
+func (s *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateReward"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	var (
+		minStakeDuration = s.vm.MinStakeDuration
+		maxStakeDuration = s.vm.MaxStakeDuration
+		rewardConfig     = s.vm.RewardConfig
+	)
+	if args.SubnetID != constants.PrimaryNetworkID {
+		transformSubnet, err := txexecutor.GetTransformSubnetTx(s.vm.state, args.SubnetID)
+		if err != nil {
+			return fmt.Errorf("couldn't get subnet transformation: %w", err)
+		}
+
+		minStakeDuration = time.Duration(transformSubnet.MinStakeDuration) * time.Second
+		maxStakeDuration = time.Duration(transformSubnet.MaxStakeDuration) * time.Second
+		rewardConfig = reward.Config{
+			MaxConsumptionRate: transformSubnet.MaxConsumptionRate,
+			MinConsumptionRate: transformSubnet.MinConsumptionRate,
+			MintingPeriod:      s.vm.RewardConfig.MintingPeriod,
+			SupplyCap:          transformSubnet.MaximumSupply,
+		}
+	}
+
+	duration := time.Duration(args.Duration) * time.Second
+	if duration < minStakeDuration || duration > maxStakeDuration {
+		return fmt.Errorf("%w: %s is outside of [%s, %s]",
+			errInvalidStakeDuration,
+			duration,
+			minStakeDuration,
+			maxStakeDuration,
+		)
+	}
+
+	currentSupply, err := s.vm.state.GetCurrentSupply(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get current supply: %w", err)
+	}
+
+	calculator := reward.NewCalculator(rewardConfig)
+	potentialReward := calculator.Calculate(duration, uint64(args.Weight), currentSupply)
+
+	var delegationFee uint64
+	if args.NodeID != ids.EmptyNodeID {
+		validator, err := s.vm.state.GetCurrentValidator(args.SubnetID, args.NodeID)
+		if err != nil {
+			return fmt.Errorf("couldn't get validator %s: %w", args.NodeID, err)
+		}
+		shares, err := s.getDelegationShares(validator.TxID)
+		if err != nil {
+			return err
+		}
+		delegationFee, _ = reward.Split(potentialReward, shares)
+	}
+
+	reply.PotentialReward = avajson.Uint64(potentialReward)
+	reply.DelegationFee = avajson.Uint64(delegationFee)
+	reply.Reward = avajson.Uint64(potentialReward - delegationFee)
+	reply.CurrentSupply = avajson.Uint64(currentSupply)
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -1771,6 +2957,226 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+const (
+	ValidatorDiffAdded         = "added"
+	ValidatorDiffRemoved       = "removed"
+	ValidatorDiffWeightChanged = "weightChanged"
+)
+
+// GetValidatorSetDiffsArgs are the arguments for GetValidatorSetDiffs
+type GetValidatorSetDiffsArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// Diffs introduced at heights in (StartHeight, EndHeight] are returned
+	StartHeight avajson.Uint64 `json:"startHeight"`
+	EndHeight   avajson.Uint64 `json:"endHeight"`
+	// Limit is the number of diffs after which the page is cut off at the
+	// next height boundary
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// APIValidatorDiff is a change to a validator set introduced at a height.
+//
+// PreviousPublicKey and PublicKey are only populated for primary network diffs
+// that changed the BLS key of the validator.
+type APIValidatorDiff struct {
+	Height            avajson.Uint64 `json:"height"`
+	NodeID            ids.NodeID     `json:"nodeID"`
+	Type              string         `json:"type"`
+	PreviousWeight    avajson.Uint64 `json:"previousWeight"`
+	Weight            avajson.Uint64 `json:"weight"`
+	PreviousPublicKey *string        `json:"previousPublicKey,omitempty"`
+	PublicKey         *string        `json:"publicKey,omitempty"`
+}
+
+// GetValidatorSetDiffsReply is the response from GetValidatorSetDiffs
+type GetValidatorSetDiffsReply struct {
+	// Diffs are ordered by decreasing height
+	Diffs []APIValidatorDiff `json:"diffs"`
+	// NextEndHeight is the EndHeight to request the next page with. If nil,
+	// all diffs in the requested range were returned.
+	NextEndHeight *avajson.Uint64 `json:"nextEndHeight,omitempty"`
+}
+
+// GetValidatorSetDiffs returns the changes made to the validator set of a
+// provided subnet between two heights.
+func (s *Service) GetValidatorSetDiffs(r *http.Request, args *GetValidatorSetDiffsArgs, reply *GetValidatorSetDiffsReply) error {
+	var (
+		startHeight = uint64(args.StartHeight)
+		endHeight   = uint64(args.EndHeight)
+	)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorSetDiffs"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("endHeight", endHeight),
+	)
+
+	if startHeight > endHeight {
+		return fmt.Errorf("%w: startHeight (%d) > endHeight (%d)",
+			errInvalidHeightRange,
+			startHeight,
+			endHeight,
+		)
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxPageSize < limit {
+		limit = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	ctx := r.Context()
+	currentHeight, err := s.vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current height: %w", err)
+	}
+	if endHeight > currentHeight {
+		return fmt.Errorf("%w: endHeight (%d) > current height (%d)",
+			errInvalidHeightRange,
+			endHeight,
+			currentHeight,
+		)
+	}
+
+	vdrs, err := s.vm.GetValidatorSet(ctx, endHeight, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get validator set: %w", err)
+	}
+
+	// The validator set may be cached, so it must not be modified.
+	weights := make(map[ids.NodeID]uint64, len(vdrs))
+	publicKeys := make(map[ids.NodeID]*bls.PublicKey, len(vdrs))
+	for nodeID, vdr := range vdrs {
+		weights[nodeID] = vdr.Weight
+		if vdr.PublicKey != nil {
+			publicKeys[nodeID] = vdr.PublicKey
+		}
+	}
+
+	type diffKey struct {
+		height uint64
+		nodeID ids.NodeID
+	}
+	var (
+		diffs       []APIValidatorDiff
+		diffIndices = make(map[diffKey]int)
+		applyErr    error
+	)
+	err = s.vm.state.IterateValidatorWeightDiffs(
+		ctx,
+		endHeight,
+		startHeight+1,
+		args.SubnetID,
+		func(height uint64, nodeID ids.NodeID, diff *state.ValidatorWeightDiff) bool {
+			// Pages only end on height boundaries so that every page describes
+			// complete blocks.
+			if len(diffs) >= limit && uint64(diffs[len(diffs)-1].Height) != height {
+				nextEndHeight := avajson.Uint64(height)
+				reply.NextEndHeight = &nextEndHeight
+				return false
+			}
+
+			weight := weights[nodeID]
+			var prevWeight uint64
+			if diff.Decrease {
+				prevWeight, applyErr = safemath.Add(weight, diff.Amount)
+			} else {
+				prevWeight, applyErr = safemath.Sub(weight, diff.Amount)
+			}
+			if applyErr != nil {
+				applyErr = fmt.Errorf("failed to apply weight diff of %s at height %d: %w",
+					nodeID,
+					height,
+					applyErr,
+				)
+				return false
+			}
+
+			diffType := ValidatorDiffWeightChanged
+			switch {
+			case prevWeight == 0:
+				diffType = ValidatorDiffAdded
+			case weight == 0:
+				diffType = ValidatorDiffRemoved
+			}
+
+			diffIndices[diffKey{height: height, nodeID: nodeID}] = len(diffs)
+			diffs = append(diffs, APIValidatorDiff{
+				Height:         avajson.Uint64(height),
+				NodeID:         nodeID,
+				Type:           diffType,
+				PreviousWeight: avajson.Uint64(prevWeight),
+				Weight:         avajson.Uint64(weight),
+			})
+			weights[nodeID] = prevWeight
+			return true
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to iterate weight diffs: %w", err)
+	}
+	if applyErr != nil {
+		return applyErr
+	}
+
+	// BLS keys are only tracked for the primary network.
+	if args.SubnetID == constants.PrimaryNetworkID && len(diffs) > 0 {
+		lowestHeight := uint64(diffs[len(diffs)-1].Height)
+		err = s.vm.state.IterateValidatorPublicKeyDiffs(
+			ctx,
+			endHeight,
+			lowestHeight,
+			func(height uint64, nodeID ids.NodeID, prevPublicKey *bls.PublicKey) bool {
+				if i, ok := diffIndices[diffKey{height: height, nodeID: nodeID}]; ok {
+					diffs[i].PublicKey, applyErr = encodePublicKey(publicKeys[nodeID])
+					if applyErr != nil {
+						return false
+					}
+					diffs[i].PreviousPublicKey, applyErr = encodePublicKey(prevPublicKey)
+					if applyErr != nil {
+						return false
+					}
+				}
+
+				if prevPublicKey == nil {
+					delete(publicKeys, nodeID)
+				} else {
+					publicKeys[nodeID] = prevPublicKey
+				}
+				return true
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to iterate public key diffs: %w", err)
+		}
+		if applyErr != nil {
+			return fmt.Errorf("failed to encode public key: %w", applyErr)
+		}
+	}
+
+	reply.Diffs = diffs
+	if reply.Diffs == nil {
+		reply.Diffs = []APIValidatorDiff{}
+	}
+	return nil
+}
+
+// encodePublicKey returns the hex encoding of the compressed [pk], or nil if
+// [pk] is nil.
+func encodePublicKey(pk *bls.PublicKey) (*string, error) {
+	if pk == nil {
+		return nil, nil
+	}
+	pkStr, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToCompressedBytes(pk))
+	if err != nil {
+		return nil, err
+	}
+	return &pkStr, nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -1888,6 +3294,382 @@ func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateRe
 	return nil
 }
 
+// EstimateFeeArgs are the arguments for calling EstimateFee
+type EstimateFeeArgs struct {
+	// Tx is the byte representation of a signed or unsigned tx to estimate
+	// the fee of. If provided, [TxType], [NumInputs] and [NumOutputs] are
+	// ignored.
+	Tx string `json:"tx"`
+	// Encoding of [Tx]
+	Encoding formatting.Encoding `json:"encoding"`
+	// TxType is the name of the tx type to estimate the fee of, e.g. "BaseTx"
+	TxType string `json:"txType"`
+	// NumInputs is the number of single signature inputs of the tx
+	NumInputs avajson.Uint32 `json:"numInputs"`
+	// NumOutputs is the number of single address outputs of the tx
+	NumOutputs avajson.Uint32 `json:"numOutputs"`
+	// ProjectionSeconds is how far into the future to project the fee
+	ProjectionSeconds avajson.Uint64 `json:"projectionSeconds"`
+	// ProjectionInterval is the number of seconds between fee projections. If
+	// 0, defaults to 1.
+	ProjectionInterval avajson.Uint64 `json:"projectionInterval"`
+}
+
+// EstimateFeeReply is the response from calling EstimateFee
+type EstimateFeeReply struct {
+	Complexity gas.Dimensions `json:"complexity"`
+	Gas        gas.Gas        `json:"gas"`
+	APIFeeEstimate
+	// ProjectedFees are the fees of the tx over the next [ProjectionSeconds],
+	// assuming that no gas is consumed
+	ProjectedFees []APIFeeEstimate `json:"projectedFees"`
+}
+
+// APIFeeEstimate is the fee of a tx at a point in time
+type APIFeeEstimate struct {
+	Price gas.Price      `json:"price"`
+	Fee   avajson.Uint64 `json:"fee"`
+	Time  time.Time      `json:"timestamp"`
+}
+
+// EstimateFee returns the fee a tx is required to burn if it were included in
+// the next block, along with the projected fees over the following seconds.
+func (s *Service) EstimateFee(_ *http.Request, args *EstimateFeeArgs, reply *EstimateFeeReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "estimateFee"),
+	)
+
+	var err error
+	reply.Complexity, err = getEstimateFeeComplexity(args)
+	if err != nil {
+		return err
+	}
+
+	interval := uint64(args.ProjectionInterval)
+	if interval == 0 {
+		interval = 1
+	}
+	numProjections := uint64(args.ProjectionSeconds) / interval
+	if numProjections > maxFeeProjections {
+		return fmt.Errorf("%w: %d > %d", errTooManyFeeProjections, numProjections, maxFeeProjections)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	preferredID := s.vm.manager.Preferred()
+	preferredState, ok := s.vm.manager.GetState(preferredID)
+	if !ok {
+		return fmt.Errorf("could not retrieve state for block %s", preferredID)
+	}
+
+	nextBlkTime, _, err := state.NextBlockTime(preferredState, &s.vm.clock)
+	if err != nil {
+		return err
+	}
+	if !s.vm.Config.UpgradeConfig.IsEtnaActivated(nextBlkTime) {
+		return errDynamicFeesNotActive
+	}
+
+	config := s.vm.DynamicFeeConfig
+	reply.Gas, err = reply.Complexity.ToGas(config.Weights)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate gas: %w", err)
+	}
+
+	var (
+		parentTime = preferredState.GetTimestamp()
+		feeState   = preferredState.GetFeeState().AdvanceTime(
+			config.MaxCapacity,
+			config.MaxPerSecond,
+			config.TargetPerSecond,
+			uint64(nextBlkTime.Sub(parentTime)/time.Second),
+		)
+	)
+	reply.APIFeeEstimate, err = estimateFee(config, feeState, reply.Gas, nextBlkTime)
+	if err != nil {
+		return err
+	}
+
+	reply.ProjectedFees = make([]APIFeeEstimate, numProjections)
+	for i := range reply.ProjectedFees {
+		feeState = feeState.AdvanceTime(
+			config.MaxCapacity,
+			config.MaxPerSecond,
+			config.TargetPerSecond,
+			interval,
+		)
+		projectedTime := nextBlkTime.Add(time.Duration(uint64(i+1)*interval) * time.Second)
+		reply.ProjectedFees[i], err = estimateFee(config, feeState, reply.Gas, projectedTime)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func estimateFee(config gas.Config, feeState gas.State, gasUsed gas.Gas, timestamp time.Time) (APIFeeEstimate, error) {
+	price := gas.CalculatePrice(
+		config.MinPrice,
+		feeState.Excess,
+		config.ExcessConversionConstant,
+	)
+	txFee, err := gasUsed.Cost(price)
+	if err != nil {
+		return APIFeeEstimate{}, fmt.Errorf("couldn't calculate fee: %w", err)
+	}
+	return APIFeeEstimate{
+		Price: price,
+		Fee:   avajson.Uint64(txFee),
+		Time:  timestamp,
+	}, nil
+}
+
+// getEstimateFeeComplexity returns the complexity of the tx described by
+// [args].
+func getEstimateFeeComplexity(args *EstimateFeeArgs) (gas.Dimensions, error) {
+	if args.Tx == "" {
+		complexity, err := estimateTxComplexity(
+			args.TxType,
+			uint64(args.NumInputs),
+			uint64(args.NumOutputs),
+		)
+		if err != nil {
+			return gas.Dimensions{}, fmt.Errorf("couldn't estimate complexity: %w", err)
+		}
+		return complexity, nil
+	}
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return gas.Dimensions{}, fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, _, err := parseSignedOrUnsignedTx(txBytes)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	complexity, err := fee.TxComplexity(tx.Unsigned)
+	if err != nil {
+		return gas.Dimensions{}, fmt.Errorf("couldn't calculate complexity: %w", err)
+	}
+	return complexity, nil
+}
+
+// estimateTxComplexity estimates the complexity of a tx of type [txType] with
+// [numInputs] single signature inputs and [numOutputs] single address outputs.
+//
+// Type specific fields of variable size, such as owners, are not included.
+func estimateTxComplexity(txType string, numInputs uint64, numOutputs uint64) (gas.Dimensions, error) {
+	intrinsicComplexity, ok := intrinsicTxComplexities[txType]
+	if !ok {
+		return gas.Dimensions{}, fmt.Errorf("%w: %q", errUnsupportedTxType, txType)
+	}
+
+	inputComplexity, err := fee.InputComplexity(&avax.TransferableInput{
+		In: &secp256k1fx.TransferInput{
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	})
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	outputComplexity, err := fee.OutputComplexity(&avax.TransferableOutput{
+		Out: &secp256k1fx.TransferOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{{}},
+			},
+		},
+	})
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+
+	for i := range inputComplexity {
+		inputComplexity[i], err = safemath.Mul(inputComplexity[i], numInputs)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+		outputComplexity[i], err = safemath.Mul(outputComplexity[i], numOutputs)
+		if err != nil {
+			return gas.Dimensions{}, err
+		}
+	}
+	return intrinsicComplexity.Add(&inputComplexity, &outputComplexity)
+}
+
+// GetUptimeHistoryArgs are the arguments for calling GetUptimeHistory
+type GetUptimeHistoryArgs struct {
+	// Subnet to report the uptimes of
+	// If omitted, defaults to primary network
+	SubnetID ids.ID `json:"subnetID"`
+	// NodeIDs of validators to report the uptimes of. If [NodeIDs] is empty,
+	// the uptimes of all current validators are reported. If some nodeIDs are
+	// not currently validators, they will be omitted from the response.
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Unix time in seconds of the start of the period to report
+	// If omitted, defaults to a week before [EndTime]
+	StartTime avajson.Uint64 `json:"startTime"`
+	// Unix time in seconds of the end of the period to report
+	// If omitted, defaults to now
+	EndTime avajson.Uint64 `json:"endTime"`
+}
+
+// APIUptimeWindow is the uptime of a validator during a window
+type APIUptimeWindow struct {
+	// Unix time in seconds
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+	// Amount of time in seconds that the validator was considered online
+	UpDuration avajson.Uint64 `json:"upDuration"`
+	// Amount of time in seconds that the uptime of the validator was measured
+	ObservedDuration avajson.Uint64 `json:"observedDuration"`
+	// Percentage (0-100) of the observed duration that the validator was
+	// considered online
+	Uptime avajson.Float32 `json:"uptime"`
+}
+
+// APIUptimeHistory is the uptime of a validator during a period
+type APIUptimeHistory struct {
+	NodeID    ids.NodeID `json:"nodeID"`
+	Connected bool       `json:"connected"`
+	// Uptime of the validator over the whole period
+	APIUptimeWindow
+	// Uptime of the validator during each window in the period
+	Windows []APIUptimeWindow `json:"windows"`
+}
+
+// GetUptimeHistoryReply are the results from calling GetUptimeHistory
+type GetUptimeHistoryReply struct {
+	Validators []APIUptimeHistory `json:"validators"`
+}
+
+// GetUptimeHistory returns the uptime that this node observed for validators
+// during each window of a period.
+func (s *Service) GetUptimeHistory(_ *http.Request, args *GetUptimeHistoryArgs, reply *GetUptimeHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getUptimeHistory"),
+	)
+
+	// Only report uptimes that we have been actively tracking.
+	if constants.PrimaryNetworkID != args.SubnetID && !s.vm.TrackedSubnets.Contains(args.SubnetID) {
+		return fmt.Errorf("%w: %s", errUntrackedSubnet, args.SubnetID)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	endTime := s.vm.clock.UnixTime()
+	if args.EndTime != 0 {
+		endTime = time.Unix(int64(args.EndTime), 0)
+	}
+	startTime := endTime.Add(-defaultUptimeHistoryDuration)
+	if args.StartTime != 0 {
+		startTime = time.Unix(int64(args.StartTime), 0)
+	}
+	switch period := endTime.Sub(startTime); {
+	case period <= 0:
+		return fmt.Errorf("%w: start time %d isn't before end time %d", errInvalidUptimePeriod, startTime.Unix(), endTime.Unix())
+	case period > maxUptimeHistoryDuration:
+		return fmt.Errorf("%w: period %s exceeds maximum %s", errInvalidUptimePeriod, period, maxUptimeHistoryDuration)
+	}
+
+	var stakers []*state.Staker
+	if len(args.NodeIDs) == 0 {
+		currentStakerIterator, err := s.vm.state.GetCurrentStakerIterator()
+		if err != nil {
+			return err
+		}
+		for currentStakerIterator.Next() {
+			staker := currentStakerIterator.Value()
+			if args.SubnetID == staker.SubnetID && staker.Priority.IsCurrentValidator() {
+				stakers = append(stakers, staker)
+			}
+		}
+		currentStakerIterator.Release()
+	} else {
+		for nodeID := range set.Of(args.NodeIDs...) {
+			staker, err := s.vm.state.GetCurrentValidator(args.SubnetID, nodeID)
+			switch err {
+			case nil:
+				stakers = append(stakers, staker)
+			case database.ErrNotFound:
+				// nothing to do, continue
+			default:
+				return err
+			}
+		}
+	}
+
+	reply.Validators = make([]APIUptimeHistory, 0, len(stakers))
+	for _, staker := range stakers {
+		// There is no uptime to report prior to the validator starting.
+		validatorStartTime := startTime
+		if validatorStartTime.Before(staker.StartTime) {
+			validatorStartTime = staker.StartTime
+		}
+		if !validatorStartTime.Before(endTime) {
+			continue
+		}
+
+		samples, err := s.vm.uptimeManager.CalculateUptimeHistory(
+			staker.NodeID,
+			args.SubnetID,
+			validatorStartTime,
+			endTime,
+		)
+		if err != nil {
+			return err
+		}
+
+		var (
+			upDuration       time.Duration
+			observedDuration time.Duration
+			windows          = make([]APIUptimeWindow, len(samples))
+		)
+		for i, sample := range samples {
+			upDuration += sample.UpDuration
+			observedDuration += sample.ObservedDuration
+			windows[i] = newAPIUptimeWindow(sample)
+		}
+
+		total := newAPIUptimeWindow(uptime.Sample{
+			UpDuration:       upDuration,
+			ObservedDuration: observedDuration,
+		})
+		total.StartTime = avajson.Uint64(validatorStartTime.Unix())
+		total.EndTime = avajson.Uint64(endTime.Unix())
+		reply.Validators = append(reply.Validators, APIUptimeHistory{
+			NodeID:          staker.NodeID,
+			Connected:       s.vm.uptimeManager.IsConnected(staker.NodeID, args.SubnetID),
+			APIUptimeWindow: total,
+			Windows:         windows,
+		})
+	}
+	utils.Sort(reply.Validators)
+	return nil
+}
+
+func newAPIUptimeWindow(sample uptime.Sample) APIUptimeWindow {
+	return APIUptimeWindow{
+		StartTime:        avajson.Uint64(sample.Start.Unix()),
+		EndTime:          avajson.Uint64(sample.Start.Add(uptime.Window).Unix()),
+		UpDuration:       avajson.Uint64(sample.UpDuration / time.Second),
+		ObservedDuration: avajson.Uint64(sample.ObservedDuration / time.Second),
+		// Transform this to a percentage (0-100) to make it consistent
+		// with observedUptime in info.peers API
+		Uptime: avajson.Float32(sample.Uptime() * 100),
+	}
+}
+
+func (h APIUptimeHistory) Compare(o APIUptimeHistory) int {
+	return h.NodeID.Compare(o.NodeID)
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*avajson.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -28,21 +29,25 @@ import (
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	"github.com/f01c5700/avalanchego/snow/validators"
 	"github.com/f01c5700/avalanchego/upgrade/upgradetest"
+	"github.com/f01c5700/avalanchego/utils"
 	"github.com/f01c5700/avalanchego/utils/constants"
 	"github.com/f01c5700/avalanchego/utils/crypto/bls"
 	"github.com/f01c5700/avalanchego/utils/crypto/secp256k1"
 	"github.com/f01c5700/avalanchego/utils/formatting"
 	"github.com/f01c5700/avalanchego/utils/formatting/address"
 	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/units"
 	"github.com/f01c5700/avalanchego/vms/components/avax"
 	"github.com/f01c5700/avalanchego/vms/components/gas"
 	"github.com/f01c5700/avalanchego/vms/platformvm/block"
 	"github.com/f01c5700/avalanchego/vms/platformvm/block/executor/executormock"
 	"github.com/f01c5700/avalanchego/vms/platformvm/genesis/genesistest"
+	"github.com/f01c5700/avalanchego/vms/platformvm/reward"
 	"github.com/f01c5700/avalanchego/vms/platformvm/signer"
 	"github.com/f01c5700/avalanchego/vms/platformvm/state"
 	"github.com/f01c5700/avalanchego/vms/platformvm/status"
 	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs/fee"
 	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
 	"github.com/f01c5700/avalanchego/wallet/subnet/primary/common"
 
@@ -367,6 +372,408 @@ func TestGetTx(t *testing.T) {
 	}
 }
 
+func TestSimulateStaker(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	nodeID := genesistest.DefaultNodeIDs[0]
+	service.vm.ctx.Lock.Lock()
+	validator, err := service.vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+
+	var (
+		wallet       = newWallet(t, service.vm, walletConfig{})
+		endTime      = service.vm.clock.Time().Add(defaultMinStakingDuration)
+		maxWeight    = txexecutor.MaxValidatorWeightFactor * validator.Weight
+		rewardsOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+	newUnsignedDelegatorTx := func(weight uint64) []byte {
+		tx, err := wallet.IssueAddPermissionlessDelegatorTx(
+			&txs.SubnetValidator{
+				Validator: txs.Validator{
+					NodeID: nodeID,
+					End:    uint64(endTime.Unix()),
+					Wght:   weight,
+				},
+				Subnet: constants.PrimaryNetworkID,
+			},
+			service.vm.ctx.AVAXAssetID,
+			rewardsOwner,
+		)
+		require.NoError(err)
+
+		txBytes, err := txs.Codec.Marshal(txs.CodecVersion, &tx.Unsigned)
+		require.NoError(err)
+		return txBytes
+	}
+	validDelegatorTxBytes := newUnsignedDelegatorTx(service.vm.MinDelegatorStake)
+	overDelegatorTxBytes := newUnsignedDelegatorTx(maxWeight - validator.Weight + 1)
+	service.vm.ctx.Lock.Unlock()
+
+	simulate := func(txBytes []byte) *SimulateStakerReply {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		require.NoError(err)
+
+		reply := &SimulateStakerReply{}
+		require.NoError(service.SimulateStaker(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+
+	// The unsigned delegator is verified without its credentials
+	reply := simulate(validDelegatorTxBytes)
+	require.Empty(reply.Error)
+	require.Equal(constants.PrimaryNetworkID, reply.SubnetID)
+	require.Equal(nodeID, reply.NodeID)
+	require.Equal(avajson.Uint64(endTime.Unix()), reply.EndTime)
+	require.Equal([]APIWeightChange{
+		{
+			Time:   reply.StartTime,
+			Weight: avajson.Uint64(validator.Weight + service.vm.MinDelegatorStake),
+		},
+	}, reply.Timeline)
+	require.Equal(avajson.Uint64(validator.Weight+service.vm.MinDelegatorStake), reply.PeakWeight)
+	require.Equal(avajson.Uint64(maxWeight), reply.MaxWeight)
+	require.Equal(avajson.Uint64(maxWeight-validator.Weight-service.vm.MinDelegatorStake), reply.Headroom)
+
+	// The delegator would exceed the max weight of the validator
+	reply = simulate(overDelegatorTxBytes)
+	require.Equal(txexecutor.ErrOverDelegated.Error(), reply.Error)
+	require.Equal(avajson.Uint64(maxWeight+1), reply.PeakWeight)
+	require.Zero(reply.Headroom)
+}
+
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	service.vm.ctx.Lock.Lock()
+	wallet := newWallet(t, service.vm, walletConfig{})
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		endTime      = service.vm.clock.Time().Add(defaultMinStakingDuration)
+		rewardsOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+	tx, err := wallet.IssueAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				End:    uint64(endTime.Unix()),
+				Wght:   service.vm.MinValidatorStake,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		signer.NewProofOfPossession(sk),
+		service.vm.ctx.AVAXAssetID,
+		rewardsOwner,
+		rewardsOwner,
+		reward.PercentDenominator,
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	simulate := func(txBytes []byte) *SimulateTxReply {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		require.NoError(err)
+
+		reply := &SimulateTxReply{}
+		require.NoError(service.SimulateTx(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+
+	// The signed tx is valid
+	reply := simulate(tx.Bytes())
+	require.Equal(tx.ID(), reply.TxID)
+	require.Empty(reply.Error)
+	require.NotNil(reply.Fee)
+	require.NotNil(reply.Complexity)
+
+	expectedConsumedUTXOIDs := tx.InputIDs().List()
+	utils.Sort(expectedConsumedUTXOIDs)
+	require.Equal(expectedConsumedUTXOIDs, reply.ConsumedUTXOIDs)
+	require.Len(reply.ProducedUTXOs, len(tx.UTXOs()))
+
+	require.Len(reply.StakerChanges, 1)
+	stakerChange := reply.StakerChanges[0]
+	require.Equal(tx.ID(), stakerChange.TxID)
+	require.Equal(nodeID, stakerChange.NodeID)
+	require.Equal(constants.PrimaryNetworkID, stakerChange.SubnetID)
+	require.Equal(avajson.Uint64(service.vm.MinValidatorStake), stakerChange.Weight)
+	require.Equal(avajson.Uint64(endTime.Unix()), stakerChange.EndTime)
+	require.False(stakerChange.Delegator)
+	require.False(stakerChange.Pending)
+	require.False(stakerChange.Removed)
+
+	// The tx was not issued
+	_, ok := service.vm.Builder.Get(tx.ID())
+	require.False(ok)
+
+	// The unsigned tx is valid without credentials
+	unsignedTxBytes, err := txs.Codec.Marshal(txs.CodecVersion, &tx.Unsigned)
+	require.NoError(err)
+
+	signedFee := reply.Fee
+	reply = simulate(unsignedTxBytes)
+	require.NotEqual(tx.ID(), reply.TxID)
+	require.Empty(reply.Error)
+	require.Equal(signedFee, reply.Fee)
+	require.Equal(expectedConsumedUTXOIDs, reply.ConsumedUTXOIDs)
+
+	require.Len(reply.StakerChanges, 1)
+	stakerChange = reply.StakerChanges[0]
+	require.Equal(reply.TxID, stakerChange.TxID)
+	require.Equal(nodeID, stakerChange.NodeID)
+	require.Equal(avajson.Uint64(service.vm.MinValidatorStake), stakerChange.Weight)
+
+	// The amounts of the unsigned tx are still verified
+	var invalidUnsignedTx txs.UnsignedTx
+	_, err = txs.Codec.Unmarshal(unsignedTxBytes, &invalidUnsignedTx)
+	require.NoError(err)
+	in := invalidUnsignedTx.(*txs.AddPermissionlessValidatorTx).Ins[0].In.(*secp256k1fx.TransferInput)
+	in.Amt++
+
+	invalidUnsignedTxBytes, err := txs.Codec.Marshal(txs.CodecVersion, &invalidUnsignedTx)
+	require.NoError(err)
+
+	reply = simulate(invalidUnsignedTxBytes)
+	require.Contains(reply.Error, secp256k1fx.ErrMismatchedAmounts.Error())
+	require.Empty(reply.ConsumedUTXOIDs)
+	require.Empty(reply.StakerChanges)
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	service.vm.ctx.Lock.Lock()
+	wallet := newWallet(t, service.vm, walletConfig{})
+	tx, err := wallet.IssueCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	var mempoolReply GetMempoolReply
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{}, &mempoolReply))
+	require.Empty(mempoolReply.Txs)
+	require.Nil(mempoolReply.NextCursor)
+
+	var mempoolTxReply GetMempoolTxReply
+	err = service.GetMempoolTx(nil, &api.GetTxArgs{
+		TxID:     tx.ID(),
+		Encoding: formatting.Hex,
+	}, &mempoolTxReply)
+	require.ErrorIs(err, errTxNotInMempool)
+
+	require.NoError(service.vm.Network.IssueTxFromRPC(tx))
+
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{}, &mempoolReply))
+	require.Len(mempoolReply.Txs, 1)
+	require.Nil(mempoolReply.NextCursor)
+	mempoolTx := mempoolReply.Txs[0]
+	require.Equal(tx.ID(), mempoolTx.TxID)
+	require.Equal("CreateSubnetTx", mempoolTx.Type)
+	require.Equal(avajson.Uint64(tx.Size()), mempoolTx.Size)
+	require.NotNil(mempoolTx.Fee)
+	require.NotNil(mempoolTx.Complexity)
+	require.False(mempoolTx.TimeAdded.IsZero())
+
+	require.NoError(service.GetMempoolTx(nil, &api.GetTxArgs{
+		TxID:     tx.ID(),
+		Encoding: formatting.Hex,
+	}, &mempoolTxReply))
+	require.Equal(mempoolTx, mempoolTxReply.APIMempoolTx)
+
+	var txStr string
+	require.NoError(json.Unmarshal(mempoolTxReply.Tx, &txStr))
+	txBytes, err := formatting.Decode(mempoolTxReply.Encoding, txStr)
+	require.NoError(err)
+	require.Equal(tx.Bytes(), txBytes)
+}
+
+func TestGetMempoolPagination(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	// Each tx is funded by a different key so that the txs don't conflict.
+	mempoolTxs := make([]*txs.Tx, 3)
+	service.vm.ctx.Lock.Lock()
+	for i := range mempoolTxs {
+		wallet := newWallet(t, service.vm, walletConfig{
+			keys: genesistest.DefaultFundedKeys[i : i+1],
+		})
+		tx, err := wallet.IssueCreateSubnetTx(
+			&secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		)
+		require.NoError(err)
+		mempoolTxs[i] = tx
+	}
+	service.vm.ctx.Lock.Unlock()
+
+	for _, tx := range mempoolTxs {
+		require.NoError(service.vm.Network.IssueTxFromRPC(tx))
+	}
+
+	var reply GetMempoolReply
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{
+		Limit: 2,
+	}, &reply))
+	require.Len(reply.Txs, 2)
+	require.Equal(mempoolTxs[0].ID(), reply.Txs[0].TxID)
+	require.Equal(mempoolTxs[1].ID(), reply.Txs[1].TxID)
+	require.NotNil(reply.NextCursor)
+	require.Equal(mempoolTxs[1].ID(), *reply.NextCursor)
+
+	cursor := *reply.NextCursor
+	reply = GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, &GetMempoolArgs{
+		Limit:  2,
+		Cursor: cursor,
+	}, &reply))
+	require.Len(reply.Txs, 1)
+	require.Equal(mempoolTxs[2].ID(), reply.Txs[0].TxID)
+	require.Nil(reply.NextCursor)
+
+	err := service.GetMempool(nil, &GetMempoolArgs{
+		Cursor: ids.GenerateTestID(),
+	}, &reply)
+	require.ErrorIs(err, errTxNotInMempool)
+}
+
+func TestGetDroppedTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	var reply GetDroppedTxsReply
+	require.NoError(service.GetDroppedTxs(nil, nil, &reply))
+	require.Empty(reply.Txs)
+
+	var (
+		txID    = ids.GenerateTestID()
+		testErr = errors.New("test")
+	)
+	service.vm.Builder.MarkDropped(txID, testErr)
+
+	require.NoError(service.GetDroppedTxs(nil, nil, &reply))
+	require.Equal([]APIDroppedTx{{
+		TxID:   txID,
+		Reason: testErr.Error(),
+	}}, reply.Txs)
+}
+
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		addr  = ids.GenerateTestShortID()
+		txIDs = []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+		ctx   = &snow.Context{Log: logging.NoLog{}}
+	)
+	mockState := state.NewMockState(ctrl)
+	service := &Service{
+		vm: &VM{
+			state: mockState,
+			ctx:   ctx,
+		},
+		addrManager: avax.NewAddressManager(ctx),
+	}
+
+	tests := []struct {
+		name          string
+		args          GetAddressTxsArgs
+		setup         func()
+		expectedReply GetAddressTxsReply
+		expectedErr   error
+	}{
+		{
+			name: "default page size",
+			args: GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+				Cursor:      3,
+			},
+			setup: func() {
+				mockState.EXPECT().GetAddressTxs(addr, uint64(3), maxPageSize).Return(txIDs, nil)
+			},
+			expectedReply: GetAddressTxsReply{
+				TxIDs:  txIDs,
+				Cursor: 5,
+			},
+		},
+		{
+			name: "page size capped",
+			args: GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+				PageSize:    maxPageSize + 1,
+			},
+			setup: func() {
+				mockState.EXPECT().GetAddressTxs(addr, uint64(0), maxPageSize).Return(txIDs, nil)
+			},
+			expectedReply: GetAddressTxsReply{
+				TxIDs:  txIDs,
+				Cursor: 2,
+			},
+		},
+		{
+			name: "no more txs",
+			args: GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+				Cursor:      2,
+				PageSize:    1,
+			},
+			setup: func() {
+				mockState.EXPECT().GetAddressTxs(addr, uint64(2), 1).Return(nil, nil)
+			},
+			expectedReply: GetAddressTxsReply{
+				TxIDs:  []ids.ID{},
+				Cursor: 2,
+			},
+		},
+		{
+			name: "index disabled",
+			args: GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+			},
+			setup: func() {
+				mockState.EXPECT().GetAddressTxs(addr, uint64(0), maxPageSize).Return(nil, state.ErrAddressTxsIndexDisabled)
+			},
+			expectedErr: state.ErrAddressTxsIndexDisabled,
+		},
+	}
+	for _, test := range tests {
+		test.setup()
+
+		var reply GetAddressTxsReply
+		err := service.GetAddressTxs(nil, &test.args, &reply)
+		require.ErrorIs(err, test.expectedErr, test.name)
+		if test.expectedErr != nil {
+			continue
+		}
+		require.Equal(test.expectedReply, reply, test.name)
+	}
+}
+
 func TestGetBalance(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t, upgradetest.Durango)
@@ -742,6 +1149,265 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetUptimeHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	var (
+		connectedNodeID    = genesistest.DefaultNodeIDs[0]
+		disconnectedNodeID = genesistest.DefaultNodeIDs[1]
+	)
+
+	service.vm.ctx.Lock.Lock()
+	require.NoError(service.vm.uptimeManager.Connect(connectedNodeID, constants.PrimaryNetworkID))
+	service.vm.clock.Set(service.vm.clock.Time().Add(2 * time.Hour))
+	service.vm.ctx.Lock.Unlock()
+
+	args := GetUptimeHistoryArgs{
+		SubnetID: constants.PrimaryNetworkID,
+		NodeIDs:  []ids.NodeID{disconnectedNodeID, connectedNodeID, ids.GenerateTestNodeID()},
+	}
+	reply := GetUptimeHistoryReply{}
+	require.NoError(service.GetUptimeHistory(nil, &args, &reply))
+	require.Len(reply.Validators, 2)
+
+	for _, vdr := range reply.Validators {
+		var (
+			upDuration       avajson.Uint64
+			observedDuration avajson.Uint64
+		)
+		for _, window := range vdr.Windows {
+			upDuration += window.UpDuration
+			observedDuration += window.ObservedDuration
+		}
+		require.Equal(vdr.UpDuration, upDuration)
+		require.Equal(vdr.ObservedDuration, observedDuration)
+
+		switch vdr.NodeID {
+		case connectedNodeID:
+			require.True(vdr.Connected)
+			require.Equal(vdr.ObservedDuration, vdr.UpDuration)
+		case disconnectedNodeID:
+			require.False(vdr.Connected)
+			require.Equal(vdr.ObservedDuration-avajson.Uint64((2*time.Hour)/time.Second), vdr.UpDuration)
+		default:
+			require.FailNow("unexpected validator", vdr.NodeID)
+		}
+	}
+
+	// The start of the period must be before the end
+	args.StartTime = avajson.Uint64(service.vm.clock.Unix())
+	args.EndTime = args.StartTime
+	err := service.GetUptimeHistory(nil, &args, &reply)
+	require.ErrorIs(err, errInvalidUptimePeriod)
+
+	// Only the uptimes of tracked subnets are reported
+	args = GetUptimeHistoryArgs{
+		SubnetID: ids.GenerateTestID(),
+	}
+	err = service.GetUptimeHistory(nil, &args, &reply)
+	require.ErrorIs(err, errUntrackedSubnet)
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	genesis := genesistest.New(t, genesistest.Config{})
+	validatorTx := genesis.Validators[0]
+	uValidatorTx := validatorTx.Unsigned.(*txs.AddValidatorTx)
+	nodeID := uValidatorTx.NodeID()
+
+	service.vm.ctx.Lock.Lock()
+
+	wallet := newWallet(t, service.vm, walletConfig{})
+	delTx, err := wallet.IssueAddDelegatorTx(
+		&txs.Validator{
+			NodeID: nodeID,
+			Start:  genesistest.DefaultValidatorStartTimeUnix,
+			End:    uint64(genesistest.DefaultValidatorStartTime.Add(defaultMinStakingDuration).Unix()),
+			Wght:   service.vm.MinDelegatorStake,
+		},
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	)
+	require.NoError(err)
+
+	delegator, err := state.NewCurrentStaker(
+		delTx.ID(),
+		delTx.Unsigned.(*txs.AddDelegatorTx),
+		genesistest.DefaultValidatorStartTime,
+		12345,
+	)
+	require.NoError(err)
+
+	service.vm.state.PutCurrentDelegator(delegator)
+	service.vm.state.AddTx(delTx, status.Committed)
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	expectedDelegationFee, _ := reward.Split(delegator.PotentialReward, uValidatorTx.Shares())
+
+	var reply GetRewardHistoryReply
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		TxIDs: []ids.ID{validatorTx.ID(), delTx.ID()},
+	}, &reply))
+	require.Len(reply.Stakers, 2)
+
+	validatorHistory := reply.Stakers[0]
+	require.Equal(validatorTx.ID(), validatorHistory.TxID)
+	require.Equal(nodeID, validatorHistory.NodeID)
+	require.Equal(constants.PrimaryNetworkID, validatorHistory.SubnetID)
+	require.Equal(StakerTypeValidator, validatorHistory.Type)
+	require.Equal(StakerStatusCurrent, validatorHistory.Status)
+	require.Equal(avajson.Uint64(uValidatorTx.Weight()), validatorHistory.Weight)
+	require.NotNil(validatorHistory.PotentialReward)
+	require.NotNil(validatorHistory.Uptime)
+	require.Nil(validatorHistory.DelegationFee)
+	require.Equal(avajson.Uint64(0), *validatorHistory.DelegateeReward)
+	require.Empty(validatorHistory.RewardUTXOs)
+
+	delegatorHistory := reply.Stakers[1]
+	require.Equal(StakerTypeDelegator, delegatorHistory.Type)
+	require.Equal(StakerStatusCurrent, delegatorHistory.Status)
+	require.Equal(avajson.Uint64(delegator.PotentialReward), *delegatorHistory.PotentialReward)
+	require.Equal(avajson.Uint64(expectedDelegationFee), *delegatorHistory.DelegationFee)
+	require.Nil(delegatorHistory.Uptime)
+	require.Nil(delegatorHistory.DelegateeReward)
+
+	// Reward the delegator
+	service.vm.ctx.Lock.Lock()
+
+	rewardTx, err := blockbuilder.NewRewardValidatorTx(service.vm.ctx, delTx.ID())
+	require.NoError(err)
+	rewardUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        delTx.ID(),
+			OutputIndex: 2,
+		},
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: delegator.PotentialReward - expectedDelegationFee,
+		},
+	}
+	service.vm.state.AddTx(rewardTx, status.Committed)
+	service.vm.state.DeleteCurrentDelegator(delegator)
+	service.vm.state.AddRewardUTXO(delTx.ID(), rewardUTXO)
+	require.NoError(service.vm.state.Commit())
+
+	service.vm.ctx.Lock.Unlock()
+
+	reply = GetRewardHistoryReply{}
+	require.NoError(service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		TxIDs:    []ids.ID{delTx.ID()},
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Len(reply.Stakers, 1)
+
+	rewardUTXOBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, rewardUTXO)
+	require.NoError(err)
+	rewardUTXOStr, err := formatting.Encode(formatting.Hex, rewardUTXOBytes)
+	require.NoError(err)
+
+	delegatorHistory = reply.Stakers[0]
+	require.Equal(StakerStatusRewarded, delegatorHistory.Status)
+	require.Equal(avajson.Uint64(genesistest.DefaultValidatorStartTimeUnix), delegatorHistory.StartTime)
+	require.Equal(avajson.Uint64(delegator.PotentialReward), *delegatorHistory.PotentialReward)
+	require.Equal(avajson.Uint64(expectedDelegationFee), *delegatorHistory.DelegationFee)
+	require.Equal([]string{rewardUTXOStr}, delegatorHistory.RewardUTXOs)
+
+	// Only txs that add stakers have a reward history
+	err = service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		TxIDs: []ids.ID{testSubnet1.ID()},
+	}, &reply)
+	require.ErrorIs(err, errNotStakerTx)
+
+	err = service.GetRewardHistory(nil, &GetRewardHistoryArgs{
+		TxIDs: []ids.ID{ids.GenerateTestID()},
+	}, &reply)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestEstimateReward(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	service.vm.ctx.Lock.Lock()
+	currentSupply, err := service.vm.state.GetCurrentSupply(constants.PrimaryNetworkID)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	var (
+		weight           = service.vm.MinValidatorStake
+		calculator       = reward.NewCalculator(service.vm.RewardConfig)
+		potentialReward  = calculator.Calculate(defaultMinStakingDuration, weight, currentSupply)
+		delegationFee, _ = reward.Split(potentialReward, genesistest.ValidatorDelegationShares)
+	)
+
+	tests := []struct {
+		name          string
+		args          EstimateRewardArgs
+		expectedReply EstimateRewardReply
+		expectedErr   error
+	}{
+		{
+			name: "validator",
+			args: EstimateRewardArgs{
+				Weight:   avajson.Uint64(weight),
+				Duration: avajson.Uint64(defaultMinStakingDuration / time.Second),
+			},
+			expectedReply: EstimateRewardReply{
+				PotentialReward: avajson.Uint64(potentialReward),
+				Reward:          avajson.Uint64(potentialReward),
+				CurrentSupply:   avajson.Uint64(currentSupply),
+			},
+		},
+		{
+			name: "delegator",
+			args: EstimateRewardArgs{
+				Weight:   avajson.Uint64(weight),
+				Duration: avajson.Uint64(defaultMinStakingDuration / time.Second),
+				NodeID:   genesistest.DefaultNodeIDs[0],
+			},
+			expectedReply: EstimateRewardReply{
+				PotentialReward: avajson.Uint64(potentialReward),
+				DelegationFee:   avajson.Uint64(delegationFee),
+				Reward:          avajson.Uint64(potentialReward - delegationFee),
+				CurrentSupply:   avajson.Uint64(currentSupply),
+			},
+		},
+		{
+			name: "duration too short",
+			args: EstimateRewardArgs{
+				Weight:   avajson.Uint64(weight),
+				Duration: avajson.Uint64(defaultMinStakingDuration/time.Second) - 1,
+			},
+			expectedErr: errInvalidStakeDuration,
+		},
+		{
+			name: "unknown validator",
+			args: EstimateRewardArgs{
+				Weight:   avajson.Uint64(weight),
+				Duration: avajson.Uint64(defaultMinStakingDuration / time.Second),
+				NodeID:   ids.GenerateTestNodeID(),
+			},
+			expectedErr: database.ErrNotFound,
+		},
+	}
+	for _, test := range tests {
+		var reply EstimateRewardReply
+		err := service.EstimateReward(nil, &test.args, &reply)
+		require.ErrorIs(err, test.expectedErr, test.name)
+		if test.expectedErr != nil {
+			continue
+		}
+		require.Equal(test.expectedReply, reply, test.name)
+	}
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t, upgradetest.Latest)
@@ -877,6 +1543,197 @@ func TestGetValidatorsAtReplyMarshalling(t *testing.T) {
 	require.Equal(reply, &parsedReply)
 }
 
+func TestGetValidatorSetDiffs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	service.vm.ctx.Lock.Lock()
+
+	wallet := newWallet(t, service.vm, walletConfig{})
+
+	var (
+		endTime      = service.vm.clock.Time().Add(defaultMinStakingDuration)
+		nodeID       = ids.GenerateTestNodeID()
+		rewardsOwner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	// Height 1 is the block that created testSubnet1.
+
+	// Height 2: add a primary network validator
+	validatorTx, err := wallet.IssueAddPermissionlessValidatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				End:    uint64(endTime.Unix()),
+				Wght:   service.vm.MinValidatorStake,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		signer.NewProofOfPossession(sk),
+		service.vm.ctx.AVAXAssetID,
+		rewardsOwner,
+		rewardsOwner,
+		reward.PercentDenominator,
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(service.vm.issueTxFromRPC(validatorTx))
+	service.vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(service.vm))
+
+	// Height 3: add a delegator to a genesis validator
+	delegateeNodeID := genesistest.DefaultNodeIDs[0]
+	delegatorTx, err := wallet.IssueAddPermissionlessDelegatorTx(
+		&txs.SubnetValidator{
+			Validator: txs.Validator{
+				NodeID: delegateeNodeID,
+				End:    uint64(endTime.Unix()),
+				Wght:   service.vm.MinDelegatorStake,
+			},
+			Subnet: constants.PrimaryNetworkID,
+		},
+		service.vm.ctx.AVAXAssetID,
+		rewardsOwner,
+	)
+	require.NoError(err)
+
+	service.vm.ctx.Lock.Unlock()
+	require.NoError(service.vm.issueTxFromRPC(delegatorTx))
+	service.vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(service.vm))
+
+	service.vm.ctx.Lock.Unlock()
+
+	encodedPK, err := formatting.Encode(formatting.HexNC, bls.PublicKeyToCompressedBytes(pk))
+	require.NoError(err)
+
+	var (
+		delegatorDiff = APIValidatorDiff{
+			Height:         3,
+			NodeID:         delegateeNodeID,
+			Type:           ValidatorDiffWeightChanged,
+			PreviousWeight: avajson.Uint64(genesistest.DefaultValidatorWeight),
+			Weight:         avajson.Uint64(genesistest.DefaultValidatorWeight + service.vm.MinDelegatorStake),
+		}
+		validatorDiff = APIValidatorDiff{
+			Height:         2,
+			NodeID:         nodeID,
+			Type:           ValidatorDiffAdded,
+			PreviousWeight: 0,
+			Weight:         avajson.Uint64(service.vm.MinValidatorStake),
+			PublicKey:      &encodedPK,
+		}
+		nextEndHeight = avajson.Uint64(2)
+	)
+	tests := []struct {
+		name          string
+		args          GetValidatorSetDiffsArgs
+		expectedReply GetValidatorSetDiffsReply
+		expectedErr   error
+	}{
+		{
+			name: "full range",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 0,
+				EndHeight:   3,
+			},
+			expectedReply: GetValidatorSetDiffsReply{
+				Diffs: []APIValidatorDiff{
+					delegatorDiff,
+					validatorDiff,
+				},
+			},
+		},
+		{
+			name: "first page",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 1,
+				EndHeight:   3,
+				Limit:       1,
+			},
+			expectedReply: GetValidatorSetDiffsReply{
+				Diffs: []APIValidatorDiff{
+					delegatorDiff,
+				},
+				NextEndHeight: &nextEndHeight,
+			},
+		},
+		{
+			name: "second page",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 1,
+				EndHeight:   2,
+				Limit:       1,
+			},
+			expectedReply: GetValidatorSetDiffsReply{
+				Diffs: []APIValidatorDiff{
+					validatorDiff,
+				},
+			},
+		},
+		{
+			name: "empty range",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 3,
+				EndHeight:   3,
+			},
+			expectedReply: GetValidatorSetDiffsReply{
+				Diffs: []APIValidatorDiff{},
+			},
+		},
+		{
+			name: "untouched subnet",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    ids.GenerateTestID(),
+				StartHeight: 0,
+				EndHeight:   3,
+			},
+			expectedReply: GetValidatorSetDiffsReply{
+				Diffs: []APIValidatorDiff{},
+			},
+		},
+		{
+			name: "start after end",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 2,
+				EndHeight:   1,
+			},
+			expectedErr: errInvalidHeightRange,
+		},
+		{
+			name: "end after current height",
+			args: GetValidatorSetDiffsArgs{
+				SubnetID:    constants.PrimaryNetworkID,
+				StartHeight: 0,
+				EndHeight:   4,
+			},
+			expectedErr: errInvalidHeightRange,
+		},
+	}
+	for _, test := range tests {
+		var reply GetValidatorSetDiffsReply
+		err := service.GetValidatorSetDiffs(&http.Request{}, &test.args, &reply)
+		require.ErrorIs(err, test.expectedErr, test.name)
+		if test.expectedErr != nil {
+			continue
+		}
+		require.Equal(test.expectedReply, reply, test.name)
+	}
+}
+
 func TestServiceGetBlockByHeight(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -1121,6 +1978,251 @@ func TestServiceGetSubnets(t *testing.T) {
 	}, response.Subnets)
 }
 
+func TestGetSubnetLifecycle(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	testSubnet1ID := testSubnet1.ID()
+	wallet := newWallet(t, service.vm, walletConfig{
+		subnetIDs: []ids.ID{testSubnet1ID},
+	})
+	createChainTx, err := wallet.IssueCreateChainTx(
+		testSubnet1ID,
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+	)
+	require.NoError(err)
+
+	require.NoError(service.vm.issueTxFromRPC(createChainTx))
+	service.vm.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(service.vm))
+	service.vm.ctx.Lock.Unlock()
+
+	createChainTxID := createChainTx.ID()
+	createdEvent := APISubnetEvent{
+		Type:   SubnetEventCreated,
+		TxID:   testSubnet1ID,
+		Height: 1,
+		Owner: &pchainapi.Owner{
+			Threshold: 2,
+			Addresses: []string{
+				"P-testing1d6kkj0qh4wcmus3tk59npwt3rluc6en72ngurd",
+				"P-testing17fpqs358de5lgu7a5ftpw2t8axf0pm33983krk",
+				"P-testing1lnk637g0edwnqc2tn8tel39652fswa3xk4r65e",
+			},
+		},
+	}
+	chainCreatedEvent := APISubnetEvent{
+		Type:      SubnetEventChainCreated,
+		TxID:      createChainTxID,
+		Height:    2,
+		ChainID:   &createChainTxID,
+		ChainName: "chain name",
+		VMID:      &constants.AVMID,
+	}
+
+	var reply GetSubnetLifecycleReply
+	require.NoError(service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: testSubnet1ID,
+	}, &reply))
+	require.Equal(GetSubnetLifecycleReply{
+		Events: []APISubnetEvent{
+			createdEvent,
+			chainCreatedEvent,
+		},
+		Cursor: 2,
+	}, reply)
+
+	// The events can be paginated.
+	reply = GetSubnetLifecycleReply{}
+	require.NoError(service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: testSubnet1ID,
+		PageSize: 1,
+	}, &reply))
+	require.Equal(GetSubnetLifecycleReply{
+		Events: []APISubnetEvent{
+			createdEvent,
+		},
+		Cursor: 1,
+	}, reply)
+
+	reply = GetSubnetLifecycleReply{}
+	require.NoError(service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: testSubnet1ID,
+		Cursor:   1,
+		PageSize: 1,
+	}, &reply))
+	require.Equal(GetSubnetLifecycleReply{
+		Events: []APISubnetEvent{
+			chainCreatedEvent,
+		},
+		Cursor: 2,
+	}, reply)
+
+	// Past the last page, no events are returned.
+	reply = GetSubnetLifecycleReply{}
+	require.NoError(service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: testSubnet1ID,
+		Cursor:   2,
+	}, &reply))
+	require.Equal(GetSubnetLifecycleReply{
+		Events: []APISubnetEvent{},
+		Cursor: 2,
+	}, reply)
+
+	err = service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: constants.PrimaryNetworkID,
+	}, &GetSubnetLifecycleReply{})
+	require.ErrorIs(err, errPrimaryNetworkIsNotASubnet)
+
+	err = service.GetSubnetLifecycle(nil, &GetSubnetLifecycleArgs{
+		SubnetID: ids.GenerateTestID(),
+	}, &GetSubnetLifecycleReply{})
+	require.ErrorIs(err, errSubnetNotFound)
+}
+
+func TestEstimateTxComplexity(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	wallet := newWallet(t, service.vm, walletConfig{})
+	tx, err := wallet.IssueBaseTx([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: service.vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.Avax,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}})
+	require.NoError(err)
+
+	expectedComplexity, err := fee.TxComplexity(tx.Unsigned)
+	require.NoError(err)
+
+	baseTx := tx.Unsigned.(*txs.BaseTx)
+	complexity, err := estimateTxComplexity(
+		"BaseTx",
+		uint64(len(baseTx.Ins)),
+		uint64(len(baseTx.Outs)),
+	)
+	require.NoError(err)
+	require.Equal(expectedComplexity, complexity)
+
+	_, err = estimateTxComplexity("AddValidatorTx", 1, 1)
+	require.ErrorIs(err, errUnsupportedTxType)
+}
+
+func TestEstimateFee(t *testing.T) {
+	tests := []struct {
+		name        string
+		fork        upgradetest.Fork
+		args        EstimateFeeArgs
+		expectedErr error
+	}{
+		{
+			name: "tx type",
+			fork: upgradetest.Latest,
+			args: EstimateFeeArgs{
+				TxType:            "BaseTx",
+				NumInputs:         1,
+				NumOutputs:        2,
+				ProjectionSeconds: 10,
+			},
+		},
+		{
+			name: "projection interval",
+			fork: upgradetest.Latest,
+			args: EstimateFeeArgs{
+				TxType:             "CreateSubnetTx",
+				ProjectionSeconds:  10,
+				ProjectionInterval: 3,
+			},
+		},
+		{
+			name: "unsupported tx type",
+			fork: upgradetest.Latest,
+			args: EstimateFeeArgs{
+				TxType: "AddValidatorTx",
+			},
+			expectedErr: errUnsupportedTxType,
+		},
+		{
+			name: "too many projections",
+			fork: upgradetest.Latest,
+			args: EstimateFeeArgs{
+				TxType:            "BaseTx",
+				ProjectionSeconds: maxFeeProjections + 1,
+			},
+			expectedErr: errTooManyFeeProjections,
+		},
+		{
+			name: "pre-etna",
+			fork: upgradetest.Durango,
+			args: EstimateFeeArgs{
+				TxType: "BaseTx",
+			},
+			expectedErr: errDynamicFeesNotActive,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			service, _ := defaultService(t, test.fork)
+
+			service.vm.ctx.Lock.Lock()
+			service.vm.state.SetFeeState(gas.State{
+				Excess: 100_000,
+			})
+			service.vm.ctx.Lock.Unlock()
+
+			var reply EstimateFeeReply
+			err := service.EstimateFee(nil, &test.args, &reply)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			expectedComplexity, err := estimateTxComplexity(
+				test.args.TxType,
+				uint64(test.args.NumInputs),
+				uint64(test.args.NumOutputs),
+			)
+			require.NoError(err)
+			require.Equal(expectedComplexity, reply.Complexity)
+
+			expectedGas, err := expectedComplexity.ToGas(defaultDynamicFeeConfig.Weights)
+			require.NoError(err)
+			require.Equal(expectedGas, reply.Gas)
+
+			expectedFee, err := reply.Gas.Cost(reply.Price)
+			require.NoError(err)
+			require.Equal(avajson.Uint64(expectedFee), reply.Fee)
+
+			interval := max(uint64(test.args.ProjectionInterval), 1)
+			require.Len(reply.ProjectedFees, int(uint64(test.args.ProjectionSeconds)/interval))
+
+			// As no gas is consumed, the projected fees should decrease.
+			previous := reply.APIFeeEstimate
+			for i, projected := range reply.ProjectedFees {
+				require.Equal(
+					reply.Time.Add(time.Duration(uint64(i+1)*interval)*time.Second),
+					projected.Time,
+				)
+				require.Less(projected.Price, previous.Price)
+				require.LessOrEqual(projected.Fee, previous.Fee)
+				previous = projected
+			}
+		})
+	}
+}
+
 func TestGetFeeConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/platformvm/block"
+	"github.com/f01c5700/avalanchego/vms/platformvm/status"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrAddressTxsIndexDisabled = errors.New("address transaction index is disabled")
+
+	errMissingSpentOutput = errors.New("missing spent output")
+)
+
+func (s *state) GetAddressTxs(addr ids.ShortID, cursor uint64, limit int) ([]ids.ID, error) {
+	if s.addressTxsIndex == nil {
+		return nil, ErrAddressTxsIndexDisabled
+	}
+	if err := s.verifyIndexComplete(s.addressTxsIndex); err != nil {
+		return nil, err
+	}
+
+	values, err := readList(s.addressTxsDB, addr[:], cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]ids.ID, len(values))
+	for i, value := range values {
+		txIDs[i], err = ids.ToID(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txIDs, nil
+}
+
+// indexAddressTxs appends the IDs of the txs accepted in [blk] to the lists
+// of the addresses they touched.
+func (s *state) indexAddressTxs(blk block.Block) error {
+	for _, tx := range s.getIndexedTxs(blk) {
+		txID := tx.ID()
+		_, txStatus, err := s.GetTx(txID)
+		if err != nil {
+			return fmt.Errorf("failed to get status of tx %s: %w", txID, err)
+		}
+
+		// Aborted txs don't modify any UTXOs, with the exception of reward
+		// txs, which return the stake regardless of the outcome.
+		_, isRewardTx := tx.Unsigned.(*txs.RewardValidatorTx)
+		if txStatus != status.Committed && !isRewardTx {
+			continue
+		}
+
+		addrs, err := s.getTxAddresses(tx)
+		if err != nil {
+			return fmt.Errorf("failed to get addresses of tx %s: %w", txID, err)
+		}
+		for addr := range addrs {
+			if err := appendToList(s.addressTxsDB, addr[:], txID[:]); err != nil {
+				return fmt.Errorf("failed to index tx %s of %s: %w", txID, addr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getTxAddresses returns the owners of the outputs consumed and produced by
+// [tx], including stake outputs and reward owners.
+func (s *state) getTxAddresses(tx *txs.Tx) (set.Set[ids.ShortID], error) {
+	var (
+		addrs   set.Set[ids.ShortID]
+		inputs  []*avax.UTXOID
+		outputs = [][]*avax.TransferableOutput{tx.Unsigned.Outputs()}
+	)
+	switch utx := tx.Unsigned.(type) {
+	case *txs.RewardValidatorTx:
+		return s.getRewardTxAddresses(utx)
+	case *txs.ImportTx:
+		// The owners of imported UTXOs are only known to shared memory, so
+		// only the local inputs are indexed.
+		inputs = utx.BaseTx.InputUTXOs()
+	case *txs.ExportTx:
+		inputs = utx.InputUTXOs()
+		outputs = append(outputs, utx.ExportedOutputs)
+	case interface{ InputUTXOs() []*avax.UTXOID }:
+		inputs = utx.InputUTXOs()
+	}
+
+	for _, utxoID := range inputs {
+		out, err := s.getSpentOutput(utxoID)
+		if err != nil {
+			return nil, err
+		}
+		if err := addOwnerAddresses(&addrs, out); err != nil {
+			return nil, err
+		}
+	}
+	if staker, ok := tx.Unsigned.(txs.PermissionlessStaker); ok {
+		outputs = append(outputs, staker.Stake())
+	}
+	for _, outs := range outputs {
+		for _, out := range outs {
+			if err := addOwnerAddresses(&addrs, out.Out); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var owners []any
+	switch utx := tx.Unsigned.(type) {
+	case txs.ValidatorTx:
+		owners = []any{utx.ValidationRewardsOwner(), utx.DelegationRewardsOwner()}
+	case txs.DelegatorTx:
+		owners = []any{utx.RewardsOwner()}
+	}
+	for _, owner := range owners {
+		if err := addOwnerAddresses(&addrs, owner); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+// getRewardTxAddresses returns the owners of the stake returned and the
+// rewards paid by [tx].
+func (s *state) getRewardTxAddresses(tx *txs.RewardValidatorTx) (set.Set[ids.ShortID], error) {
+	var addrs set.Set[ids.ShortID]
+	stakerTx, _, err := s.GetTx(tx.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staker tx %s: %w", tx.TxID, err)
+	}
+	if staker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker); ok {
+		for _, out := range staker.Stake() {
+			if err := addOwnerAddresses(&addrs, out.Out); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	rewardUTXOs, err := s.GetRewardUTXOs(tx.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward UTXOs of %s: %w", tx.TxID, err)
+	}
+	for _, utxo := range rewardUTXOs {
+		if err := addOwnerAddresses(&addrs, utxo.Out); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+// getSpentOutput returns the output referenced by [utxoID]. Because the UTXO
+// may have already been removed from the UTXO set, the output is looked up
+// from the tx that produced it.
+func (s *state) getSpentOutput(utxoID *avax.UTXOID) (any, error) {
+	if utxo, ok := s.genesisUTXOs[utxoID.InputID()]; ok {
+		return utxo.Out, nil
+	}
+
+	tx, _, err := s.GetTx(utxoID.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx %s: %w", utxoID.TxID, err)
+	}
+
+	// UTXOs produced by a tx are indexed by its outputs, followed by its stake
+	// outputs, followed by its reward outputs.
+	var (
+		outputIndex = int(utxoID.OutputIndex)
+		outs        = tx.Unsigned.Outputs()
+	)
+	if outputIndex < len(outs) {
+		return outs[outputIndex].Out, nil
+	}
+	if staker, ok := tx.Unsigned.(txs.PermissionlessStaker); ok {
+		stake := staker.Stake()
+		if stakeIndex := outputIndex - len(outs); stakeIndex < len(stake) {
+			return stake[stakeIndex].Out, nil
+		}
+	}
+
+	rewardUTXOs, err := s.GetRewardUTXOs(utxoID.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reward UTXOs of %s: %w", utxoID.TxID, err)
+	}
+	for _, utxo := range rewardUTXOs {
+		if utxo.OutputIndex == utxoID.OutputIndex {
+			return utxo.Out, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errMissingSpentOutput, utxoID)
+}
+
+func addOwnerAddresses(addrs *set.Set[ids.ShortID], owner any) error {
+	addressable, ok := owner.(avax.Addressable)
+	if !ok {
+		return nil
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return err
+		}
+		addrs.Add(addr)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/timer"
+	"github.com/f01c5700/avalanchego/utils/wrappers"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/platformvm/block"
+	"github.com/f01c5700/avalanchego/vms/platformvm/config"
+	"github.com/f01c5700/avalanchego/vms/platformvm/genesis"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+)
+
+var ErrIndexIncomplete = errors.New("index is incomplete")
+
+// blockIndex is an index derived from the txs of accepted blocks.
+//
+// Blocks are indexed in order of height as they are written. If the index is
+// behind the last accepted block, because it was enabled on a node that
+// previously ran without it, the missing blocks are indexed by
+// IndexHistoricalBlocks. Until then, blocks being written are not indexed.
+type blockIndex struct {
+	name string
+	// nextHeightKey is the key in the singletonDB that [nextHeight] is
+	// persisted under.
+	nextHeightKey []byte
+	// nextHeight is the height of the next block to index.
+	nextHeight uint64
+	indexBlock func(blk block.Block) error
+}
+
+// initBlockIndices registers the indices enabled in [execCfg]. The genesis is
+// only parsed if an index is enabled, as its txs are indexed as the txs of the
+// genesis block.
+func (s *state) initBlockIndices(execCfg *config.ExecutionConfig, genesisBytes []byte) error {
+	if !execCfg.IndexTransactions && !execCfg.IndexSubnetHistory {
+		return nil
+	}
+
+	genesis, err := genesis.Parse(genesisBytes)
+	if err != nil {
+		return err
+	}
+	s.genesisTxs = append(genesis.Validators, genesis.Chains...)
+
+	if execCfg.IndexSubnetHistory {
+		s.subnetHistoryIndex = &blockIndex{
+			name:          "subnet history",
+			nextHeightKey: SubnetHistoryNextHeightKey,
+			indexBlock:    s.indexSubnetHistory,
+		}
+		s.blockIndices = append(s.blockIndices, s.subnetHistoryIndex)
+	}
+	if execCfg.IndexTransactions {
+		s.genesisUTXOs = make(map[ids.ID]*avax.UTXO, len(genesis.UTXOs))
+		for _, utxo := range genesis.UTXOs {
+			s.genesisUTXOs[utxo.InputID()] = &utxo.UTXO
+		}
+		s.addressTxsIndex = &blockIndex{
+			name:          "address txs",
+			nextHeightKey: AddressTxsNextHeightKey,
+			indexBlock:    s.indexAddressTxs,
+		}
+		s.blockIndices = append(s.blockIndices, s.addressTxsIndex)
+	}
+	return nil
+}
+
+func (s *state) loadBlockIndices() error {
+	for _, index := range s.blockIndices {
+		// If the index was never written to, indexing starts from genesis.
+		nextHeight, err := database.GetUInt64(s.singletonDB, index.nextHeightKey)
+		if err != nil && err != database.ErrNotFound {
+			return fmt.Errorf("failed to get next height of %s index: %w", index.name, err)
+		}
+		index.nextHeight = nextHeight
+	}
+	return nil
+}
+
+// writeBlockIndices indexes the blocks being written by every index that has
+// caught up to them.
+//
+// Invariant: Must be called before writeBlocks.
+func (s *state) writeBlockIndices() error {
+	for _, index := range s.blockIndices {
+		startHeight := index.nextHeight
+		for {
+			blkID, ok := s.addedBlockIDs[index.nextHeight]
+			if !ok {
+				break
+			}
+			if err := index.indexBlock(s.addedBlocks[blkID]); err != nil {
+				return fmt.Errorf("failed to index block %s in %s index: %w", blkID, index.name, err)
+			}
+			index.nextHeight++
+		}
+		if index.nextHeight == startHeight {
+			continue
+		}
+		if err := database.PutUInt64(s.singletonDB, index.nextHeightKey, index.nextHeight); err != nil {
+			return fmt.Errorf("failed to write next height of %s index: %w", index.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *state) IndexHistoricalBlocks(lock sync.Locker, log logging.Logger) error {
+	for _, index := range s.blockIndices {
+		if err := s.indexHistoricalBlocks(index, lock, log); err != nil {
+			return fmt.Errorf("failed to populate %s index: %w", index.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *state) indexHistoricalBlocks(index *blockIndex, lock sync.Locker, log logging.Logger) error {
+	lock.Lock()
+	startHeight := index.nextHeight
+	lastAcceptedHeight, err := s.getLastAcceptedHeight()
+	lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if startHeight > lastAcceptedHeight {
+		log.Info("blocks already indexed",
+			zap.String("index", index.name),
+		)
+		return nil
+	}
+
+	log.Info("starting historical block indexing",
+		zap.String("index", index.name),
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+	)
+
+	var (
+		startTime  = time.Now()
+		nextUpdate = startTime.Add(indexLogFrequency)
+	)
+	for {
+		batchStartTime := time.Now()
+
+		// We must hold the lock while indexing to make sure that blocks are
+		// not concurrently being accepted.
+		lock.Lock()
+		nextHeight, lastAcceptedHeight, err := s.indexBlockBatch(index, indexIterationLimit)
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+		if nextHeight > lastAcceptedHeight {
+			break
+		}
+
+		now := time.Now()
+		if now.After(nextUpdate) {
+			nextUpdate = now.Add(indexLogFrequency)
+
+			eta := timer.EstimateETA(
+				startTime,
+				nextHeight-startHeight,
+				lastAcceptedHeight+1-startHeight,
+			)
+			log.Info("indexing historical blocks",
+				zap.String("index", index.name),
+				zap.Uint64("nextHeight", nextHeight),
+				zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+				zap.Duration("eta", eta),
+			)
+		}
+
+		// Avoid starving block acceptance of the lock while indexing.
+		sleepDuration := min(
+			indexIterationSleepMultiplier*now.Sub(batchStartTime),
+			indexIterationSleepCap,
+		)
+		time.Sleep(sleepDuration)
+	}
+
+	log.Info("finished historical block indexing",
+		zap.String("index", index.name),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// indexBlockBatch indexes up to [maxBlocks] accepted blocks that [index] has
+// not indexed yet and commits them. It returns the next height to index and
+// the height of the last accepted block.
+//
+// Invariant: There must not be any uncommitted changes.
+func (s *state) indexBlockBatch(index *blockIndex, maxBlocks int) (uint64, uint64, error) {
+	lastAcceptedHeight, err := s.getLastAcceptedHeight()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	startHeight := index.nextHeight
+	for i := 0; i < maxBlocks && index.nextHeight <= lastAcceptedHeight; i++ {
+		if err := s.indexNextHeight(index); err != nil {
+			index.nextHeight = startHeight
+			s.Abort()
+			return 0, 0, err
+		}
+	}
+	if err := database.PutUInt64(s.singletonDB, index.nextHeightKey, index.nextHeight); err != nil {
+		index.nextHeight = startHeight
+		s.Abort()
+		return 0, 0, err
+	}
+	if err := s.Commit(); err != nil {
+		index.nextHeight = startHeight
+		return 0, 0, err
+	}
+	return index.nextHeight, lastAcceptedHeight, nil
+}
+
+func (s *state) indexNextHeight(index *blockIndex) error {
+	height := index.nextHeight
+	blkID, err := s.GetBlockIDAtHeight(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block ID at height %d: %w", height, err)
+	}
+	blk, err := s.GetStatelessBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("failed to get block %s: %w", blkID, err)
+	}
+	if err := index.indexBlock(blk); err != nil {
+		return fmt.Errorf("failed to index block %s: %w", blkID, err)
+	}
+	index.nextHeight++
+	return nil
+}
+
+// verifyIndexComplete returns an error if [index] hasn't indexed the last
+// accepted block.
+func (s *state) verifyIndexComplete(index *blockIndex) error {
+	lastAcceptedHeight, err := s.getLastAcceptedHeight()
+	if err != nil {
+		return err
+	}
+	if index.nextHeight <= lastAcceptedHeight {
+		return fmt.Errorf("%w: %s index has indexed %d of %d blocks",
+			ErrIndexIncomplete,
+			index.name,
+			index.nextHeight,
+			lastAcceptedHeight+1,
+		)
+	}
+	return nil
+}
+
+func (s *state) getLastAcceptedHeight() (uint64, error) {
+	lastAcceptedID := s.GetLastAccepted()
+	lastAccepted, err := s.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	return lastAccepted.Height(), nil
+}
+
+// getIndexedTxs returns the txs that were accepted in [blk].
+func (s *state) getIndexedTxs(blk block.Block) []*txs.Tx {
+	// The genesis block doesn't contain the genesis txs.
+	if blk.Height() == 0 {
+		return s.genesisTxs
+	}
+	return blk.Txs()
+}
+
+// Indices store lists of values as:
+//
+//	listID                -> length of the list
+//	listID | index (BE64) -> value
+//
+// Because the length key is a strict prefix of the value keys, iterating over
+// the value keys of a list starting at any index skips the length.
+func listKey(listID []byte, index uint64) []byte {
+	key := make([]byte, len(listID)+wrappers.LongLen)
+	copy(key, listID)
+	binary.BigEndian.PutUint64(key[len(listID):], index)
+	return key
+}
+
+// appendToList appends [value] to the list [listID] in [db].
+func appendToList(db database.KeyValueReaderWriter, listID []byte, value []byte) error {
+	length, err := database.GetUInt64(db, listID)
+	if err != nil && err != database.ErrNotFound {
+		return fmt.Errorf("failed to get list length: %w", err)
+	}
+	if err := db.Put(listKey(listID, length), value); err != nil {
+		return fmt.Errorf("failed to put list value: %w", err)
+	}
+	return database.PutUInt64(db, listID, length+1)
+}
+
+// readList returns up to [limit] values of the list [listID] in [db], starting
+// from index [start].
+func readList(db database.Iteratee, listID []byte, start uint64, limit int) ([][]byte, error) {
+	it := db.NewIteratorWithStartAndPrefix(listKey(listID, start), listID)
+	defer it.Release()
+
+	var values [][]byte
+	for len(values) < limit && it.Next() {
+		values = append(values, slices.Clone(it.Value()))
+	}
+	return values, it.Error()
+}
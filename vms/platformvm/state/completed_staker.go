@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+)
+
+// CompletedStaker is the record of a staker that was removed from the current
+// staker set.
+type CompletedStaker struct {
+	TxID            ids.ID
+	NodeID          ids.NodeID
+	SubnetID        ids.ID
+	Weight          uint64
+	StartTime       time.Time
+	EndTime         time.Time
+	PotentialReward uint64
+	// Height of the block that removed the staker
+	Height uint64
+
+	// PotentialDelegateeReward is the reward that a validator accrued from the
+	// fees of its delegators. It is only populated for validators.
+	PotentialDelegateeReward uint64
+	// UpDuration and LastUpdated are the uptime of a validator as last
+	// recorded by this node. They are only populated for validators.
+	UpDuration  time.Duration
+	LastUpdated time.Time
+
+	// DelegationShares is the fee rate, out of [reward.PercentDenominator],
+	// that the validator charged a delegator. It is only populated for
+	// delegators.
+	DelegationShares uint32
+}
+
+type completedStakerMetadata struct {
+	NodeID                   ids.NodeID    `v0:"true"`
+	SubnetID                 ids.ID        `v0:"true"`
+	Weight                   uint64        `v0:"true"`
+	StartTime                uint64        `v0:"true"` // Unix time in seconds
+	EndTime                  uint64        `v0:"true"` // Unix time in seconds
+	PotentialReward          uint64        `v0:"true"`
+	Height                   uint64        `v0:"true"`
+	PotentialDelegateeReward uint64        `v0:"true"`
+	UpDuration               time.Duration `v0:"true"`
+	LastUpdated              uint64        `v0:"true"` // Unix time in seconds
+	DelegationShares         uint32        `v0:"true"`
+}
+
+func marshalCompletedStaker(staker *CompletedStaker) ([]byte, error) {
+	metadata := &completedStakerMetadata{
+		NodeID:                   staker.NodeID,
+		SubnetID:                 staker.SubnetID,
+		Weight:                   staker.Weight,
+		StartTime:                uint64(staker.StartTime.Unix()),
+		EndTime:                  uint64(staker.EndTime.Unix()),
+		PotentialReward:          staker.PotentialReward,
+		Height:                   staker.Height,
+		PotentialDelegateeReward: staker.PotentialDelegateeReward,
+		UpDuration:               staker.UpDuration,
+		DelegationShares:         staker.DelegationShares,
+	}
+	if !staker.LastUpdated.IsZero() {
+		metadata.LastUpdated = uint64(staker.LastUpdated.Unix())
+	}
+	return MetadataCodec.Marshal(CodecVersion0, metadata)
+}
+
+func unmarshalCompletedStaker(txID ids.ID, bytes []byte) (*CompletedStaker, error) {
+	var metadata completedStakerMetadata
+	if _, err := MetadataCodec.Unmarshal(bytes, &metadata); err != nil {
+		return nil, err
+	}
+	staker := &CompletedStaker{
+		TxID:                     txID,
+		NodeID:                   metadata.NodeID,
+		SubnetID:                 metadata.SubnetID,
+		Weight:                   metadata.Weight,
+		StartTime:                time.Unix(int64(metadata.StartTime), 0),
+		EndTime:                  time.Unix(int64(metadata.EndTime), 0),
+		PotentialReward:          metadata.PotentialReward,
+		Height:                   metadata.Height,
+		PotentialDelegateeReward: metadata.PotentialDelegateeReward,
+		UpDuration:               metadata.UpDuration,
+		DelegationShares:         metadata.DelegationShares,
+	}
+	if metadata.LastUpdated != 0 {
+		staker.LastUpdated = time.Unix(int64(metadata.LastUpdated), 0)
+	}
+	return staker, nil
+}
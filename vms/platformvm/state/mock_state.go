@@ -17,7 +17,9 @@ import (
 
 	database "github.com/f01c5700/avalanchego/database"
 	ids "github.com/f01c5700/avalanchego/ids"
+	uptime "github.com/f01c5700/avalanchego/snow/uptime"
 	validators "github.com/f01c5700/avalanchego/snow/validators"
+	bls "github.com/f01c5700/avalanchego/utils/crypto/bls"
 	iterator "github.com/f01c5700/avalanchego/utils/iterator"
 	logging "github.com/f01c5700/avalanchego/utils/logging"
 	avax "github.com/f01c5700/avalanchego/vms/components/avax"
@@ -293,6 +295,21 @@ func (mr *MockStateMockRecorder) DeleteUTXO(utxoID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), utxoID)
 }
 
+// GetAddressTxs mocks base method.
+func (m *MockState) GetAddressTxs(addr ids.ShortID, cursor uint64, limit int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressTxs", addr, cursor, limit)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressTxs indicates an expected call of GetAddressTxs.
+func (mr *MockStateMockRecorder) GetAddressTxs(addr, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressTxs", reflect.TypeOf((*MockState)(nil).GetAddressTxs), addr, cursor, limit)
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +340,21 @@ func (mr *MockStateMockRecorder) GetChains(subnetID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChains", reflect.TypeOf((*MockState)(nil).GetChains), subnetID)
 }
 
+// GetCompletedStaker mocks base method.
+func (m *MockState) GetCompletedStaker(txID ids.ID) (*CompletedStaker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedStaker", txID)
+	ret0, _ := ret[0].(*CompletedStaker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedStaker indicates an expected call of GetCompletedStaker.
+func (mr *MockStateMockRecorder) GetCompletedStaker(txID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedStaker", reflect.TypeOf((*MockState)(nil).GetCompletedStaker), txID)
+}
+
 // GetCurrentDelegatorIterator mocks base method.
 func (m *MockState) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (iterator.Iterator[*Staker], error) {
 	m.ctrl.T.Helper()
@@ -516,6 +548,21 @@ func (mr *MockStateMockRecorder) GetStatelessBlock(blockID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatelessBlock", reflect.TypeOf((*MockState)(nil).GetStatelessBlock), blockID)
 }
 
+// GetSubnetHistory mocks base method.
+func (m *MockState) GetSubnetHistory(subnetID ids.ID, cursor uint64, limit int) ([]*SubnetEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetHistory", subnetID, cursor, limit)
+	ret0, _ := ret[0].([]*SubnetEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetHistory indicates an expected call of GetSubnetHistory.
+func (mr *MockStateMockRecorder) GetSubnetHistory(subnetID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetHistory", reflect.TypeOf((*MockState)(nil).GetSubnetHistory), subnetID, cursor, limit)
+}
+
 // GetSubnetIDs mocks base method.
 func (m *MockState) GetSubnetIDs() ([]ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -638,6 +685,63 @@ func (mr *MockStateMockRecorder) GetUptime(nodeID, subnetID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), nodeID, subnetID)
 }
 
+// GetUptimeSample mocks base method.
+func (m *MockState) GetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, windowStart time.Time) (uptime.Sample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUptimeSample", nodeID, subnetID, windowStart)
+	ret0, _ := ret[0].(uptime.Sample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUptimeSample indicates an expected call of GetUptimeSample.
+func (mr *MockStateMockRecorder) GetUptimeSample(nodeID, subnetID, windowStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptimeSample", reflect.TypeOf((*MockState)(nil).GetUptimeSample), nodeID, subnetID, windowStart)
+}
+
+// IndexHistoricalBlocks mocks base method.
+func (m *MockState) IndexHistoricalBlocks(lock sync.Locker, log logging.Logger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexHistoricalBlocks", lock, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexHistoricalBlocks indicates an expected call of IndexHistoricalBlocks.
+func (mr *MockStateMockRecorder) IndexHistoricalBlocks(lock, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexHistoricalBlocks", reflect.TypeOf((*MockState)(nil).IndexHistoricalBlocks), lock, log)
+}
+
+// IterateValidatorPublicKeyDiffs mocks base method.
+func (m *MockState) IterateValidatorPublicKeyDiffs(ctx context.Context, startHeight, endHeight uint64, f func(uint64, ids.NodeID, *bls.PublicKey) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateValidatorPublicKeyDiffs", ctx, startHeight, endHeight, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateValidatorPublicKeyDiffs indicates an expected call of IterateValidatorPublicKeyDiffs.
+func (mr *MockStateMockRecorder) IterateValidatorPublicKeyDiffs(ctx, startHeight, endHeight, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateValidatorPublicKeyDiffs", reflect.TypeOf((*MockState)(nil).IterateValidatorPublicKeyDiffs), ctx, startHeight, endHeight, f)
+}
+
+// IterateValidatorWeightDiffs mocks base method.
+func (m *MockState) IterateValidatorWeightDiffs(ctx context.Context, startHeight, endHeight uint64, subnetID ids.ID, f func(uint64, ids.NodeID, *ValidatorWeightDiff) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateValidatorWeightDiffs", ctx, startHeight, endHeight, subnetID, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateValidatorWeightDiffs indicates an expected call of IterateValidatorWeightDiffs.
+func (mr *MockStateMockRecorder) IterateValidatorWeightDiffs(ctx, startHeight, endHeight, subnetID, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateValidatorWeightDiffs", reflect.TypeOf((*MockState)(nil).IterateValidatorWeightDiffs), ctx, startHeight, endHeight, subnetID, f)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockState) PutCurrentDelegator(staker *Staker) {
 	m.ctrl.T.Helper()
@@ -816,6 +920,20 @@ func (mr *MockStateMockRecorder) SetUptime(nodeID, subnetID, upDuration, lastUpd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptime", reflect.TypeOf((*MockState)(nil).SetUptime), nodeID, subnetID, upDuration, lastUpdated)
 }
 
+// SetUptimeSample mocks base method.
+func (m *MockState) SetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, sample uptime.Sample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUptimeSample", nodeID, subnetID, sample)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUptimeSample indicates an expected call of SetUptimeSample.
+func (mr *MockStateMockRecorder) SetUptimeSample(nodeID, subnetID, sample any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptimeSample", reflect.TypeOf((*MockState)(nil).SetUptimeSample), nodeID, subnetID, sample)
+}
+
 // UTXOIDs mocks base method.
 func (m *MockState) UTXOIDs(addr []byte, previous ids.ID, limit int) ([]ids.ID, error) {
 	m.ctrl.T.Helper()
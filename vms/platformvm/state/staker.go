@@ -29,6 +29,11 @@ type Staker struct {
 	EndTime         time.Time
 	PotentialReward uint64
 
+	// DelegationShares is the fee rate, out of [reward.PercentDenominator],
+	// that the validator charges its delegators. It is only populated for
+	// validators that can be delegated to.
+	DelegationShares uint32
+
 	// NextTime is the next time this staker will be moved from a validator set.
 	// If the staker is in the pending validator set, NextTime will equal
 	// StartTime. If the staker is in the current validator set, NextTime will
@@ -80,16 +85,17 @@ func NewCurrentStaker(
 	}
 	endTime := staker.EndTime()
 	return &Staker{
-		TxID:            txID,
-		NodeID:          staker.NodeID(),
-		PublicKey:       publicKey,
-		SubnetID:        staker.SubnetID(),
-		Weight:          staker.Weight(),
-		StartTime:       startTime,
-		EndTime:         endTime,
-		PotentialReward: potentialReward,
-		NextTime:        endTime,
-		Priority:        staker.CurrentPriority(),
+		TxID:             txID,
+		NodeID:           staker.NodeID(),
+		PublicKey:        publicKey,
+		SubnetID:         staker.SubnetID(),
+		Weight:           staker.Weight(),
+		StartTime:        startTime,
+		EndTime:          endTime,
+		PotentialReward:  potentialReward,
+		DelegationShares: delegationShares(staker),
+		NextTime:         endTime,
+		Priority:         staker.CurrentPriority(),
 	}, nil
 }
 
@@ -100,14 +106,24 @@ func NewPendingStaker(txID ids.ID, staker txs.ScheduledStaker) (*Staker, error)
 	}
 	startTime := staker.StartTime()
 	return &Staker{
-		TxID:      txID,
-		NodeID:    staker.NodeID(),
-		PublicKey: publicKey,
-		SubnetID:  staker.SubnetID(),
-		Weight:    staker.Weight(),
-		StartTime: startTime,
-		EndTime:   staker.EndTime(),
-		NextTime:  startTime,
-		Priority:  staker.PendingPriority(),
+		TxID:             txID,
+		NodeID:           staker.NodeID(),
+		PublicKey:        publicKey,
+		SubnetID:         staker.SubnetID(),
+		Weight:           staker.Weight(),
+		StartTime:        startTime,
+		EndTime:          staker.EndTime(),
+		DelegationShares: delegationShares(staker),
+		NextTime:         startTime,
+		Priority:         staker.PendingPriority(),
 	}, nil
 }
+
+// delegationShares returns the delegation fee rate of [staker]. Only validator
+// txs that implement [txs.ValidatorTx] can be delegated to.
+func delegationShares(staker txs.Staker) uint32 {
+	if validatorTx, ok := staker.(txs.ValidatorTx); ok {
+		return validatorTx.Shares()
+	}
+	return 0
+}
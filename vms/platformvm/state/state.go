@@ -83,6 +83,10 @@ var (
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
 	SingletonPrefix               = []byte("singleton")
+	UptimeHistoryPrefix           = []byte("uptimeHistory")
+	CompletedStakerPrefix         = []byte("completedStaker")
+	AddressTxsPrefix              = []byte("addressTxs")
+	SubnetHistoryPrefix           = []byte("subnetHistory")
 
 	TimestampKey       = []byte("timestamp")
 	FeeStateKey        = []byte("fee state")
@@ -91,6 +95,9 @@ var (
 	HeightsIndexedKey  = []byte("heights indexed")
 	InitializedKey     = []byte("initialized")
 	BlocksReindexedKey = []byte("blocks reindexed")
+
+	AddressTxsNextHeightKey    = []byte("address txs next height")
+	SubnetHistoryNextHeightKey = []byte("subnet history next height")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -145,6 +152,19 @@ type State interface {
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
+	// GetCompletedStaker returns the record of the staker created by [txID]
+	// once it has been removed from the current staker set. If the staker has
+	// not been removed, [database.ErrNotFound] is returned.
+	GetCompletedStaker(txID ids.ID) (*CompletedStaker, error)
+	// GetAddressTxs returns the IDs of up to [limit] txs that touched [addr],
+	// skipping the first [cursor] txs, in the order they were accepted. If the
+	// index is disabled or still being built, an error is returned.
+	GetAddressTxs(addr ids.ShortID, cursor uint64, limit int) ([]ids.ID, error)
+	// GetSubnetHistory returns up to [limit] accepted txs that created or
+	// modified [subnetID], excluding changes to its validator set, skipping the
+	// first [cursor] txs, in the order they were accepted. If the index is
+	// disabled or still being built, an error is returned.
+	GetSubnetHistory(subnetID ids.ID, cursor uint64, limit int) ([]*SubnetEvent, error)
 	GetSubnetIDs() ([]ids.ID, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
@@ -185,6 +205,40 @@ type State interface {
 		endHeight uint64,
 	) error
 
+	// IterateValidatorWeightDiffs iterates from [startHeight] towards the
+	// genesis block over all of the weight diffs of [subnetID] up to and
+	// including [endHeight]. [f] is called with the height the diff was
+	// introduced at, the node whose weight changed, and the change. Iteration
+	// stops early if [f] returns false.
+	//
+	// Note: Because this function iterates towards the genesis, [startHeight]
+	// will typically be greater than or equal to [endHeight]. If [startHeight]
+	// is less than [endHeight], [f] will not be called.
+	IterateValidatorWeightDiffs(
+		ctx context.Context,
+		startHeight uint64,
+		endHeight uint64,
+		subnetID ids.ID,
+		f func(height uint64, nodeID ids.NodeID, diff *ValidatorWeightDiff) bool,
+	) error
+
+	// IterateValidatorPublicKeyDiffs iterates from [startHeight] towards the
+	// genesis block over all of the primary network public key diffs up to and
+	// including [endHeight]. [f] is called with the height the diff was
+	// introduced at, the node whose key changed, and the key the node had
+	// prior to that height, which is nil if the node had no key. Iteration
+	// stops early if [f] returns false.
+	//
+	// Note: Because this function iterates towards the genesis, [startHeight]
+	// will typically be greater than or equal to [endHeight]. If [startHeight]
+	// is less than [endHeight], [f] will not be called.
+	IterateValidatorPublicKeyDiffs(
+		ctx context.Context,
+		startHeight uint64,
+		endHeight uint64,
+		f func(height uint64, nodeID ids.NodeID, prevPublicKey *bls.PublicKey) bool,
+	) error
+
 	SetHeight(height uint64)
 
 	// Discard uncommitted changes to the database.
@@ -198,6 +252,12 @@ type State interface {
 	// TODO: Remove after v1.12.x is activated
 	ReindexBlocks(lock sync.Locker, log logging.Logger) error
 
+	// IndexHistoricalBlocks indexes the accepted blocks that the indices
+	// derived from accepted blocks, such as the address tx index, haven't
+	// indexed yet. If all indices are up to date, this function will return
+	// immediately.
+	IndexHistoricalBlocks(lock sync.Locker, log logging.Logger) error
+
 	// Commit changes to the base database.
 	Commit() error
 
@@ -274,6 +334,8 @@ type stateBlk struct {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. uptimeHistory
+ * | '-- subnetID+nodeID+windowStart -> upDuration + observedDuration
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- blocksReindexedKey -> nil
@@ -368,6 +430,26 @@ type state struct {
 	chainDBCache cache.Cacher[ids.ID, linkeddb.LinkedDB] // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
+	addedUptimeSamples map[uptimeSampleKey]uptime.Sample // map of (subnetID, nodeID, windowStart) -> sample
+	uptimeHistoryDB    database.Database
+
+	completedStakerDB database.Database
+
+	// blockIndices are the enabled indices derived from accepted blocks
+	blockIndices []*blockIndex
+	// genesisTxs are indexed as the txs of the genesis block
+	genesisTxs []*txs.Tx
+
+	// addressTxsIndex is nil if the address tx index is disabled
+	addressTxsIndex *blockIndex
+	// genesisUTXOs is only populated if the address tx index is enabled
+	genesisUTXOs map[ids.ID]*avax.UTXO // map of inputID -> genesis UTXO
+	addressTxsDB database.Database
+
+	// subnetHistoryIndex is nil if the subnet history index is disabled
+	subnetHistoryIndex *blockIndex
+	subnetHistoryDB    database.Database
+
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	feeState, persistedFeeState           gas.State
@@ -665,9 +747,21 @@ func New(
 		chainCache:   chainCache,
 		chainDBCache: chainDBCache,
 
+		addedUptimeSamples: make(map[uptimeSampleKey]uptime.Sample),
+		uptimeHistoryDB:    prefixdb.New(UptimeHistoryPrefix, baseDB),
+
+		completedStakerDB: prefixdb.New(CompletedStakerPrefix, baseDB),
+
+		addressTxsDB:    prefixdb.New(AddressTxsPrefix, baseDB),
+		subnetHistoryDB: prefixdb.New(SubnetHistoryPrefix, baseDB),
+
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
 	}
 
+	if err := s.initBlockIndices(execCfg, genesisBytes); err != nil {
+		return nil, err
+	}
+
 	if err := s.sync(genesisBytes); err != nil {
 		return nil, errors.Join(
 			err,
@@ -1036,6 +1130,41 @@ func (s *state) GetStartTime(nodeID ids.NodeID, subnetID ids.ID) (time.Time, err
 	return staker.StartTime, nil
 }
 
+func (s *state) GetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, windowStart time.Time) (uptime.Sample, error) {
+	key := uptimeSampleKey{
+		subnetID:    subnetID,
+		nodeID:      nodeID,
+		windowStart: windowStart.Unix(),
+	}
+	if sample, ok := s.addedUptimeSamples[key]; ok {
+		return sample, nil
+	}
+
+	sampleBytes, err := s.uptimeHistoryDB.Get(marshalUptimeSampleKey(key))
+	if err == database.ErrNotFound {
+		return uptime.Sample{Start: windowStart}, nil
+	}
+	if err != nil {
+		return uptime.Sample{}, err
+	}
+	return unmarshalUptimeSample(windowStart, sampleBytes)
+}
+
+func (s *state) SetUptimeSample(nodeID ids.NodeID, subnetID ids.ID, sample uptime.Sample) error {
+	// Only validators have their uptime recorded.
+	if _, _, err := s.validatorState.GetUptime(nodeID, subnetID); err != nil {
+		return err
+	}
+
+	key := uptimeSampleKey{
+		subnetID:    subnetID,
+		nodeID:      nodeID,
+		windowStart: sample.Start.Unix(),
+	}
+	s.addedUptimeSamples[key] = sample
+	return nil
+}
+
 func (s *state) GetTimestamp() time.Time {
 	return s.timestamp
 }
@@ -1105,6 +1234,30 @@ func (s *state) ApplyValidatorWeightDiffs(
 	startHeight uint64,
 	endHeight uint64,
 	subnetID ids.ID,
+) error {
+	var applyErr error
+	err := s.IterateValidatorWeightDiffs(
+		ctx,
+		startHeight,
+		endHeight,
+		subnetID,
+		func(_ uint64, nodeID ids.NodeID, weightDiff *ValidatorWeightDiff) bool {
+			applyErr = applyWeightDiff(validators, nodeID, weightDiff)
+			return applyErr == nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return applyErr
+}
+
+func (s *state) IterateValidatorWeightDiffs(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	subnetID ids.ID,
+	f func(height uint64, nodeID ids.NodeID, diff *ValidatorWeightDiff) bool,
 ) error {
 	diffIter := s.validatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(subnetID, startHeight),
@@ -1147,8 +1300,8 @@ func (s *state) ApplyValidatorWeightDiffs(
 			return err
 		}
 
-		if err := applyWeightDiff(validators, nodeID, weightDiff); err != nil {
-			return err
+		if !f(parsedHeight, nodeID, weightDiff) {
+			return diffIter.Error()
 		}
 	}
 	return diffIter.Error()
@@ -1196,6 +1349,30 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
 	startHeight uint64,
 	endHeight uint64,
+) error {
+	// Note: this does not fallback to the linkeddb index because the linkeddb
+	// index does not contain entries for when to remove the public key.
+	//
+	// Nodes may see inconsistent public keys for heights before the new public
+	// key index was populated.
+	return s.IterateValidatorPublicKeyDiffs(
+		ctx,
+		startHeight,
+		endHeight,
+		func(_ uint64, nodeID ids.NodeID, prevPublicKey *bls.PublicKey) bool {
+			if vdr, ok := validators[nodeID]; ok {
+				vdr.PublicKey = prevPublicKey
+			}
+			return true
+		},
+	)
+}
+
+func (s *state) IterateValidatorPublicKeyDiffs(
+	ctx context.Context,
+	startHeight uint64,
+	endHeight uint64,
+	f func(height uint64, nodeID ids.NodeID, prevPublicKey *bls.PublicKey) bool,
 ) error {
 	diffIter := s.validatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
@@ -1218,25 +1395,14 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 			break
 		}
 
-		vdr, ok := validators[nodeID]
-		if !ok {
-			continue
+		var prevPublicKey *bls.PublicKey
+		if pkBytes := diffIter.Value(); len(pkBytes) != 0 {
+			prevPublicKey = bls.PublicKeyFromValidUncompressedBytes(pkBytes)
 		}
-
-		pkBytes := diffIter.Value()
-		if len(pkBytes) == 0 {
-			vdr.PublicKey = nil
-			continue
+		if !f(parsedHeight, nodeID, prevPublicKey) {
+			break
 		}
-
-		vdr.PublicKey = bls.PublicKeyFromValidUncompressedBytes(pkBytes)
 	}
-
-	// Note: this does not fallback to the linkeddb index because the linkeddb
-	// index does not contain entries for when to remove the public key.
-	//
-	// Nodes may see inconsistent public keys for heights before the new public
-	// key index was populated.
 	return diffIter.Error()
 }
 
@@ -1322,6 +1488,7 @@ func (s *state) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) er
 func (s *state) load() error {
 	return errors.Join(
 		s.loadMetadata(),
+		s.loadBlockIndices(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
@@ -1683,6 +1850,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	}
 
 	return errors.Join(
+		s.writeBlockIndices(), // Must be called before writeBlocks
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
@@ -1696,6 +1864,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeTransformedSubnets(),
 		s.writeSubnetSupplies(),
 		s.writeChains(),
+		s.writeUptimeHistory(), // Must be called after writeCurrentStakers
 		s.writeMetadata(),
 	)
 }
@@ -1720,6 +1889,10 @@ func (s *state) Close() error {
 		s.transformedSubnetDB.Close(),
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
+		s.uptimeHistoryDB.Close(),
+		s.completedStakerDB.Close(),
+		s.addressTxsDB.Close(),
+		s.subnetHistoryDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
@@ -2001,7 +2174,18 @@ func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecV
 					return fmt.Errorf("failed to delete current staker: %w", err)
 				}
 
+				if err := s.writeCompletedValidator(staker, height); err != nil {
+					return err
+				}
+
 				s.validatorState.DeleteValidatorMetadata(nodeID, subnetID)
+				if err := s.deleteUptimeHistory(nodeID, subnetID); err != nil {
+					return fmt.Errorf("failed to delete uptime history: %w", err)
+				}
+			}
+
+			if err := s.writeCompletedDelegators(subnetID, nodeID, validatorDiff, height); err != nil {
+				return err
 			}
 
 			err := writeCurrentDelegatorDiff(
@@ -2331,6 +2515,118 @@ func (s *state) writeChains() error {
 	return nil
 }
 
+func (s *state) GetCompletedStaker(txID ids.ID) (*CompletedStaker, error) {
+	stakerBytes, err := s.completedStakerDB.Get(txID[:])
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalCompletedStaker(txID, stakerBytes)
+}
+
+// writeCompletedValidator records [validator] as having been removed at
+// [height].
+//
+// Invariant: must be called before the metadata of [validator] is deleted.
+func (s *state) writeCompletedValidator(validator *Staker, height uint64) error {
+	completed := newCompletedStaker(validator, height)
+
+	var err error
+	completed.UpDuration, completed.LastUpdated, err = s.validatorState.GetUptime(validator.NodeID, validator.SubnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get uptime of completed validator: %w", err)
+	}
+	completed.PotentialDelegateeReward, err = s.validatorState.GetDelegateeReward(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get delegatee reward of completed validator: %w", err)
+	}
+	return s.putCompletedStaker(completed)
+}
+
+// writeCompletedDelegators records the delegators deleted in [validatorDiff] as
+// having been removed at [height].
+func (s *state) writeCompletedDelegators(
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	validatorDiff *diffValidator,
+	height uint64,
+) error {
+	if len(validatorDiff.deletedDelegators) == 0 {
+		return nil
+	}
+
+	validator := validatorDiff.validator
+	if validator == nil {
+		var err error
+		validator, err = s.currentStakers.GetValidator(subnetID, nodeID)
+		if err != nil {
+			return fmt.Errorf("failed to get validator of completed delegators: %w", err)
+		}
+	}
+
+	for _, delegator := range validatorDiff.deletedDelegators {
+		completed := newCompletedStaker(delegator, height)
+		completed.DelegationShares = validator.DelegationShares
+		if err := s.putCompletedStaker(completed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newCompletedStaker(staker *Staker, height uint64) *CompletedStaker {
+	return &CompletedStaker{
+		TxID:            staker.TxID,
+		NodeID:          staker.NodeID,
+		SubnetID:        staker.SubnetID,
+		Weight:          staker.Weight,
+		StartTime:       staker.StartTime,
+		EndTime:         staker.EndTime,
+		PotentialReward: staker.PotentialReward,
+		Height:          height,
+	}
+}
+
+func (s *state) putCompletedStaker(staker *CompletedStaker) error {
+	stakerBytes, err := marshalCompletedStaker(staker)
+	if err != nil {
+		return fmt.Errorf("failed to serialize completed staker: %w", err)
+	}
+	if err := s.completedStakerDB.Put(staker.TxID[:], stakerBytes); err != nil {
+		return fmt.Errorf("failed to write completed staker: %w", err)
+	}
+	return nil
+}
+
+func (s *state) writeUptimeHistory() error {
+	for key, sample := range s.addedUptimeSamples {
+		delete(s.addedUptimeSamples, key)
+
+		err := s.uptimeHistoryDB.Put(
+			marshalUptimeSampleKey(key),
+			marshalUptimeSample(sample),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to write uptime sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteUptimeHistory removes all the uptime samples of [nodeID] on
+// [subnetID], including the samples that haven't been written yet.
+func (s *state) deleteUptimeHistory(nodeID ids.NodeID, subnetID ids.ID) error {
+	for key := range s.addedUptimeSamples {
+		if key.subnetID == subnetID && key.nodeID == nodeID {
+			delete(s.addedUptimeSamples, key)
+		}
+	}
+	return database.AtomicClearPrefix(
+		s.uptimeHistoryDB,
+		s.uptimeHistoryDB,
+		marshalUptimeSamplePrefix(subnetID, nodeID),
+	)
+}
+
 func (s *state) writeMetadata() error {
 	if !s.persistedTimestamp.Equal(s.timestamp) {
 		if err := database.PutTimestamp(s.singletonDB, TimestampKey, s.timestamp); err != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow"
 	"github.com/f01c5700/avalanchego/snow/choices"
+	"github.com/f01c5700/avalanchego/snow/uptime"
 	"github.com/f01c5700/avalanchego/snow/validators"
 	"github.com/f01c5700/avalanchego/upgrade/upgradetest"
 	"github.com/f01c5700/avalanchego/utils/constants"
@@ -50,6 +51,10 @@ import (
 var defaultValidatorNodeID = ids.GenerateTestNodeID()
 
 func newTestState(t testing.TB, db database.Database) *state {
+	return newTestStateWithExecConfig(t, db, &config.DefaultExecutionConfig)
+}
+
+func newTestStateWithExecConfig(t testing.TB, db database.Database, execCfg *config.ExecutionConfig) *state {
 	s, err := New(
 		db,
 		genesistest.NewBytes(t, genesistest.Config{
@@ -58,7 +63,7 @@ func newTestState(t testing.TB, db database.Database) *state {
 		prometheus.NewRegistry(),
 		validators.NewManager(),
 		upgradetest.GetConfig(upgradetest.Latest),
-		&config.DefaultExecutionConfig,
+		execCfg,
 		&snow.Context{
 			NetworkID: constants.UnitTestID,
 			NodeID:    ids.GenerateTestNodeID(),
@@ -1165,6 +1170,259 @@ func TestStateAddRemoveValidator(t *testing.T) {
 	}
 }
 
+// Tests IterateValidatorWeightDiffs, IterateValidatorPublicKeyDiffs
+func TestStateIterateValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	var (
+		startTime = time.Now()
+		endTime   = startTime.Add(24 * time.Hour)
+		validator = Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    ids.GenerateTestNodeID(),
+			PublicKey: bls.PublicFromSecretKey(sk),
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    5,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+		delegator = Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    validator.NodeID,
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    2,
+			StartTime: startTime,
+			EndTime:   endTime,
+		}
+	)
+
+	// Height 1: add the validator
+	require.NoError(state.PutCurrentValidator(&validator))
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	// Height 2: add the delegator
+	state.PutCurrentDelegator(&delegator)
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	// Height 3: remove the delegator and the validator
+	state.DeleteCurrentDelegator(&delegator)
+	state.DeleteCurrentValidator(&validator)
+	state.SetHeight(3)
+	require.NoError(state.Commit())
+
+	type weightDiff struct {
+		height uint64
+		nodeID ids.NodeID
+		diff   ValidatorWeightDiff
+	}
+	var weightDiffs []weightDiff
+	require.NoError(state.IterateValidatorWeightDiffs(
+		context.Background(),
+		3,
+		1,
+		constants.PrimaryNetworkID,
+		func(height uint64, nodeID ids.NodeID, diff *ValidatorWeightDiff) bool {
+			weightDiffs = append(weightDiffs, weightDiff{
+				height: height,
+				nodeID: nodeID,
+				diff:   *diff,
+			})
+			return true
+		},
+	))
+	require.Equal(
+		[]weightDiff{
+			{
+				height: 3,
+				nodeID: validator.NodeID,
+				diff: ValidatorWeightDiff{
+					Decrease: true,
+					Amount:   validator.Weight + delegator.Weight,
+				},
+			},
+			{
+				height: 2,
+				nodeID: validator.NodeID,
+				diff: ValidatorWeightDiff{
+					Amount: delegator.Weight,
+				},
+			},
+			{
+				height: 1,
+				nodeID: validator.NodeID,
+				diff: ValidatorWeightDiff{
+					Amount: validator.Weight,
+				},
+			},
+		},
+		weightDiffs,
+	)
+
+	// Iteration stops once the callback returns false
+	var numCalls int
+	require.NoError(state.IterateValidatorWeightDiffs(
+		context.Background(),
+		3,
+		1,
+		constants.PrimaryNetworkID,
+		func(uint64, ids.NodeID, *ValidatorWeightDiff) bool {
+			numCalls++
+			return false
+		},
+	))
+	require.Equal(1, numCalls)
+
+	// Diffs below the end height are not iterated over
+	var heights []uint64
+	require.NoError(state.IterateValidatorWeightDiffs(
+		context.Background(),
+		2,
+		2,
+		constants.PrimaryNetworkID,
+		func(height uint64, _ ids.NodeID, _ *ValidatorWeightDiff) bool {
+			heights = append(heights, height)
+			return true
+		},
+	))
+	require.Equal([]uint64{2}, heights)
+
+	type publicKeyDiff struct {
+		height        uint64
+		nodeID        ids.NodeID
+		prevPublicKey *bls.PublicKey
+	}
+	var publicKeyDiffs []publicKeyDiff
+	require.NoError(state.IterateValidatorPublicKeyDiffs(
+		context.Background(),
+		3,
+		1,
+		func(height uint64, nodeID ids.NodeID, prevPublicKey *bls.PublicKey) bool {
+			publicKeyDiffs = append(publicKeyDiffs, publicKeyDiff{
+				height:        height,
+				nodeID:        nodeID,
+				prevPublicKey: prevPublicKey,
+			})
+			return true
+		},
+	))
+	require.Len(publicKeyDiffs, 2)
+	require.Equal(uint64(3), publicKeyDiffs[0].height)
+	require.Equal(validator.NodeID, publicKeyDiffs[0].nodeID)
+	require.Equal(
+		bls.PublicKeyToUncompressedBytes(validator.PublicKey),
+		bls.PublicKeyToUncompressedBytes(publicKeyDiffs[0].prevPublicKey),
+	)
+	require.Equal(uint64(1), publicKeyDiffs[1].height)
+	require.Equal(validator.NodeID, publicKeyDiffs[1].nodeID)
+	require.Nil(publicKeyDiffs[1].prevPublicKey)
+}
+
+// Tests GetCompletedStaker
+func TestStateGetCompletedStaker(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		startTime   = time.Unix(1_000_000, 0)
+		endTime     = startTime.Add(24 * time.Hour)
+		lastUpdated = startTime.Add(12 * time.Hour)
+		upDuration  = 6 * time.Hour
+		utx         = createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, txs.Validator{
+			NodeID: nodeID,
+			End:    uint64(endTime.Unix()),
+			Wght:   5,
+		})
+		validatorTx = &txs.Tx{Unsigned: utx}
+	)
+	require.NoError(validatorTx.Initialize(txs.Codec))
+
+	validator, err := NewCurrentStaker(validatorTx.ID(), utx, startTime, 10)
+	require.NoError(err)
+
+	delegator := &Staker{
+		TxID:            ids.GenerateTestID(),
+		NodeID:          nodeID,
+		SubnetID:        constants.PrimaryNetworkID,
+		Weight:          2,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		PotentialReward: 3,
+		Priority:        txs.PrimaryNetworkDelegatorCurrentPriority,
+	}
+
+	// Height 1: add the validator and the delegator
+	require.NoError(state.PutCurrentValidator(validator))
+	state.PutCurrentDelegator(delegator)
+	state.SetHeight(1)
+	require.NoError(state.Commit())
+
+	_, err = state.GetCompletedStaker(validator.TxID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = state.GetCompletedStaker(delegator.TxID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(state.SetUptime(nodeID, constants.PrimaryNetworkID, upDuration, lastUpdated))
+	require.NoError(state.SetDelegateeReward(constants.PrimaryNetworkID, nodeID, 4))
+
+	// Height 2: remove the delegator
+	state.DeleteCurrentDelegator(delegator)
+	state.SetHeight(2)
+	require.NoError(state.Commit())
+
+	completedDelegator, err := state.GetCompletedStaker(delegator.TxID)
+	require.NoError(err)
+	require.Equal(
+		&CompletedStaker{
+			TxID:             delegator.TxID,
+			NodeID:           nodeID,
+			SubnetID:         constants.PrimaryNetworkID,
+			Weight:           delegator.Weight,
+			StartTime:        startTime,
+			EndTime:          endTime,
+			PotentialReward:  delegator.PotentialReward,
+			Height:           2,
+			DelegationShares: utx.DelegationShares,
+		},
+		completedDelegator,
+	)
+
+	_, err = state.GetCompletedStaker(validator.TxID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Height 3: remove the validator
+	state.DeleteCurrentValidator(validator)
+	state.SetHeight(3)
+	require.NoError(state.Commit())
+
+	completedValidator, err := state.GetCompletedStaker(validator.TxID)
+	require.NoError(err)
+	require.Equal(
+		&CompletedStaker{
+			TxID:                     validator.TxID,
+			NodeID:                   nodeID,
+			SubnetID:                 constants.PrimaryNetworkID,
+			Weight:                   validator.Weight,
+			StartTime:                startTime,
+			EndTime:                  endTime,
+			PotentialReward:          validator.PotentialReward,
+			Height:                   3,
+			PotentialDelegateeReward: 4,
+			UpDuration:               upDuration,
+			LastUpdated:              lastUpdated,
+		},
+		completedValidator,
+	)
+}
+
 func copyValidatorSet(
 	input map[ids.NodeID]*validators.GetValidatorOutput,
 ) map[ids.NodeID]*validators.GetValidatorOutput {
@@ -1361,6 +1619,55 @@ func TestStateSubnetManager(t *testing.T) {
 	}
 }
 
+func TestStateUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newTestState(t, db)
+
+	var (
+		subnetID    = constants.PrimaryNetworkID
+		windowStart = uptime.WindowStart(time.Now())
+		sample      = uptime.Sample{
+			Start:            windowStart,
+			UpDuration:       time.Hour,
+			ObservedDuration: 2 * time.Hour,
+		}
+	)
+
+	// Only validators have their uptime recorded
+	err := s.SetUptimeSample(ids.GenerateTestNodeID(), subnetID, sample)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	emptySample, err := s.GetUptimeSample(defaultValidatorNodeID, subnetID, windowStart)
+	require.NoError(err)
+	require.Equal(uptime.Sample{Start: windowStart}, emptySample)
+
+	require.NoError(s.SetUptimeSample(defaultValidatorNodeID, subnetID, sample))
+	stagedSample, err := s.GetUptimeSample(defaultValidatorNodeID, subnetID, windowStart)
+	require.NoError(err)
+	require.Equal(sample, stagedSample)
+	require.NoError(s.Commit())
+
+	// The sample should be loaded from disk
+	s = newTestState(t, db)
+	loadedSample, err := s.GetUptimeSample(defaultValidatorNodeID, subnetID, windowStart)
+	require.NoError(err)
+	require.Equal(sample.UpDuration, loadedSample.UpDuration)
+	require.Equal(sample.ObservedDuration, loadedSample.ObservedDuration)
+	require.True(windowStart.Equal(loadedSample.Start))
+
+	// Removing the validator removes its uptime history
+	staker, err := s.GetCurrentValidator(subnetID, defaultValidatorNodeID)
+	require.NoError(err)
+	s.DeleteCurrentValidator(staker)
+	require.NoError(s.Commit())
+
+	emptySample, err = s.GetUptimeSample(defaultValidatorNodeID, subnetID, windowStart)
+	require.NoError(err)
+	require.Equal(uptime.Sample{Start: windowStart}, emptySample)
+}
+
 func makeBlocks(require *require.Assertions) []block.Block {
 	var blks []block.Block
 	{
@@ -1554,3 +1861,346 @@ func TestGetFeeStateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestStateAddressTxs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		fundedAddr   = genesistest.DefaultFundedKeys[0].Address()
+		receiverAddr = ids.GenerateTestShortID()
+		finalAddr    = ids.GenerateTestShortID()
+		unknownAddr  = ids.GenerateTestShortID()
+	)
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: constants.PlatformChainID,
+		Ins: []*avax.TransferableInput{{
+			// The first genesis UTXO is owned by [fundedAddr].
+			UTXOID: avax.UTXOID{
+				TxID:        genesistest.AVAXAsset.ID,
+				OutputIndex: 0,
+			},
+			Asset: genesistest.AVAXAsset,
+			In: &secp256k1fx.TransferInput{
+				Amt: genesistest.DefaultInitialBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: genesistest.AVAXAsset,
+			Out: &secp256k1fx.TransferOutput{
+				Amt: genesistest.DefaultInitialBalance,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{receiverAddr},
+				},
+			},
+		}},
+	}}}
+	require.NoError(tx.Initialize(txs.Codec))
+	txID := tx.ID()
+
+	// [spendTx] spends the output of [tx], which is no longer in the UTXO set
+	// when the index is rebuilt.
+	spendTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: constants.PlatformChainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        txID,
+				OutputIndex: 0,
+			},
+			Asset: genesistest.AVAXAsset,
+			In: &secp256k1fx.TransferInput{
+				Amt: genesistest.DefaultInitialBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}},
+		Outs: []*avax.TransferableOutput{{
+			Asset: genesistest.AVAXAsset,
+			Out: &secp256k1fx.TransferOutput{
+				Amt: genesistest.DefaultInitialBalance,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{finalAddr},
+				},
+			},
+		}},
+	}}}
+	require.NoError(spendTx.Initialize(txs.Codec))
+	spendTxID := spendTx.ID()
+
+	acceptBlocks := func(s *state) {
+		for i, blkTx := range []*txs.Tx{tx, spendTx} {
+			height := uint64(i + 1)
+			blk, err := block.NewBanffStandardBlock(
+				s.GetTimestamp(),
+				s.GetLastAccepted(),
+				height,
+				[]*txs.Tx{blkTx},
+			)
+			require.NoError(err)
+
+			s.AddStatelessBlock(blk)
+			s.AddTx(blkTx, status.Committed)
+			s.SetHeight(height)
+			s.SetLastAccepted(blk.ID())
+			require.NoError(s.Commit())
+		}
+	}
+
+	verifyIndex := func(s *state) {
+		// The genesis validator is owned by [fundedAddr].
+		vdr, err := s.GetCurrentValidator(constants.PrimaryNetworkID, defaultValidatorNodeID)
+		require.NoError(err)
+
+		tests := []struct {
+			addr     ids.ShortID
+			cursor   uint64
+			limit    int
+			expected []ids.ID
+		}{
+			{
+				addr:     fundedAddr,
+				cursor:   0,
+				limit:    10,
+				expected: []ids.ID{vdr.TxID, txID},
+			},
+			{
+				addr:     fundedAddr,
+				cursor:   0,
+				limit:    1,
+				expected: []ids.ID{vdr.TxID},
+			},
+			{
+				addr:     fundedAddr,
+				cursor:   1,
+				limit:    10,
+				expected: []ids.ID{txID},
+			},
+			{
+				addr:     fundedAddr,
+				cursor:   2,
+				limit:    10,
+				expected: []ids.ID{},
+			},
+			{
+				addr:     receiverAddr,
+				cursor:   0,
+				limit:    10,
+				expected: []ids.ID{txID, spendTxID},
+			},
+			{
+				addr:     finalAddr,
+				cursor:   0,
+				limit:    10,
+				expected: []ids.ID{spendTxID},
+			},
+			{
+				addr:     unknownAddr,
+				cursor:   0,
+				limit:    10,
+				expected: []ids.ID{},
+			},
+		}
+		for _, test := range tests {
+			txIDs, err := s.GetAddressTxs(test.addr, test.cursor, test.limit)
+			require.NoError(err)
+			require.Equal(test.expected, txIDs)
+		}
+	}
+
+	indexedCfg := config.DefaultExecutionConfig
+	indexedCfg.IndexTransactions = true
+
+	// Txs are indexed as they are accepted.
+	s := newTestStateWithExecConfig(t, memdb.New(), &indexedCfg)
+	acceptBlocks(s)
+	verifyIndex(s)
+
+	// The index is disabled by default.
+	db := memdb.New()
+	s = newTestState(t, db)
+	acceptBlocks(s)
+	_, err := s.GetAddressTxs(fundedAddr, 0, 10)
+	require.ErrorIs(err, ErrAddressTxsIndexDisabled)
+
+	// Enabling the index on an existing node requires reindexing from genesis.
+	s = newTestStateWithExecConfig(t, db, &indexedCfg)
+	_, err = s.GetAddressTxs(fundedAddr, 0, 10)
+	require.ErrorIs(err, ErrIndexIncomplete)
+
+	require.NoError(s.IndexHistoricalBlocks(&sync.Mutex{}, logging.NoLog{}))
+	verifyIndex(s)
+
+	// Reindexing is a noop once the index has caught up.
+	require.NoError(s.IndexHistoricalBlocks(&sync.Mutex{}, logging.NoLog{}))
+	verifyIndex(s)
+}
+
+func TestStateSubnetHistory(t *testing.T) {
+	require := require.New(t)
+
+	createSubnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(createSubnetTx.Initialize(txs.Codec))
+	subnetID := createSubnetTx.ID()
+
+	createChainTx := &txs.Tx{Unsigned: &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		SubnetID:   subnetID,
+		ChainName:  "chain",
+		VMID:       ids.GenerateTestID(),
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(createChainTx.Initialize(txs.Codec))
+
+	transferSubnetOwnershipTx := &txs.Tx{Unsigned: &txs.TransferSubnetOwnershipTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+		}},
+		Subnet:     subnetID,
+		SubnetAuth: &secp256k1fx.Input{},
+		Owner:      &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(transferSubnetOwnershipTx.Initialize(txs.Codec))
+
+	acceptBlocks := func(s *state) {
+		blkTxs := [][]*txs.Tx{
+			{createSubnetTx},
+			{createChainTx, transferSubnetOwnershipTx},
+		}
+		for i, blkTxs := range blkTxs {
+			height := uint64(i + 1)
+			blk, err := block.NewBanffStandardBlock(
+				s.GetTimestamp(),
+				s.GetLastAccepted(),
+				height,
+				blkTxs,
+			)
+			require.NoError(err)
+
+			s.AddStatelessBlock(blk)
+			for _, tx := range blkTxs {
+				s.AddTx(tx, status.Committed)
+			}
+			s.SetHeight(height)
+			s.SetLastAccepted(blk.ID())
+			require.NoError(s.Commit())
+		}
+	}
+
+	verifyIndex := func(s *state) {
+		// The genesis chain was created at height 0.
+		primaryNetworkEvents, err := s.GetSubnetHistory(constants.PrimaryNetworkID, 0, math.MaxInt)
+		require.NoError(err)
+		require.Len(primaryNetworkEvents, 1)
+		require.Zero(primaryNetworkEvents[0].Height)
+
+		tests := []struct {
+			subnetID ids.ID
+			cursor   uint64
+			limit    int
+			expected []*SubnetEvent
+		}{
+			{
+				subnetID: subnetID,
+				cursor:   0,
+				limit:    math.MaxInt,
+				expected: []*SubnetEvent{
+					{
+						TxID:   subnetID,
+						Height: 1,
+					},
+					{
+						TxID:   createChainTx.ID(),
+						Height: 2,
+					},
+					{
+						TxID:   transferSubnetOwnershipTx.ID(),
+						Height: 2,
+					},
+				},
+			},
+			{
+				subnetID: subnetID,
+				cursor:   0,
+				limit:    1,
+				expected: []*SubnetEvent{
+					{
+						TxID:   subnetID,
+						Height: 1,
+					},
+				},
+			},
+			{
+				subnetID: subnetID,
+				cursor:   1,
+				limit:    math.MaxInt,
+				expected: []*SubnetEvent{
+					{
+						TxID:   createChainTx.ID(),
+						Height: 2,
+					},
+					{
+						TxID:   transferSubnetOwnershipTx.ID(),
+						Height: 2,
+					},
+				},
+			},
+			{
+				subnetID: subnetID,
+				cursor:   3,
+				limit:    math.MaxInt,
+				expected: []*SubnetEvent{},
+			},
+			{
+				subnetID: ids.GenerateTestID(),
+				cursor:   0,
+				limit:    math.MaxInt,
+				expected: []*SubnetEvent{},
+			},
+		}
+		for _, test := range tests {
+			events, err := s.GetSubnetHistory(test.subnetID, test.cursor, test.limit)
+			require.NoError(err)
+			require.Equal(test.expected, events)
+		}
+	}
+
+	indexedCfg := config.DefaultExecutionConfig
+	indexedCfg.IndexSubnetHistory = true
+
+	// Txs are indexed as they are accepted.
+	s := newTestStateWithExecConfig(t, memdb.New(), &indexedCfg)
+	acceptBlocks(s)
+	verifyIndex(s)
+
+	// The index is disabled by default.
+	db := memdb.New()
+	s = newTestState(t, db)
+	acceptBlocks(s)
+	_, err := s.GetSubnetHistory(subnetID, 0, math.MaxInt)
+	require.ErrorIs(err, ErrSubnetHistoryIndexDisabled)
+
+	// Enabling the index on an existing node requires reindexing from genesis.
+	s = newTestStateWithExecConfig(t, db, &indexedCfg)
+	_, err = s.GetSubnetHistory(subnetID, 0, math.MaxInt)
+	require.ErrorIs(err, ErrIndexIncomplete)
+
+	require.NoError(s.IndexHistoricalBlocks(&sync.Mutex{}, logging.NoLog{}))
+	verifyIndex(s)
+}
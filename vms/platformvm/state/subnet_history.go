@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/vms/platformvm/block"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+)
+
+var ErrSubnetHistoryIndexDisabled = errors.New("subnet history index is disabled")
+
+// SubnetEvent is an accepted tx that modified a subnet.
+type SubnetEvent struct {
+	TxID ids.ID `v0:"true"`
+	// Height of the block that accepted the tx
+	Height uint64 `v0:"true"`
+}
+
+func (s *state) GetSubnetHistory(subnetID ids.ID, cursor uint64, limit int) ([]*SubnetEvent, error) {
+	if s.subnetHistoryIndex == nil {
+		return nil, ErrSubnetHistoryIndexDisabled
+	}
+	if err := s.verifyIndexComplete(s.subnetHistoryIndex); err != nil {
+		return nil, err
+	}
+
+	values, err := readList(s.subnetHistoryDB, subnetID[:], cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*SubnetEvent, len(values))
+	for i, value := range values {
+		events[i] = &SubnetEvent{}
+		if _, err := MetadataCodec.Unmarshal(value, events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// indexSubnetHistory appends the txs accepted in [blk] to the histories of
+// the subnets they modified.
+func (s *state) indexSubnetHistory(blk block.Block) error {
+	height := blk.Height()
+	for _, tx := range s.getIndexedTxs(blk) {
+		subnetID, ok := getModifiedSubnetID(tx)
+		if !ok {
+			continue
+		}
+
+		event := &SubnetEvent{
+			TxID:   tx.ID(),
+			Height: height,
+		}
+		eventBytes, err := MetadataCodec.Marshal(CodecVersion0, event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event of subnet %s: %w", subnetID, err)
+		}
+		if err := appendToList(s.subnetHistoryDB, subnetID[:], eventBytes); err != nil {
+			return fmt.Errorf("failed to index tx %s of subnet %s: %w", event.TxID, subnetID, err)
+		}
+	}
+	return nil
+}
+
+// getModifiedSubnetID returns the subnet that [tx] created or modified, if
+// any.
+//
+// Note: Changes to the validator set of a subnet are not included.
+func getModifiedSubnetID(tx *txs.Tx) (ids.ID, bool) {
+	switch utx := tx.Unsigned.(type) {
+	case *txs.CreateSubnetTx:
+		return tx.ID(), true
+	case *txs.TransferSubnetOwnershipTx:
+		return utx.Subnet, true
+	case *txs.CreateChainTx:
+		return utx.SubnetID, true
+	case *txs.TransformSubnetTx:
+		return utx.Subnet, true
+	case *txs.ConvertSubnetTx:
+		return utx.Subnet, true
+	default:
+		return ids.Empty, false
+	}
+}
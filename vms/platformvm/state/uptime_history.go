@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/f01c5700/avalanchego/database"
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/uptime"
+)
+
+const (
+	// uptimeSamplePrefixLength = [subnetID] + [nodeID]
+	uptimeSamplePrefixLength = ids.IDLen + ids.NodeIDLen
+	// uptimeSampleKeyLength = [subnetID] + [nodeID] + [windowStart]
+	uptimeSampleKeyLength = uptimeSamplePrefixLength + database.Uint64Size
+
+	// uptimeSampleValueLength = [upDuration] + [observedDuration]
+	uptimeSampleValueLength = 2 * database.Uint64Size
+)
+
+var errUnexpectedUptimeSampleValueLength = fmt.Errorf("expected uptime sample value length %d", uptimeSampleValueLength)
+
+type uptimeSampleKey struct {
+	subnetID    ids.ID
+	nodeID      ids.NodeID
+	windowStart int64 // Unix time in seconds
+}
+
+// marshalUptimeSamplePrefix is used to iterate over all the uptime samples of
+// [nodeID] on [subnetID].
+//
+// Invariant: the result is a prefix of [marshalUptimeSampleKey] when called
+// with the same subnetID and nodeID.
+func marshalUptimeSamplePrefix(subnetID ids.ID, nodeID ids.NodeID) []byte {
+	prefix := make([]byte, uptimeSamplePrefixLength)
+	copy(prefix, subnetID[:])
+	copy(prefix[ids.IDLen:], nodeID.Bytes())
+	return prefix
+}
+
+func marshalUptimeSampleKey(key uptimeSampleKey) []byte {
+	bytes := make([]byte, uptimeSampleKeyLength)
+	copy(bytes, key.subnetID[:])
+	copy(bytes[ids.IDLen:], key.nodeID.Bytes())
+	binary.BigEndian.PutUint64(bytes[uptimeSamplePrefixLength:], uint64(key.windowStart))
+	return bytes
+}
+
+func marshalUptimeSample(sample uptime.Sample) []byte {
+	value := make([]byte, uptimeSampleValueLength)
+	binary.BigEndian.PutUint64(value, uint64(sample.UpDuration))
+	binary.BigEndian.PutUint64(value[database.Uint64Size:], uint64(sample.ObservedDuration))
+	return value
+}
+
+func unmarshalUptimeSample(windowStart time.Time, value []byte) (uptime.Sample, error) {
+	if len(value) != uptimeSampleValueLength {
+		return uptime.Sample{}, errUnexpectedUptimeSampleValueLength
+	}
+	return uptime.Sample{
+		Start:            windowStart,
+		UpDuration:       time.Duration(binary.BigEndian.Uint64(value)),
+		ObservedDuration: time.Duration(binary.BigEndian.Uint64(value[database.Uint64Size:])),
+	}, nil
+}
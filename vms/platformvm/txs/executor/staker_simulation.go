@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/components/verify"
+	"github.com/f01c5700/avalanchego/vms/platformvm/state"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs/fee"
+	"github.com/f01c5700/avalanchego/vms/platformvm/utxo"
+
+	safemath "github.com/f01c5700/avalanchego/utils/math"
+)
+
+var (
+	_ utxo.Verifier = skipFlowCheck{}
+
+	ErrUnsupportedStakerTx = errors.New("unsupported staker tx")
+)
+
+// StakerSimulation is the result of verifying a proposed staker against the
+// current and pending stakers of a chain state.
+type StakerSimulation struct {
+	SubnetID  ids.ID
+	NodeID    ids.NodeID
+	StartTime time.Time
+	EndTime   time.Time
+	// Timeline is the total weight of the validator, including its delegators
+	// and the proposed staker, at [StartTime] and after every change until
+	// [EndTime]. Multiple changes may happen at the same time. It is empty if
+	// the validator or its delegation rules couldn't be found.
+	Timeline []WeightChange
+	// PeakWeight is the maximum weight in [Timeline].
+	PeakWeight uint64
+	// MaxWeight is the maximum total weight the validator may have.
+	MaxWeight uint64
+	// Headroom is the weight that can still be delegated to the validator
+	// over the entire staking period after adding the proposed staker.
+	Headroom uint64
+	// Err is the staking constraint that the proposed staker violates, if
+	// any.
+	Err error
+}
+
+// WeightChange is the total weight of a validator starting at [Time].
+type WeightChange struct {
+	Time   time.Time
+	Weight uint64
+}
+
+// SimulateStaker verifies the AddPermissionlessValidatorTx or
+// AddPermissionlessDelegatorTx [tx] against [chainState] and reports how it
+// would change the weight of its validator.
+//
+// Proposed stakers are typically not yet funded, so the flow check is skipped.
+func SimulateStaker(
+	backend *Backend,
+	feeCalculator fee.Calculator,
+	chainState state.Chain,
+	tx *txs.Tx,
+) (*StakerSimulation, error) {
+	simulationBackend := *backend
+	simulationBackend.FlowChecker = skipFlowCheck{}
+
+	var (
+		currentTimestamp = chainState.GetTimestamp()
+		isDurangoActive  = backend.Config.UpgradeConfig.IsDurangoActivated(currentTimestamp)
+	)
+	switch utx := tx.Unsigned.(type) {
+	case *txs.AddPermissionlessValidatorTx:
+		simulation := newStakerSimulation(isDurangoActive, currentTimestamp, utx)
+		simulation.Err = verifyAddPermissionlessValidatorTx(&simulationBackend, feeCalculator, chainState, tx, utx)
+
+		rules, err := getDelegatorRules(backend, chainState, utx.Subnet)
+		if err != nil {
+			return simulation, nil
+		}
+		simulation.MaxWeight = getMaxValidatorWeight(rules, utx.Validator.Wght)
+		simulation.Timeline = []WeightChange{{
+			Time:   simulation.StartTime,
+			Weight: utx.Validator.Wght,
+		}}
+		simulation.setPeakWeight()
+		return simulation, nil
+	case *txs.AddPermissionlessDelegatorTx:
+		simulation := newStakerSimulation(isDurangoActive, currentTimestamp, utx)
+		simulation.Err = verifyAddPermissionlessDelegatorTx(&simulationBackend, feeCalculator, chainState, tx, utx)
+
+		rules, err := getDelegatorRules(backend, chainState, utx.Subnet)
+		if err != nil {
+			return simulation, nil
+		}
+		validator, err := GetValidator(chainState, utx.Subnet, utx.Validator.NodeID)
+		if err != nil {
+			return simulation, nil
+		}
+		simulation.MaxWeight = getMaxValidatorWeight(rules, validator.Weight)
+		simulation.Timeline, err = getWeightTimeline(chainState, validator, simulation.StartTime, simulation.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		for i := range simulation.Timeline {
+			simulation.Timeline[i].Weight, err = safemath.Add(simulation.Timeline[i].Weight, utx.Validator.Wght)
+			if err != nil {
+				return nil, err
+			}
+		}
+		simulation.setPeakWeight()
+		return simulation, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedStakerTx, tx.Unsigned)
+	}
+}
+
+func newStakerSimulation(
+	isDurangoActive bool,
+	currentTimestamp time.Time,
+	staker txs.ScheduledStaker,
+) *StakerSimulation {
+	startTime := currentTimestamp
+	if !isDurangoActive {
+		startTime = staker.StartTime()
+	}
+	return &StakerSimulation{
+		SubnetID:  staker.SubnetID(),
+		NodeID:    staker.NodeID(),
+		StartTime: startTime,
+		EndTime:   staker.EndTime(),
+	}
+}
+
+func (s *StakerSimulation) setPeakWeight() {
+	for _, change := range s.Timeline {
+		s.PeakWeight = max(s.PeakWeight, change.Weight)
+	}
+	if s.PeakWeight < s.MaxWeight {
+		s.Headroom = s.MaxWeight - s.PeakWeight
+	}
+}
+
+// getMaxValidatorWeight returns the maximum total weight of a validator that
+// is staking [validatorWeight].
+func getMaxValidatorWeight(rules *addDelegatorRules, validatorWeight uint64) uint64 {
+	maximumWeight, err := safemath.Mul(
+		uint64(rules.maxValidatorWeightFactor),
+		validatorWeight,
+	)
+	if err != nil {
+		maximumWeight = math.MaxUint64
+	}
+	return min(maximumWeight, rules.maxValidatorStake)
+}
+
+// getWeightTimeline returns the total weight of [validator] at [startTime]
+// and after every change in [startTime, endTime], in the order the changes are
+// applied. The weights match the ones considered by GetMaxWeight, so the
+// maximum weight of the timeline is the result of GetMaxWeight.
+func getWeightTimeline(
+	chainState state.Chain,
+	validator *state.Staker,
+	startTime time.Time,
+	endTime time.Time,
+) ([]WeightChange, error) {
+	currentDelegatorIterator, err := chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentWeight := validator.Weight
+	for currentDelegatorIterator.Next() {
+		currentWeight, err = safemath.Add(currentWeight, currentDelegatorIterator.Value().Weight)
+		if err != nil {
+			currentDelegatorIterator.Release()
+			return nil, err
+		}
+	}
+	currentDelegatorIterator.Release()
+
+	currentDelegatorIterator, err = chainState.GetCurrentDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	pendingDelegatorIterator, err := chainState.GetPendingDelegatorIterator(validator.SubnetID, validator.NodeID)
+	if err != nil {
+		currentDelegatorIterator.Release()
+		return nil, err
+	}
+	delegatorChangesIterator := state.NewStakerDiffIterator(currentDelegatorIterator, pendingDelegatorIterator)
+	defer delegatorChangesIterator.Release()
+
+	var timeline []WeightChange
+	for delegatorChangesIterator.Next() {
+		delegator, isAdded := delegatorChangesIterator.Value()
+		if delegator.NextTime.After(endTime) {
+			break
+		}
+
+		// Changes prior to [startTime] are reflected in the initial weight.
+		if len(timeline) == 0 && !delegator.NextTime.Before(startTime) {
+			timeline = append(timeline, WeightChange{
+				Time:   startTime,
+				Weight: currentWeight,
+			})
+		}
+
+		var op func(uint64, uint64) (uint64, error)
+		if isAdded {
+			op = safemath.Add
+		} else {
+			op = safemath.Sub
+		}
+		currentWeight, err = op(currentWeight, delegator.Weight)
+		if err != nil {
+			return nil, err
+		}
+		if len(timeline) == 0 {
+			continue
+		}
+
+		// Changes that happen at the same time aren't merged, because the
+		// intermediate weights are considered by GetMaxWeight.
+		timeline = append(timeline, WeightChange{
+			Time:   delegator.NextTime,
+			Weight: currentWeight,
+		})
+	}
+	if len(timeline) == 0 {
+		timeline = append(timeline, WeightChange{
+			Time:   startTime,
+			Weight: currentWeight,
+		})
+	}
+	return timeline, nil
+}
+
+// skipFlowCheck is a [utxo.Verifier] that accepts any spend.
+type skipFlowCheck struct{}
+
+func (skipFlowCheck) VerifySpend(
+	txs.UnsignedTx,
+	avax.UTXOGetter,
+	[]*avax.TransferableInput,
+	[]*avax.TransferableOutput,
+	[]verify.Verifiable,
+	map[ids.ID]uint64,
+) error {
+	return nil
+}
+
+func (skipFlowCheck) VerifySpendUTXOs(
+	txs.UnsignedTx,
+	[]*avax.UTXO,
+	[]*avax.TransferableInput,
+	[]*avax.TransferableOutput,
+	[]verify.Verifiable,
+	map[ids.ID]uint64,
+) error {
+	return nil
+}
@@ -0,0 +1,324 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/upgrade/upgradetest"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/units"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/platformvm/genesis/genesistest"
+	"github.com/f01c5700/avalanchego/vms/platformvm/reward"
+	"github.com/f01c5700/avalanchego/vms/platformvm/signer"
+	"github.com/f01c5700/avalanchego/vms/platformvm/state"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
+)
+
+func TestSimulateStaker(t *testing.T) {
+	env := newEnvironment(t, upgradetest.Latest)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var primaryValidator *state.Staker
+	it, err := env.state.GetCurrentStakerIterator()
+	require.NoError(t, err)
+	for it.Next() {
+		staker := it.Value()
+		if staker.Priority != txs.PrimaryNetworkValidatorCurrentPriority {
+			continue
+		}
+		primaryValidator = staker
+		break
+	}
+	it.Release()
+
+	var (
+		chainTime = env.state.GetTimestamp()
+		day1      = chainTime.Add(24 * time.Hour)
+		day2      = chainTime.Add(2 * 24 * time.Hour)
+		day3      = chainTime.Add(3 * 24 * time.Hour)
+		maxWeight = MaxValidatorWeightFactor * primaryValidator.Weight
+	)
+
+	// The validator is delegated to by a current delegator until [day1] and by
+	// a pending delegator from [day2] until [day3].
+	chainState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(t, err)
+	chainState.PutCurrentDelegator(&state.Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    primaryValidator.NodeID,
+		SubnetID:  constants.PrimaryNetworkID,
+		Weight:    10 * units.MilliAvax,
+		StartTime: chainTime,
+		EndTime:   day1,
+		NextTime:  day1,
+		Priority:  txs.PrimaryNetworkDelegatorCurrentPriority,
+	})
+	chainState.PutPendingDelegator(&state.Staker{
+		TxID:      ids.GenerateTestID(),
+		NodeID:    primaryValidator.NodeID,
+		SubnetID:  constants.PrimaryNetworkID,
+		Weight:    3 * units.MilliAvax,
+		StartTime: day2,
+		EndTime:   day3,
+		NextTime:  day2,
+		Priority:  txs.PrimaryNetworkDelegatorApricotPendingPriority,
+	})
+
+	newValidatorTx := func(t *testing.T, nodeID ids.NodeID, weight uint64) *txs.Tx {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+
+		return newUnsignedStakerTx(t, &txs.AddPermissionlessValidatorTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    env.ctx.NetworkID,
+				BlockchainID: env.ctx.ChainID,
+			}},
+			Validator: txs.Validator{
+				NodeID: nodeID,
+				End:    uint64(day3.Unix()),
+				Wght:   weight,
+			},
+			Subnet:                constants.PrimaryNetworkID,
+			Signer:                signer.NewProofOfPossession(sk),
+			StakeOuts:             newStakeOuts(env.ctx.AVAXAssetID, weight),
+			ValidatorRewardsOwner: &secp256k1fx.OutputOwners{},
+			DelegatorRewardsOwner: &secp256k1fx.OutputOwners{},
+			DelegationShares:      reward.PercentDenominator,
+		})
+	}
+	newDelegatorTx := func(t *testing.T, weight uint64) *txs.Tx {
+		return newUnsignedStakerTx(t, &txs.AddPermissionlessDelegatorTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    env.ctx.NetworkID,
+				BlockchainID: env.ctx.ChainID,
+			}},
+			Validator: txs.Validator{
+				NodeID: primaryValidator.NodeID,
+				End:    uint64(day3.Unix()),
+				Wght:   weight,
+			},
+			Subnet:                 constants.PrimaryNetworkID,
+			StakeOuts:              newStakeOuts(env.ctx.AVAXAssetID, weight),
+			DelegationRewardsOwner: &secp256k1fx.OutputOwners{},
+		})
+	}
+
+	newNodeID := ids.GenerateTestNodeID()
+	tests := []struct {
+		name        string
+		tx          func(t *testing.T) *txs.Tx
+		expectedErr error
+		expected    *StakerSimulation
+	}{
+		{
+			name: "validator",
+			tx: func(t *testing.T) *txs.Tx {
+				return newValidatorTx(t, newNodeID, 10*units.MilliAvax)
+			},
+			expected: &StakerSimulation{
+				SubnetID:  constants.PrimaryNetworkID,
+				NodeID:    newNodeID,
+				StartTime: chainTime,
+				EndTime:   day3,
+				Timeline: []WeightChange{
+					{Time: chainTime, Weight: 10 * units.MilliAvax},
+				},
+				PeakWeight: 10 * units.MilliAvax,
+				MaxWeight:  50 * units.MilliAvax,
+				Headroom:   40 * units.MilliAvax,
+			},
+		},
+		{
+			name: "validator weight too large",
+			tx: func(t *testing.T) *txs.Tx {
+				return newValidatorTx(t, newNodeID, env.config.MaxValidatorStake+1)
+			},
+			expected: &StakerSimulation{
+				SubnetID:  constants.PrimaryNetworkID,
+				NodeID:    newNodeID,
+				StartTime: chainTime,
+				EndTime:   day3,
+				Timeline: []WeightChange{
+					{Time: chainTime, Weight: env.config.MaxValidatorStake + 1},
+				},
+				PeakWeight: env.config.MaxValidatorStake + 1,
+				MaxWeight:  env.config.MaxValidatorStake,
+				Err:        ErrWeightTooLarge,
+			},
+		},
+		{
+			name: "duplicate validator",
+			tx: func(t *testing.T) *txs.Tx {
+				return newValidatorTx(t, primaryValidator.NodeID, 10*units.MilliAvax)
+			},
+			expected: &StakerSimulation{
+				SubnetID:  constants.PrimaryNetworkID,
+				NodeID:    primaryValidator.NodeID,
+				StartTime: chainTime,
+				EndTime:   day3,
+				Timeline: []WeightChange{
+					{Time: chainTime, Weight: 10 * units.MilliAvax},
+				},
+				PeakWeight: 10 * units.MilliAvax,
+				MaxWeight:  50 * units.MilliAvax,
+				Headroom:   40 * units.MilliAvax,
+				Err:        ErrDuplicateValidator,
+			},
+		},
+		{
+			name: "delegator",
+			tx: func(t *testing.T) *txs.Tx {
+				return newDelegatorTx(t, 5*units.MilliAvax)
+			},
+			expected: &StakerSimulation{
+				SubnetID:  constants.PrimaryNetworkID,
+				NodeID:    primaryValidator.NodeID,
+				StartTime: chainTime,
+				EndTime:   day3,
+				Timeline: []WeightChange{
+					{Time: chainTime, Weight: primaryValidator.Weight + 15*units.MilliAvax},
+					{Time: day1, Weight: primaryValidator.Weight + 5*units.MilliAvax},
+					{Time: day2, Weight: primaryValidator.Weight + 8*units.MilliAvax},
+					{Time: day3, Weight: primaryValidator.Weight + 5*units.MilliAvax},
+				},
+				PeakWeight: primaryValidator.Weight + 15*units.MilliAvax,
+				MaxWeight:  maxWeight,
+				Headroom:   maxWeight - primaryValidator.Weight - 15*units.MilliAvax,
+			},
+		},
+		{
+			name: "over delegated",
+			tx: func(t *testing.T) *txs.Tx {
+				return newDelegatorTx(t, maxWeight-primaryValidator.Weight-10*units.MilliAvax+1)
+			},
+			expected: &StakerSimulation{
+				SubnetID:  constants.PrimaryNetworkID,
+				NodeID:    primaryValidator.NodeID,
+				StartTime: chainTime,
+				EndTime:   day3,
+				Timeline: []WeightChange{
+					{Time: chainTime, Weight: maxWeight + 1},
+					{Time: day1, Weight: maxWeight - 10*units.MilliAvax + 1},
+					{Time: day2, Weight: maxWeight - 7*units.MilliAvax + 1},
+					{Time: day3, Weight: maxWeight - 10*units.MilliAvax + 1},
+				},
+				PeakWeight: maxWeight + 1,
+				MaxWeight:  maxWeight,
+				Err:        ErrOverDelegated,
+			},
+		},
+		{
+			name: "unsupported tx",
+			tx: func(t *testing.T) *txs.Tx {
+				return newUnsignedStakerTx(t, &txs.BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    env.ctx.NetworkID,
+					BlockchainID: env.ctx.ChainID,
+				}})
+			},
+			expectedErr: ErrUnsupportedStakerTx,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			feeCalculator := state.PickFeeCalculator(env.config, chainState)
+			simulation, err := SimulateStaker(&env.backend, feeCalculator, chainState, test.tx(t))
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.ErrorIs(simulation.Err, test.expected.Err)
+			simulation.Err = test.expected.Err
+			require.Equal(test.expected, simulation)
+		})
+	}
+}
+
+func TestGetWeightTimelineMatchesGetMaxWeight(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t, upgradetest.Latest)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	var (
+		chainTime = env.state.GetTimestamp()
+		validator = &state.Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    ids.GenerateTestNodeID(),
+			SubnetID:  constants.PrimaryNetworkID,
+			Weight:    genesistest.DefaultValidatorWeight,
+			StartTime: chainTime,
+			EndTime:   chainTime.Add(10 * time.Hour),
+		}
+	)
+	chainState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	// Delegators change the weight of the validator at [chainTime], which is
+	// also the start of the period, and every hour after.
+	for i := 0; i < 5; i++ {
+		startTime := chainTime.Add(time.Duration(i) * time.Hour)
+		chainState.PutPendingDelegator(&state.Staker{
+			TxID:      ids.GenerateTestID(),
+			NodeID:    validator.NodeID,
+			SubnetID:  validator.SubnetID,
+			Weight:    uint64(i+1) * units.MilliAvax,
+			StartTime: startTime,
+			EndTime:   startTime.Add(2 * time.Hour),
+			NextTime:  startTime,
+			Priority:  txs.PrimaryNetworkDelegatorApricotPendingPriority,
+		})
+	}
+
+	for _, period := range [][2]time.Duration{
+		{0, 10 * time.Hour},
+		{0, time.Hour},
+		{90 * time.Minute, 3 * time.Hour},
+		{2 * time.Hour, 4 * time.Hour},
+		{8 * time.Hour, 10 * time.Hour},
+	} {
+		startTime := chainTime.Add(period[0])
+		endTime := chainTime.Add(period[1])
+
+		timeline, err := getWeightTimeline(chainState, validator, startTime, endTime)
+		require.NoError(err)
+		require.Equal(startTime, timeline[0].Time)
+
+		var peakWeight uint64
+		for _, change := range timeline {
+			peakWeight = max(peakWeight, change.Weight)
+		}
+		maxWeight, err := GetMaxWeight(chainState, validator, startTime, endTime)
+		require.NoError(err)
+		require.Equal(maxWeight, peakWeight, period)
+	}
+}
+
+func newStakeOuts(assetID ids.ID, weight uint64) []*avax.TransferableOutput {
+	return []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          weight,
+			OutputOwners: secp256k1fx.OutputOwners{},
+		},
+	}}
+}
+
+func newUnsignedStakerTx(t *testing.T, utx txs.UnsignedTx) *txs.Tx {
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/components/verify"
+	"github.com/f01c5700/avalanchego/vms/platformvm/fx"
+	"github.com/f01c5700/avalanchego/vms/platformvm/state"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs/fee"
+	"github.com/f01c5700/avalanchego/vms/platformvm/utxo"
+	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ fx.Fx         = skipCredentialsFx{}
+	_ utxo.Verifier = skipCredentialsFlowCheck{}
+)
+
+// ExecuteUnsignedTx executes [tx] on [chainState] as a standard tx without
+// verifying its credentials. Inputs must still be well-formed and consume the
+// full amount of their UTXOs, and the flow check is still performed.
+func ExecuteUnsignedTx(
+	backend *Backend,
+	feeCalculator fee.Calculator,
+	chainState state.Diff,
+	tx *txs.Tx,
+) error {
+	unsignedBackend := *backend
+	unsignedBackend.Fx = skipCredentialsFx{Fx: backend.Fx}
+	unsignedBackend.FlowChecker = skipCredentialsFlowCheck{
+		Verifier: utxo.NewVerifier(backend.Ctx, backend.Clk, unsignedBackend.Fx),
+	}
+
+	// Txs that modify a subnet use their last credential as the subnet
+	// authorization, so a placeholder is provided for it. The tx keeps the ID
+	// of [tx].
+	unsignedTx := &txs.Tx{
+		Unsigned: tx.Unsigned,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{},
+		},
+	}
+	unsignedTx.SetBytes(tx.Unsigned.Bytes(), tx.Bytes())
+
+	return tx.Unsigned.Visit(&StandardTxExecutor{
+		Backend:       &unsignedBackend,
+		State:         chainState,
+		FeeCalculator: feeCalculator,
+		Tx:            unsignedTx,
+	})
+}
+
+// skipCredentialsFx verifies transfers and permissions without verifying
+// their credentials.
+type skipCredentialsFx struct {
+	fx.Fx
+}
+
+func (skipCredentialsFx) VerifyTransfer(_, inIntf, _, utxoIntf interface{}) error {
+	in, ok := inIntf.(*secp256k1fx.TransferInput)
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	out, ok := utxoIntf.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return secp256k1fx.ErrWrongUTXOType
+	}
+	if err := verify.All(out, in); err != nil {
+		return err
+	}
+	if out.Amt != in.Amt {
+		return fmt.Errorf("%w: %d != %d", secp256k1fx.ErrMismatchedAmounts, out.Amt, in.Amt)
+	}
+	return nil
+}
+
+func (skipCredentialsFx) VerifyPermission(_, inIntf, _, ownerIntf interface{}) error {
+	in, ok := inIntf.(*secp256k1fx.Input)
+	if !ok {
+		return secp256k1fx.ErrWrongInputType
+	}
+	owner, ok := ownerIntf.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return secp256k1fx.ErrWrongOwnerType
+	}
+	return verify.All(in, owner)
+}
+
+// skipCredentialsFlowCheck is a [utxo.Verifier] that provides an empty
+// credential for every input rather than the credentials of the tx.
+type skipCredentialsFlowCheck struct {
+	utxo.Verifier
+}
+
+func (f skipCredentialsFlowCheck) VerifySpend(
+	tx txs.UnsignedTx,
+	utxoDB avax.UTXOGetter,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	_ []verify.Verifiable,
+	unlockedProduced map[ids.ID]uint64,
+) error {
+	return f.Verifier.VerifySpend(tx, utxoDB, ins, outs, emptyCredentials(len(ins)), unlockedProduced)
+}
+
+func (f skipCredentialsFlowCheck) VerifySpendUTXOs(
+	tx txs.UnsignedTx,
+	utxos []*avax.UTXO,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	_ []verify.Verifiable,
+	unlockedProduced map[ids.ID]uint64,
+) error {
+	return f.Verifier.VerifySpendUTXOs(tx, utxos, ins, outs, emptyCredentials(len(ins)), unlockedProduced)
+}
+
+func emptyCredentials(n int) []verify.Verifiable {
+	creds := make([]verify.Verifiable, n)
+	for i := range creds {
+		creds[i] = &secp256k1fx.Credential{}
+	}
+	return creds
+}
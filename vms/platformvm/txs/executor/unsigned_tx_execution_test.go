@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/upgrade/upgradetest"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/vms/components/avax"
+	"github.com/f01c5700/avalanchego/vms/platformvm/state"
+	"github.com/f01c5700/avalanchego/vms/platformvm/txs"
+	"github.com/f01c5700/avalanchego/vms/secp256k1fx"
+)
+
+func TestExecuteUnsignedTx(t *testing.T) {
+	env := newEnvironment(t, upgradetest.Latest)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	subnetID := testSubnet1.ID()
+	wallet := newWallet(t, env, walletConfig{
+		subnetIDs: []ids.ID{subnetID},
+	})
+
+	createChainTx, err := wallet.IssueCreateChainTx(
+		subnetID,
+		nil,
+		constants.AVMID,
+		nil,
+		"chain name",
+	)
+	require.NoError(t, err)
+
+	baseTx, err := wallet.IssueBaseTx(
+		[]*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: env.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ids.ShortEmpty},
+					},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		signedTx          *txs.Tx
+		modify            func(txs.UnsignedTx)
+		verifyCredentials bool
+		expectedErr       error
+	}{
+		{
+			name:     "valid subnet authorization",
+			signedTx: createChainTx,
+			modify:   func(txs.UnsignedTx) {},
+		},
+		{
+			name:     "valid transfer",
+			signedTx: baseTx,
+			modify:   func(txs.UnsignedTx) {},
+		},
+		{
+			name:     "input amount mismatch",
+			signedTx: baseTx,
+			modify: func(utx txs.UnsignedTx) {
+				utx.(*txs.BaseTx).Ins[0].In.(*secp256k1fx.TransferInput).Amt++
+			},
+			expectedErr: secp256k1fx.ErrMismatchedAmounts,
+		},
+		{
+			name:              "credentials verified",
+			signedTx:          createChainTx,
+			modify:            func(txs.UnsignedTx) {},
+			verifyCredentials: true,
+			expectedErr:       errWrongNumberOfCredentials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var utx txs.UnsignedTx
+			_, err := txs.Codec.Unmarshal(test.signedTx.Unsigned.Bytes(), &utx)
+			require.NoError(err)
+			test.modify(utx)
+
+			tx := &txs.Tx{Unsigned: utx}
+			require.NoError(tx.Initialize(txs.Codec))
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			feeCalculator := state.PickFeeCalculator(env.config, stateDiff)
+			if test.verifyCredentials {
+				err = tx.Unsigned.Visit(&StandardTxExecutor{
+					Backend:       &env.backend,
+					FeeCalculator: feeCalculator,
+					State:         stateDiff,
+					Tx:            tx,
+				})
+			} else {
+				err = ExecuteUnsignedTx(&env.backend, feeCalculator, stateDiff, tx)
+			}
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			// The UTXOs are produced with the ID of the unsigned tx
+			for _, utxo := range tx.UTXOs() {
+				_, err := stateDiff.GetUTXO(utxo.InputID())
+				require.NoError(err)
+			}
+		})
+	}
+}
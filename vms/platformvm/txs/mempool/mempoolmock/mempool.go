@@ -11,6 +11,7 @@ package mempoolmock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	ids "github.com/f01c5700/avalanchego/ids"
 	txs "github.com/f01c5700/avalanchego/vms/platformvm/txs"
@@ -69,6 +70,21 @@ func (mr *MempoolMockRecorder) Get(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mempool)(nil).Get), arg0)
 }
 
+// GetAddedTime mocks base method.
+func (m *Mempool) GetAddedTime(arg0 ids.ID) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddedTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetAddedTime indicates an expected call of GetAddedTime.
+func (mr *MempoolMockRecorder) GetAddedTime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddedTime", reflect.TypeOf((*Mempool)(nil).GetAddedTime), arg0)
+}
+
 // GetDropReason mocks base method.
 func (m *Mempool) GetDropReason(arg0 ids.ID) error {
 	m.ctrl.T.Helper()
@@ -95,6 +111,18 @@ func (mr *MempoolMockRecorder) Iterate(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*Mempool)(nil).Iterate), arg0)
 }
 
+// IterateDropped mocks base method.
+func (m *Mempool) IterateDropped(arg0 func(ids.ID, error) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IterateDropped", arg0)
+}
+
+// IterateDropped indicates an expected call of IterateDropped.
+func (mr *MempoolMockRecorder) IterateDropped(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateDropped", reflect.TypeOf((*Mempool)(nil).IterateDropped), arg0)
+}
+
 // Len mocks base method.
 func (m *Mempool) Len() int {
 	m.ctrl.T.Helper()
@@ -52,10 +52,11 @@ import (
 )
 
 var (
-	_ snowmanblock.ChainVM       = (*VM)(nil)
-	_ secp256k1fx.VM             = (*VM)(nil)
-	_ validators.State           = (*VM)(nil)
-	_ validators.SubnetConnector = (*VM)(nil)
+	_ snowmanblock.ChainVM                = (*VM)(nil)
+	_ snowmanblock.ParallelParsingChainVM = (*VM)(nil)
+	_ secp256k1fx.VM                      = (*VM)(nil)
+	_ validators.State                    = (*VM)(nil)
+	_ validators.SubnetConnector          = (*VM)(nil)
 )
 
 type VM struct {
@@ -193,6 +194,8 @@ func (vm *VM) Initialize(
 		mempool,
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
 		appSender,
+		network.NewSignatureRequestVerifier(&chainCtx.Lock, vm.state),
+		chainCtx.WarpSigner,
 		registerer,
 		execConfig.Network,
 	)
@@ -241,6 +244,15 @@ func (vm *VM) Initialize(
 		}
 	}()
 
+	go func() {
+		err := vm.state.IndexHistoricalBlocks(&vm.ctx.Lock, vm.ctx.Log)
+		if err != nil {
+			vm.ctx.Log.Warn("indexing historical blocks failed",
+				zap.Error(err),
+			)
+		}
+	}()
+
 	return nil
 }
 
@@ -429,6 +441,12 @@ func (vm *VM) ParseBlock(_ context.Context, b []byte) (snowman.Block, error) {
 	return vm.manager.NewBlock(statelessBlk), nil
 }
 
+// ParallelParsingEnabled returns true because parsing doesn't depend on the
+// chain's state.
+func (*VM) ParallelParsingEnabled(context.Context) (bool, error) {
+	return true, nil
+}
+
 func (vm *VM) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
 	return vm.manager.GetBlock(blkID)
 }
@@ -169,7 +169,7 @@ func defaultVM(t *testing.T, f upgradetest.Fork) (*VM, database.Database, *mutab
 		return nil
 	}
 
-	dynamicConfigBytes := []byte(`{"network":{"max-validator-set-staleness":0}}`)
+	dynamicConfigBytes := []byte(`{"network":{"max-validator-set-staleness":0},"index-subnet-history":true}`)
 	require.NoError(vm.Initialize(
 		context.Background(),
 		ctx,
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/utils/set"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+var (
+	ErrNoValidators          = errors.New("no validators with public keys")
+	ErrInvalidQuorum         = errors.New("invalid quorum")
+	ErrNonPositiveAttempts   = errors.New("max attempts must be positive")
+	ErrNegativeRetryDelay    = errors.New("retry delay must be non-negative")
+	errInvalidSignatureShare = errors.New("invalid signature share")
+)
+
+var DefaultConfig = Config{
+	MaxAttempts: 3,
+	RetryDelay:  time.Second,
+}
+
+type Config struct {
+	// MaxAttempts is the maximum number of times a signature is requested
+	// from each validator.
+	MaxAttempts int `json:"max-attempts"`
+	// RetryDelay is how long to wait before requesting a signature from a
+	// validator again after a failed request.
+	RetryDelay time.Duration `json:"retry-delay"`
+}
+
+// SignatureRequester requests signatures for warp messages from peers.
+type SignatureRequester interface {
+	// RequestSignature returns [nodeID]'s signature over [msg]. The returned
+	// signature may be invalid.
+	RequestSignature(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		msg *warp.UnsignedMessage,
+		justification []byte,
+	) (*bls.Signature, error)
+}
+
+// Aggregator collects signatures over warp messages from the validators of a
+// subnet.
+type Aggregator struct {
+	log       logging.Logger
+	requester SignatureRequester
+	state     validators.State
+	config    Config
+}
+
+func New(
+	log logging.Logger,
+	requester SignatureRequester,
+	state validators.State,
+	config Config,
+) (*Aggregator, error) {
+	switch {
+	case config.MaxAttempts <= 0:
+		return nil, fmt.Errorf("%w: %d", ErrNonPositiveAttempts, config.MaxAttempts)
+	case config.RetryDelay < 0:
+		return nil, fmt.Errorf("%w: %s", ErrNegativeRetryDelay, config.RetryDelay)
+	}
+	return &Aggregator{
+		log:       log,
+		requester: requester,
+		state:     state,
+		config:    config,
+	}, nil
+}
+
+type signatureShare struct {
+	index     int
+	signature *bls.Signature
+}
+
+// AggregateSignatures requests signatures over [msg] from the current
+// validators of [subnetID] until the signers hold at least
+// [quorumNum]/[quorumDen] of the subnet's weight. [justification] is forwarded
+// to the validators to allow them to verify [msg].
+//
+// Returns the signed message once the quorum is reached. If the quorum can't
+// be reached, an error wrapping [warp.ErrInsufficientWeight] is returned.
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	msg *warp.UnsignedMessage,
+	justification []byte,
+	subnetID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, error) {
+	if quorumDen == 0 || quorumNum > quorumDen {
+		return nil, fmt.Errorf("%w: %d/%d", ErrInvalidQuorum, quorumNum, quorumDen)
+	}
+
+	height, err := a.state.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get P-chain height: %w", err)
+	}
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.state, height, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set of %s at %d: %w", subnetID, height, err)
+	}
+	if len(vdrs) == 0 {
+		return nil, fmt.Errorf("%w: %s at %d", ErrNoValidators, subnetID, height)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each validator provides at most one share, so sending to [shares] never
+	// blocks.
+	var (
+		shares = make(chan signatureShare, len(vdrs))
+		wg     sync.WaitGroup
+	)
+	for i, vdr := range vdrs {
+		wg.Add(1)
+		go func(i int, vdr *warp.Validator) {
+			defer wg.Done()
+
+			a.requestSignature(ctx, i, vdr, msg, justification, shares)
+		}(i, vdr)
+	}
+	go func() {
+		wg.Wait()
+		close(shares)
+	}()
+
+	var (
+		signers      = set.NewBits()
+		signatures   = make([]*bls.Signature, 0, len(vdrs))
+		signedWeight uint64
+	)
+	for share := range shares {
+		signers.Add(share.index)
+		signatures = append(signatures, share.signature)
+		signedWeight += vdrs[share.index].Weight // Impossible to overflow here
+
+		if warp.VerifyWeight(signedWeight, totalWeight, quorumNum, quorumDen) != nil {
+			continue
+		}
+
+		aggregateSignature, err := bls.AggregateSignatures(signatures)
+		if err != nil {
+			return nil, err
+		}
+		signature := &warp.BitSetSignature{
+			Signers: signers.Bytes(),
+		}
+		copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+		return warp.NewMessage(msg, signature)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %d of %d signed by %d of %d validators",
+		warp.ErrInsufficientWeight,
+		signedWeight,
+		totalWeight,
+		len(signatures),
+		len(vdrs),
+	)
+}
+
+// requestSignature requests [vdr]'s signature over [msg] until a valid
+// signature is received or [Config.MaxAttempts] requests have failed. The
+// requests are spread across all of the validator's nodes.
+func (a *Aggregator) requestSignature(
+	ctx context.Context,
+	index int,
+	vdr *warp.Validator,
+	msg *warp.UnsignedMessage,
+	justification []byte,
+	shares chan<- signatureShare,
+) {
+	msgBytes := msg.Bytes()
+	for attempt := 0; attempt < a.config.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(a.config.RetryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		nodeID := vdr.NodeIDs[attempt%len(vdr.NodeIDs)]
+		signature, err := a.requester.RequestSignature(ctx, nodeID, msg, justification)
+		if err == nil && !bls.Verify(vdr.PublicKey, signature, msgBytes) {
+			err = errInvalidSignatureShare
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			a.log.Debug("failed to get signature share",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageID", msg.ID()),
+				zap.Int("attempt", attempt+1),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		shares <- signatureShare{
+			index:     index,
+			signature: signature,
+		}
+		return
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/f01c5700/avalanchego/ids"
+	"github.com/f01c5700/avalanchego/snow/validators"
+	"github.com/f01c5700/avalanchego/snow/validators/validatorstest"
+	"github.com/f01c5700/avalanchego/utils/constants"
+	"github.com/f01c5700/avalanchego/utils/crypto/bls"
+	"github.com/f01c5700/avalanchego/utils/logging"
+	"github.com/f01c5700/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	networkID = constants.UnitTestID
+	height    = 10
+)
+
+var errRequestFailed = errors.New("request failed")
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	weight uint64
+}
+
+// testRequester signs with the validator's key unless the node has remaining
+// failures or is configured to return an invalid signature.
+type testRequester struct {
+	lock     sync.Mutex
+	keys     map[ids.NodeID]*bls.SecretKey
+	failures map[ids.NodeID]int
+	invalid  map[ids.NodeID]bool
+}
+
+func (r *testRequester) RequestSignature(
+	_ context.Context,
+	nodeID ids.NodeID,
+	msg *warp.UnsignedMessage,
+	_ []byte,
+) (*bls.Signature, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.failures[nodeID] > 0 {
+		r.failures[nodeID]--
+		return nil, errRequestFailed
+	}
+	if r.invalid[nodeID] {
+		return bls.Sign(r.keys[nodeID], []byte("invalid")), nil
+	}
+	return bls.Sign(r.keys[nodeID], msg.Bytes()), nil
+}
+
+func newTestValidators(t *testing.T, weights ...uint64) []testValidator {
+	vdrs := make([]testValidator, len(weights))
+	for i, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		vdrs[i] = testValidator{
+			nodeID: ids.GenerateTestNodeID(),
+			sk:     sk,
+			weight: weight,
+		}
+	}
+	return vdrs
+}
+
+func newTestState(subnetID ids.ID, vdrs []testValidator) *validatorstest.State {
+	return &validatorstest.State{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return height, nil
+		},
+		GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+			return subnetID, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(vdrs))
+			for _, vdr := range vdrs {
+				vdrSet[vdr.nodeID] = &validators.GetValidatorOutput{
+					NodeID:    vdr.nodeID,
+					PublicKey: bls.PublicFromSecretKey(vdr.sk),
+					Weight:    vdr.weight,
+				}
+			}
+			return vdrSet, nil
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "default config",
+			config: DefaultConfig,
+		},
+		{
+			name: "zero max attempts",
+			config: Config{
+				MaxAttempts: 0,
+			},
+			expectedErr: ErrNonPositiveAttempts,
+		},
+		{
+			name: "negative retry delay",
+			config: Config{
+				MaxAttempts: 1,
+				RetryDelay:  -1,
+			},
+			expectedErr: ErrNegativeRetryDelay,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(logging.NoLog{}, nil, &validatorstest.State{}, test.config)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	tests := []struct {
+		name        string
+		weights     []uint64
+		failures    map[int]int
+		invalid     map[int]bool
+		quorumNum   uint64
+		quorumDen   uint64
+		expectedErr error
+	}{
+		{
+			name:      "all signers",
+			weights:   []uint64{1, 1, 1},
+			quorumNum: 1,
+			quorumDen: 1,
+		},
+		{
+			name:      "quorum reached without unresponsive validator",
+			weights:   []uint64{3, 1},
+			failures:  map[int]int{1: 3},
+			quorumNum: 67,
+			quorumDen: 100,
+		},
+		{
+			name:      "retries stragglers",
+			weights:   []uint64{1, 1},
+			failures:  map[int]int{0: 2, 1: 1},
+			quorumNum: 1,
+			quorumDen: 1,
+		},
+		{
+			name:        "unresponsive validator prevents quorum",
+			weights:     []uint64{1, 1},
+			failures:    map[int]int{1: 3},
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+		{
+			name:        "invalid signature prevents quorum",
+			weights:     []uint64{1, 1},
+			invalid:     map[int]bool{0: true},
+			quorumNum:   67,
+			quorumDen:   100,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+		{
+			name:        "no validators",
+			quorumNum:   1,
+			quorumDen:   1,
+			expectedErr: ErrNoValidators,
+		},
+		{
+			name:        "invalid quorum",
+			weights:     []uint64{1},
+			quorumNum:   2,
+			quorumDen:   1,
+			expectedErr: ErrInvalidQuorum,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				subnetID  = ids.GenerateTestID()
+				vdrs      = newTestValidators(t, test.weights...)
+				state     = newTestState(subnetID, vdrs)
+				requester = &testRequester{
+					keys:     make(map[ids.NodeID]*bls.SecretKey),
+					failures: make(map[ids.NodeID]int),
+					invalid:  make(map[ids.NodeID]bool),
+				}
+			)
+			for i, vdr := range vdrs {
+				requester.keys[vdr.nodeID] = vdr.sk
+				requester.failures[vdr.nodeID] = test.failures[i]
+				requester.invalid[vdr.nodeID] = test.invalid[i]
+			}
+
+			aggregator, err := New(
+				logging.NoLog{},
+				requester,
+				state,
+				Config{
+					MaxAttempts: 3,
+				},
+			)
+			require.NoError(err)
+
+			unsignedMsg, err := warp.NewUnsignedMessage(networkID, ids.GenerateTestID(), []byte("payload"))
+			require.NoError(err)
+
+			msg, err := aggregator.AggregateSignatures(
+				context.Background(),
+				unsignedMsg,
+				nil,
+				subnetID,
+				test.quorumNum,
+				test.quorumDen,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.Equal(unsignedMsg.Bytes(), msg.UnsignedMessage.Bytes())
+			require.NoError(msg.Signature.Verify(
+				context.Background(),
+				&msg.UnsignedMessage,
+				networkID,
+				state,
+				height,
+				test.quorumNum,
+				test.quorumDen,
+			))
+		})
+	}
+}
+
+func TestAggregateSignaturesContextCanceled(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		vdrs      = newTestValidators(t, 1)
+		state     = newTestState(subnetID, vdrs)
+		requester = &testRequester{
+			keys: map[ids.NodeID]*bls.SecretKey{
+				vdrs[0].nodeID: vdrs[0].sk,
+			},
+			failures: map[ids.NodeID]int{
+				vdrs[0].nodeID: 1,
+			},
+		}
+	)
+
+	aggregator, err := New(logging.NoLog{}, requester, state, DefaultConfig)
+	require.NoError(err)
+
+	unsignedMsg, err := warp.NewUnsignedMessage(networkID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = aggregator.AggregateSignatures(ctx, unsignedMsg, nil, subnetID, 1, 1)
+	require.ErrorIs(err, context.Canceled)
+}
@@ -79,6 +79,12 @@ func (p *postForkCommonComponents) Height() uint64 {
 	return p.innerBlk.Height()
 }
 
+// PreVerify pre-verifies the inner block. The outer block doesn't need to be
+// pre-verified, as its signature is only verified after bootstrapping.
+func (p *postForkCommonComponents) PreVerify(ctx context.Context) error {
+	return preVerifyInnerBlk(ctx, p.innerBlk)
+}
+
 // Verify returns nil if:
 // 1) [p]'s inner block is not an oracle block
 // 2) [child]'s P-Chain height >= [parentPChainHeight]
@@ -508,3 +514,12 @@ func (p *postForkCommonComponents) shouldBuildSignedBlockPreDurango(
 	p.vm.notifyInnerBlockReady()
 	return false, fmt.Errorf("%w: delay %s < minDelay %s", errProposerWindowNotStarted, delay, minDelay)
 }
+
+// preVerifyInnerBlk pre-verifies [innerBlk] if it supports pre-verification.
+func preVerifyInnerBlk(ctx context.Context, innerBlk snowman.Block) error {
+	blk, ok := innerBlk.(smblock.WithPreVerify)
+	if !ok {
+		return nil
+	}
+	return blk.PreVerify(ctx)
+}
@@ -117,7 +117,7 @@ func (vm *VM) pruneOldBlocks() error {
 	//
 	// Note: vm.lastAcceptedHeight is guaranteed to be >= height, so the
 	// subtraction can never underflow.
-	for vm.lastAcceptedHeight-height > vm.NumHistoricalBlocks {
+	for vm.lastAcceptedHeight.Load()-height > vm.NumHistoricalBlocks {
 		blockToDelete, err := vm.State.GetBlockIDAtHeight(height)
 		if err != nil {
 			return err
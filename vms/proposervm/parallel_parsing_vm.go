@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import "context"
+
+// ParallelParsingEnabled only reports the inner VM's setting because parsing
+// a proposervm block only reads immutable state and the inner block cache,
+// which is thread-safe, and pre-verifying a proposervm block only pre-verifies
+// its inner block.
+func (vm *VM) ParallelParsingEnabled(ctx context.Context) (bool, error) {
+	if vm.ppVM == nil {
+		return false, nil
+	}
+
+	return vm.ppVM.ParallelParsingEnabled(ctx)
+}
@@ -9,9 +9,14 @@ import (
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	"github.com/f01c5700/avalanchego/vms/proposervm/block"
+
+	smblock "github.com/f01c5700/avalanchego/snow/engine/snowman/block"
 )
 
-var _ PostForkBlock = (*postForkBlock)(nil)
+var (
+	_ PostForkBlock         = (*postForkBlock)(nil)
+	_ smblock.WithPreVerify = (*postForkBlock)(nil)
+)
 
 type postForkBlock struct {
 	block.SignedBlock
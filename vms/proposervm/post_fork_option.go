@@ -11,9 +11,14 @@ import (
 
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/vms/proposervm/block"
+
+	smblock "github.com/f01c5700/avalanchego/snow/engine/snowman/block"
 )
 
-var _ PostForkBlock = (*postForkOption)(nil)
+var (
+	_ PostForkBlock         = (*postForkOption)(nil)
+	_ smblock.WithPreVerify = (*postForkOption)(nil)
+)
 
 // The parent of a *postForkOption must be a *postForkBlock.
 type postForkOption struct {
@@ -24,7 +29,7 @@ type postForkOption struct {
 }
 
 func (b *postForkOption) Timestamp() time.Time {
-	if b.Height() <= b.vm.lastAcceptedHeight {
+	if b.Height() <= b.vm.lastAcceptedHeight.Load() {
 		return b.vm.lastAcceptedTime
 	}
 	return b.timestamp
@@ -601,7 +601,7 @@ func TestOptionTimestampValidity(t *testing.T) {
 	statefulOptionBlock, err := proVM.ParseBlock(context.Background(), option.Bytes())
 	require.NoError(err)
 
-	require.LessOrEqual(statefulOptionBlock.Height(), proVM.lastAcceptedHeight)
+	require.LessOrEqual(statefulOptionBlock.Height(), proVM.lastAcceptedHeight.Load())
 
 	coreVM.GetBlockF = func(context.Context, ids.ID) (snowman.Block, error) {
 		require.FailNow("called GetBlock when unable to handle the error")
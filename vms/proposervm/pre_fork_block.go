@@ -14,10 +14,13 @@ import (
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/snow/consensus/snowman"
 	"github.com/f01c5700/avalanchego/vms/proposervm/block"
+
+	smblock "github.com/f01c5700/avalanchego/snow/engine/snowman/block"
 )
 
 var (
-	_ Block = (*preForkBlock)(nil)
+	_ Block                 = (*preForkBlock)(nil)
+	_ smblock.WithPreVerify = (*preForkBlock)(nil)
 
 	errChildOfPreForkBlockHasProposer = errors.New("child of pre-fork block has proposer")
 )
@@ -42,6 +45,10 @@ func (b *preForkBlock) acceptInnerBlk(ctx context.Context) error {
 	return b.Block.Accept(ctx)
 }
 
+func (b *preForkBlock) PreVerify(ctx context.Context) error {
+	return preVerifyInnerBlk(ctx, b.Block)
+}
+
 func (b *preForkBlock) Verify(ctx context.Context) error {
 	parent, err := b.vm.getPreForkBlock(ctx, b.Block.Parent())
 	if err != nil {
@@ -42,7 +42,7 @@ func (s *stateSummary) Height() uint64 {
 func (s *stateSummary) Accept(ctx context.Context) (block.StateSyncMode, error) {
 	// If we have already synced up to or past this state summary, we do not
 	// want to sync to it.
-	if s.vm.lastAcceptedHeight >= s.Height() {
+	if s.vm.lastAcceptedHeight.Load() >= s.Height() {
 		return block.StateSyncSkipped, nil
 	}
 
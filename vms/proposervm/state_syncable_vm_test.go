@@ -526,7 +526,7 @@ func TestStateSummaryAcceptOlderBlock(t *testing.T) {
 
 	// Set the last accepted block height to be higher that the state summary
 	// we are going to attempt to accept
-	vm.lastAcceptedHeight = innerSummary.Height() + 1
+	vm.lastAcceptedHeight.Store(innerSummary.Height() + 1)
 
 	// store post fork block associated with summary
 	innerBlk := &snowmantest.Block{
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,9 +48,10 @@ const (
 )
 
 var (
-	_ block.ChainVM         = (*VM)(nil)
-	_ block.BatchedChainVM  = (*VM)(nil)
-	_ block.StateSyncableVM = (*VM)(nil)
+	_ block.ChainVM                = (*VM)(nil)
+	_ block.BatchedChainVM         = (*VM)(nil)
+	_ block.StateSyncableVM        = (*VM)(nil)
+	_ block.ParallelParsingChainVM = (*VM)(nil)
 
 	dbPrefix = []byte("proposervm")
 )
@@ -64,6 +66,7 @@ type VM struct {
 	blockBuilderVM block.BuildBlockWithContextChainVM
 	batchedVM      block.BatchedChainVM
 	ssVM           block.StateSyncableVM
+	ppVM           block.ParallelParsingChainVM
 
 	state.State
 
@@ -96,7 +99,10 @@ type VM struct {
 	lastAcceptedTime time.Time
 
 	// lastAcceptedHeight is set to the last accepted PostForkBlock's height.
-	lastAcceptedHeight uint64
+	//
+	// If parallel bootstrapping is enabled, blocks may be parsed concurrently
+	// with the acceptance of other blocks.
+	lastAcceptedHeight atomic.Uint64
 
 	// proposerBuildSlotGauge reports the slot index when this node may attempt
 	// to build a block.
@@ -116,12 +122,14 @@ func New(
 	blockBuilderVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	ppVM, _ := vm.(block.ParallelParsingChainVM)
 	return &VM{
 		ChainVM:        vm,
 		Config:         config,
 		blockBuilderVM: blockBuilderVM,
 		batchedVM:      batchedVM,
 		ssVM:           ssVM,
+		ppVM:           ppVM,
 	}
 }
 
@@ -205,7 +213,7 @@ func (vm *VM) Initialize(
 		chainCtx.Log.Info("initialized proposervm",
 			zap.String("state", "after fork"),
 			zap.Uint64("forkHeight", forkHeight),
-			zap.Uint64("lastAcceptedHeight", vm.lastAcceptedHeight),
+			zap.Uint64("lastAcceptedHeight", vm.lastAcceptedHeight.Load()),
 		)
 	case database.ErrNotFound:
 		chainCtx.Log.Info("initialized proposervm",
@@ -501,7 +509,7 @@ func (vm *VM) setLastAcceptedMetadata(ctx context.Context) error {
 	if err == database.ErrNotFound {
 		// If the last accepted block wasn't a PostFork block, then we don't
 		// initialize the metadata.
-		vm.lastAcceptedHeight = 0
+		vm.lastAcceptedHeight.Store(0)
 		vm.lastAcceptedTime = time.Time{}
 		return nil
 	}
@@ -515,7 +523,7 @@ func (vm *VM) setLastAcceptedMetadata(ctx context.Context) error {
 	}
 
 	// Set the last accepted height
-	vm.lastAcceptedHeight = lastAccepted.Height()
+	vm.lastAcceptedHeight.Store(lastAccepted.Height())
 
 	if _, ok := lastAccepted.getStatelessBlk().(statelessblock.SignedBlock); ok {
 		// If the last accepted block wasn't a PostForkOption, then we don't
@@ -634,7 +642,7 @@ func (vm *VM) acceptPostForkBlock(blk PostForkBlock) error {
 	height := blk.Height()
 	blkID := blk.ID()
 
-	vm.lastAcceptedHeight = height
+	vm.lastAcceptedHeight.Store(height)
 	delete(vm.verifiedBlocks, blkID)
 
 	// Persist this block, its height index, and its status
@@ -741,7 +749,7 @@ func (vm *VM) parseInnerBlock(ctx context.Context, outerBlkID ids.ID, innerBlkBy
 // Caches proposervm block ID --> inner block if the inner block's height
 // is within [innerBlkCacheSize] of the last accepted block's height.
 func (vm *VM) cacheInnerBlock(outerBlkID ids.ID, innerBlk snowman.Block) {
-	diff := math.AbsDiff(innerBlk.Height(), vm.lastAcceptedHeight)
+	diff := math.AbsDiff(innerBlk.Height(), vm.lastAcceptedHeight.Load())
 	if diff < innerBlkCacheSize {
 		vm.innerBlkCache.Put(outerBlkID, innerBlk)
 	}
@@ -1967,13 +1967,13 @@ func TestVMInnerBlkCache(t *testing.T) {
 	gotBlk, ok := vm.innerBlkCache.Get(blkNearTip.ID())
 	require.True(ok)
 	require.Equal(mockInnerBlkNearTip, gotBlk)
-	require.Zero(vm.lastAcceptedHeight)
+	require.Zero(vm.lastAcceptedHeight.Load())
 
 	// Clear the cache
 	vm.innerBlkCache.Flush()
 
 	// Advance the tip height
-	vm.lastAcceptedHeight = innerBlkCacheSize + 1
+	vm.lastAcceptedHeight.Store(innerBlkCacheSize + 1)
 
 	// Parse the block again. This time it shouldn't be cached
 	// because it's not close to the tip.
@@ -20,6 +20,7 @@ var (
 	_ snowman.Block           = (*tracedBlock)(nil)
 	_ snowman.OracleBlock     = (*tracedBlock)(nil)
 	_ block.WithVerifyContext = (*tracedBlock)(nil)
+	_ block.WithPreVerify     = (*tracedBlock)(nil)
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
 )
@@ -40,6 +41,21 @@ func (b *tracedBlock) Verify(ctx context.Context) error {
 	return b.Block.Verify(ctx)
 }
 
+func (b *tracedBlock) PreVerify(ctx context.Context) error {
+	blkWithPreVerify, ok := b.Block.(block.WithPreVerify)
+	if !ok {
+		return nil
+	}
+
+	ctx, span := b.vm.tracer.Start(ctx, b.vm.preVerifyTag, oteltrace.WithAttributes(
+		attribute.Stringer("blkID", b.ID()),
+		attribute.Int64("height", int64(b.Height())),
+	))
+	defer span.End()
+
+	return blkWithPreVerify.PreVerify(ctx)
+}
+
 func (b *tracedBlock) Accept(ctx context.Context) error {
 	ctx, span := b.vm.tracer.Start(ctx, b.vm.acceptTag, oteltrace.WithAttributes(
 		attribute.Stringer("blkID", b.ID()),
@@ -24,6 +24,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*blockVM)(nil)
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.ParallelParsingChainVM       = (*blockVM)(nil)
 )
 
 type blockVM struct {
@@ -31,6 +32,7 @@ type blockVM struct {
 	buildBlockVM block.BuildBlockWithContextChainVM
 	batchedVM    block.BatchedChainVM
 	ssVM         block.StateSyncableVM
+	ppVM         block.ParallelParsingChainVM
 	// ChainVM tags
 	initializeTag              string
 	buildBlockTag              string
@@ -39,6 +41,7 @@ type blockVM struct {
 	setPreferenceTag           string
 	lastAcceptedTag            string
 	verifyTag                  string
+	preVerifyTag               string
 	acceptTag                  string
 	rejectTag                  string
 	optionsTag                 string
@@ -64,11 +67,13 @@ func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainV
 	buildBlockVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	ppVM, _ := vm.(block.ParallelParsingChainVM)
 	return &blockVM{
 		ChainVM:                       vm,
 		buildBlockVM:                  buildBlockVM,
 		batchedVM:                     batchedVM,
 		ssVM:                          ssVM,
+		ppVM:                          ppVM,
 		initializeTag:                 name + ".initialize",
 		buildBlockTag:                 name + ".buildBlock",
 		parseBlockTag:                 name + ".parseBlock",
@@ -76,6 +81,7 @@ func NewBlockVM(vm block.ChainVM, name string, tracer trace.Tracer) block.ChainV
 		setPreferenceTag:              name + ".setPreference",
 		lastAcceptedTag:               name + ".lastAccepted",
 		verifyTag:                     name + ".verify",
+		preVerifyTag:                  name + ".preVerify",
 		acceptTag:                     name + ".accept",
 		rejectTag:                     name + ".reject",
 		optionsTag:                    name + ".options",
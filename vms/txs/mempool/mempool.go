@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/f01c5700/avalanchego/cache"
 	"github.com/f01c5700/avalanchego/ids"
 	"github.com/f01c5700/avalanchego/utils/linked"
 	"github.com/f01c5700/avalanchego/utils/set"
 	"github.com/f01c5700/avalanchego/utils/setmap"
+	"github.com/f01c5700/avalanchego/utils/timer/mockable"
 	"github.com/f01c5700/avalanchego/utils/units"
 )
 
@@ -57,12 +59,20 @@ type Mempool[T Tx] interface {
 	// Iterate iterates over the txs until f returns false
 	Iterate(f func(tx T) bool)
 
+	// GetAddedTime returns the time [txID] was added to the mempool. Returns
+	// false if [txID] isn't in the mempool.
+	GetAddedTime(txID ids.ID) (time.Time, bool)
+
 	// Note: dropped txs are added to droppedTxIDs but are not evicted from
 	// unissued decision/staker txs. This allows previously dropped txs to be
 	// possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
 
+	// IterateDropped iterates over the most recently dropped txs, from the
+	// least to the most recently dropped, until f returns false.
+	IterateDropped(f func(txID ids.ID, reason error) bool)
+
 	// Len returns the number of txs in the mempool.
 	Len() int
 }
@@ -70,10 +80,15 @@ type Mempool[T Tx] interface {
 type mempool[T Tx] struct {
 	lock           sync.RWMutex
 	unissuedTxs    *linked.Hashmap[ids.ID, T]
+	addedTimes     map[ids.ID]time.Time           // TxID -> Time added
 	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
 	bytesAvailable int
 	droppedTxIDs   *cache.LRU[ids.ID, error] // TxID -> Verification error
+	// droppedOrder records the most recently dropped txIDs, from the least to
+	// the most recently dropped, so that they can be iterated over.
+	droppedOrder *linked.Hashmap[ids.ID, struct{}]
 
+	clock   mockable.Clock
 	metrics Metrics
 }
 
@@ -82,9 +97,11 @@ func New[T Tx](
 ) *mempool[T] {
 	m := &mempool[T]{
 		unissuedTxs:    linked.NewHashmap[ids.ID, T](),
+		addedTimes:     make(map[ids.ID]time.Time),
 		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
 		bytesAvailable: maxMempoolSize,
 		droppedTxIDs:   &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		droppedOrder:   linked.NewHashmap[ids.ID, struct{}](),
 		metrics:        metrics,
 	}
 	m.updateMetrics()
@@ -131,6 +148,7 @@ func (m *mempool[T]) Add(tx T) error {
 
 	m.bytesAvailable -= txSize
 	m.unissuedTxs.Put(txID, tx)
+	m.addedTimes[txID] = m.clock.Time()
 	m.updateMetrics()
 
 	// Mark these UTXOs as consumed in the mempool
@@ -138,6 +156,7 @@ func (m *mempool[T]) Add(tx T) error {
 
 	// An added tx must not be marked as dropped.
 	m.droppedTxIDs.Evict(txID)
+	m.droppedOrder.Delete(txID)
 	return nil
 }
 
@@ -157,6 +176,7 @@ func (m *mempool[T]) Remove(txs ...T) {
 		// If the transaction is in the mempool, remove it.
 		if _, ok := m.consumedUTXOs.DeleteKey(txID); ok {
 			m.unissuedTxs.Delete(txID)
+			delete(m.addedTimes, txID)
 			m.bytesAvailable += tx.Size()
 			continue
 		}
@@ -166,6 +186,7 @@ func (m *mempool[T]) Remove(txs ...T) {
 		for _, removed := range m.consumedUTXOs.DeleteOverlapping(inputs) {
 			tx, _ := m.unissuedTxs.Get(removed.Key)
 			m.unissuedTxs.Delete(removed.Key)
+			delete(m.addedTimes, removed.Key)
 			m.bytesAvailable += tx.Size()
 		}
 	}
@@ -192,19 +213,32 @@ func (m *mempool[T]) Iterate(f func(T) bool) {
 	}
 }
 
+func (m *mempool[_]) GetAddedTime(txID ids.ID) (time.Time, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	addedTime, ok := m.addedTimes[txID]
+	return addedTime, ok
+}
+
 func (m *mempool[_]) MarkDropped(txID ids.ID, reason error) {
 	if errors.Is(reason, ErrMempoolFull) {
 		return
 	}
 
-	m.lock.RLock()
-	defer m.lock.RUnlock()
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
 	if _, ok := m.unissuedTxs.Get(txID); ok {
 		return
 	}
 
 	m.droppedTxIDs.Put(txID, reason)
+	m.droppedOrder.Put(txID, struct{}{})
+	if m.droppedOrder.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedOrder.Oldest()
+		m.droppedOrder.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool[_]) GetDropReason(txID ids.ID) error {
@@ -212,6 +246,24 @@ func (m *mempool[_]) GetDropReason(txID ids.ID) error {
 	return err
 }
 
+func (m *mempool[_]) IterateDropped(f func(ids.ID, error) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	it := m.droppedOrder.NewIterator()
+	for it.Next() {
+		txID := it.Key()
+		// The reason may have already been evicted from the cache.
+		reason, ok := m.droppedTxIDs.Get(txID)
+		if !ok {
+			continue
+		}
+		if !f(txID, reason) {
+			return
+		}
+	}
+}
+
 func (m *mempool[_]) Len() int {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -6,6 +6,7 @@ package mempool
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -255,6 +256,82 @@ func TestDropped(t *testing.T) {
 	require.NoError(mempool.GetDropReason(txID))
 }
 
+func TestGetAddedTime(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+
+	now := time.Unix(1607133207, 0)
+	mempool.clock.Set(now)
+
+	tx0 := newTx(0, 32)
+	_, ok := mempool.GetAddedTime(tx0.ID())
+	require.False(ok)
+
+	require.NoError(mempool.Add(tx0))
+
+	addedTime, ok := mempool.GetAddedTime(tx0.ID())
+	require.True(ok)
+	require.Equal(now, addedTime)
+
+	// Removing a conflicting tx removes the added time
+	conflictingTx := newTx(0, 32)
+	mempool.Remove(conflictingTx)
+
+	_, ok = mempool.GetAddedTime(tx0.ID())
+	require.False(ok)
+}
+
+func TestIterateDropped(t *testing.T) {
+	require := require.New(t)
+
+	mempool := newMempool()
+
+	type droppedTx struct {
+		txID   ids.ID
+		reason error
+	}
+	var (
+		iteratedTxs []droppedTx
+		maxLen      = 2
+	)
+	addTxs := func(txID ids.ID, reason error) bool {
+		iteratedTxs = append(iteratedTxs, droppedTx{
+			txID:   txID,
+			reason: reason,
+		})
+		return len(iteratedTxs) < maxLen
+	}
+	mempool.IterateDropped(addTxs)
+	require.Empty(iteratedTxs)
+
+	var (
+		txID0   = ids.GenerateTestID()
+		txID1   = ids.GenerateTestID()
+		txID2   = ids.GenerateTestID()
+		testErr = errors.New("test")
+	)
+	mempool.MarkDropped(txID0, testErr)
+	mempool.MarkDropped(txID1, testErr)
+	mempool.MarkDropped(txID2, testErr)
+
+	mempool.IterateDropped(addTxs)
+	require.Equal([]droppedTx{{txID0, testErr}, {txID1, testErr}}, iteratedTxs)
+
+	// Only the most recently dropped txs are kept
+	for i := 0; i < droppedTxIDsCacheSize; i++ {
+		mempool.MarkDropped(ids.GenerateTestID(), testErr)
+	}
+	require.NoError(mempool.GetDropReason(txID0))
+
+	var numDropped int
+	mempool.IterateDropped(func(ids.ID, error) bool {
+		numDropped++
+		return true
+	})
+	require.Equal(droppedTxIDsCacheSize, numDropped)
+}
+
 func newTxs(num int, size int) []*dummyTx {
 	txs := make([]*dummyTx, num)
 	for i := range txs {